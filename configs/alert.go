@@ -0,0 +1,34 @@
+package configs
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Battery percentage under which a user's latest point raises an alert, zero turns it off
+var AlertBatteryThreshold = 15
+
+// How long a user sending live points may stay quiet before an alert, zero turns it off
+var AlertSilenceAfter = 30 * time.Minute
+
+// Users whose latest point is older than this are not watched anymore
+var AlertLookback = 24 * time.Hour
+
+func InitAlert() {
+	if raw := os.Getenv("ALERT_BATTERY_THRESHOLD"); raw != "" {
+		threshold, err := strconv.Atoi(raw)
+		if err != nil || threshold < 0 || threshold > 100 {
+			log.Fatalf("ALERT_BATTERY_THRESHOLD is not a percentage: %s\n", raw)
+		}
+		AlertBatteryThreshold = threshold
+	}
+	if raw := os.Getenv("ALERT_SILENCE_AFTER"); raw != "" {
+		after, err := time.ParseDuration(raw)
+		if err != nil || after < 0 || after >= AlertLookback {
+			log.Fatalf("ALERT_SILENCE_AFTER is not a valid duration below %s: %s\n", AlertLookback, raw)
+		}
+		AlertSilenceAfter = after
+	}
+}
@@ -0,0 +1,50 @@
+package configs
+
+import (
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// Every track & share request must carry an app's API key when true
+var ApiKeyRequired = false
+
+// SHA-256 hex of the keys set in the env, by their app source. Listing two hashes of the
+// same app keeps the old key working while the clients move to the new one.
+var ApiKeyHashes = map[string]string{}
+
+// How long the app's previous keys keep working after a rotation
+var ApiKeyRotateOverlap = 24 * time.Hour
+
+// How long a key read from the repository is trusted before it is read again
+var ApiKeyCacheTTL = time.Minute
+
+func InitApiKey() {
+	ApiKeyRequired = os.Getenv("API_KEY_REQUIRED") == "true"
+
+	// Env Keys : app_source:sha256hex, comma separated
+	if raw := os.Getenv("API_KEYS"); raw != "" {
+		for _, item := range strings.Split(raw, ",") {
+			appsSource, hash, ok := strings.Cut(strings.TrimSpace(item), ":")
+			if !ok || len(hash) != 64 || !containsAppsSource(appsSource) {
+				log.Fatalf("API_KEYS item is not app_source:sha256hex: %s\n", item)
+			}
+			ApiKeyHashes[strings.ToLower(hash)] = appsSource
+		}
+	}
+
+	if !ApiKeyRequired {
+		log.Println("API_KEY_REQUIRED is not true, API keys are not checked")
+	}
+}
+
+func containsAppsSource(appsSource string) bool {
+	for _, app := range AppsSources {
+		if app == appsSource {
+			return true
+		}
+	}
+
+	return false
+}
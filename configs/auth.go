@@ -0,0 +1,56 @@
+package configs
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/MicahParks/keyfunc"
+)
+
+// Keys verifying the bearer tokens, nil leaves the API open as before
+var AuthJWKS *keyfunc.JWKS
+
+// Claim that lets a token touch every user's data when it is true
+var AuthAdminClaim = "admin"
+
+// How often a remote key set is fetched again, unknown key ids also trigger a fetch
+var AuthJWKSRefresh = time.Hour
+
+func InitAuth() {
+	if claim := os.Getenv("JWT_ADMIN_CLAIM"); claim != "" {
+		AuthAdminClaim = claim
+	}
+
+	if url := os.Getenv("JWT_JWKS_URL"); url != "" {
+		jwks, err := keyfunc.Get(url, keyfunc.Options{
+			RefreshInterval:   AuthJWKSRefresh,
+			RefreshRateLimit:  time.Minute,
+			RefreshTimeout:    10 * time.Second,
+			RefreshUnknownKID: true,
+			RefreshErrorHandler: func(err error) {
+				log.Printf("failed to refresh JWKS: %v\n", err)
+			},
+		})
+		if err != nil {
+			log.Fatalf("failed to get JWKS from %s: %v\n", url, err)
+		}
+		AuthJWKS = jwks
+		return
+	}
+
+	if fileName := os.Getenv("JWT_JWKS_FILE"); fileName != "" {
+		raw, err := os.ReadFile(fileName)
+		if err != nil {
+			log.Fatalf("failed to read JWKS file: %v\n", err)
+		}
+		jwks, err := keyfunc.NewJSON(raw)
+		if err != nil {
+			log.Fatalf("JWKS file is not valid: %v\n", err)
+		}
+		AuthJWKS = jwks
+		return
+	}
+
+	log.Println("JWT_JWKS_URL and JWT_JWKS_FILE are not set, authentication is disabled")
+}
@@ -1,5 +1,7 @@
 package configs
 
+import "time"
+
 var ResponseMessages = map[string]string{
 	"post":        "created",
 	"put":         "updated",
@@ -16,3 +18,48 @@ var AppsSources = []string{"pinmarker", "mi-fik", "myride", "kumande"}
 
 // Doc Name
 var TrackDoc = "tracks"
+var TrackGeoDoc = "tracks_geo"
+var TrackLatestDoc = "tracks_latest"
+var TrackTrashDoc = "tracks_trash"
+
+// Most users a bulk latest track request may ask for
+var TrackLatestMaxUsers = 100
+
+// Most tracks a Firebase cursor page may read, the attribute filters are applied after the read
+var TrackCursorScanMax = 2000
+
+// Live Stream : Points a subscriber may fall behind & the idle time between heartbeats
+var TrackStreamBuffer = 64
+var TrackStreamHeartbeat = 15 * time.Second
+
+// Repository Driver
+var RepositoryDrivers = []string{"firebase", "gorm", "memory"}
+
+// Export Format
+var ExportFormats = []string{"gpx", "kml", "geojson", "csv"}
+
+// Import Format
+var ImportFormats = []string{"gpx", "geojson"}
+var ImportMaxSize int64 = 20 << 20
+
+// Geofence
+var GeofenceDoc = "geofences"
+var GeofenceEventDoc = "geofence_events"
+var GeofenceTypes = []string{"circle", "polygon"}
+
+// Alert
+var TrackAlertDoc = "track_alerts"
+var AlertTypes = []string{"low_battery", "silence"}
+
+// Share
+var ShareDoc = "shares"
+
+// API Key
+var ApiKeyDoc = "api_keys"
+var ApiKeyPrefix = "pmk_"
+
+// Usage
+var TrackUsageDoc = "track_usages"
+
+// User Data
+var UserErasureDoc = "user_erasures"
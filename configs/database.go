@@ -0,0 +1,46 @@
+package configs
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+var Database *gorm.DB
+
+func InitDatabase() {
+	driver := os.Getenv("DB_DRIVER")
+	dsn := os.Getenv("DB_DSN")
+
+	var dialector gorm.Dialector
+	switch driver {
+	case "postgres":
+		dialector = postgres.Open(dsn)
+	case "sqlite", "":
+		if dsn == "" {
+			dsn = "pinmarker.db"
+		}
+		dialector = sqlite.Open(dsn)
+	default:
+		log.Fatalf("Database init error: driver %s is not supported\n", driver)
+	}
+
+	// Translate Error : Duplicated keys & constraints come back as gorm errors
+	db, err := gorm.Open(dialector, &gorm.Config{TranslateError: true})
+	if err != nil {
+		log.Fatalf("Database init error: %v\n", err)
+	}
+	Database = db
+}
+
+func GormDB() (*gorm.DB, error) {
+	if Database == nil {
+		return nil, fmt.Errorf("failed to connect to database: not initialized")
+	}
+
+	return Database, nil
+}
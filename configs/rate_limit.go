@@ -0,0 +1,67 @@
+package configs
+
+import (
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// RateLimit is a token bucket refilled by Rate tokens a second up to Burst, a zero rate
+// turns it off
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// Limits per route name, each app source, user & client IP has its own bucket
+var RateLimits = map[string]RateLimit{
+	"create_track":       {Rate: 5, Burst: 20},
+	"create_track_multi": {Rate: 1, Burst: 5},
+	"import_track":       {Rate: 0.2, Burst: 5},
+	"read_track":         {Rate: 10, Burst: 30},
+	"read_shared":        {Rate: 2, Burst: 10},
+}
+
+// Proxies whose X-Forwarded-For is believed for the client IP, none by default so a
+// client can't pick its own bucket
+var TrustedProxies []string
+
+// Points a user may create a UTC day, zero turns the quota off
+var QuotaDailyPoints = 10000
+
+func InitRateLimit() {
+	// Env Limits : name=rate:burst, comma separated
+	if raw := os.Getenv("RATE_LIMITS"); raw != "" {
+		for _, item := range strings.Split(raw, ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(item), "=")
+			rateRaw, burstRaw, _ := strings.Cut(value, ":")
+			rate, err := strconv.ParseFloat(rateRaw, 64)
+			burst, errBurst := strconv.Atoi(burstRaw)
+			if _, ok := RateLimits[name]; !ok || err != nil || errBurst != nil || rate < 0 || burst < 1 {
+				log.Fatalf("RATE_LIMITS item is not name=rate:burst of a known route: %s\n", item)
+			}
+			RateLimits[name] = RateLimit{Rate: rate, Burst: burst}
+		}
+	}
+
+	// Env Trusted Proxies : IPs or CIDRs, comma separated
+	if raw := os.Getenv("TRUSTED_PROXIES"); raw != "" {
+		for _, item := range strings.Split(raw, ",") {
+			proxy := strings.TrimSpace(item)
+			if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+				log.Fatalf("TRUSTED_PROXIES item is not an IP or CIDR: %s\n", item)
+			}
+			TrustedProxies = append(TrustedProxies, proxy)
+		}
+	}
+
+	if raw := os.Getenv("QUOTA_DAILY_POINTS"); raw != "" {
+		points, err := strconv.Atoi(raw)
+		if err != nil || points < 0 {
+			log.Fatalf("QUOTA_DAILY_POINTS is not a valid number: %s\n", raw)
+		}
+		QuotaDailyPoints = points
+	}
+}
@@ -0,0 +1,40 @@
+package configs
+
+import (
+	"crypto/rand"
+	"log"
+	"os"
+	"time"
+)
+
+// Key signing the share tokens, a random one makes every token invalid on restart
+var ShareTokenSecret []byte
+
+// Lifetime of a share token when the request sets none, & the longest one allowed
+var ShareDefaultExpiry = time.Hour
+var ShareMaxExpiry = 7 * 24 * time.Hour
+
+// Most points a shared link returns, newest first
+var ShareMaxPoints = 1000
+
+// Without a from, a shared link also shows the points recorded this long before it was created
+var ShareRecentWindow = time.Hour
+
+// Track type a shared link returns, the user's other points stay private
+var ShareTrackType = "share-loc"
+
+func InitShareSecret() {
+	if raw := os.Getenv("SHARE_TOKEN_SECRET"); raw != "" {
+		if len(raw) < 32 {
+			log.Fatalf("SHARE_TOKEN_SECRET must be at least 32 characters\n")
+		}
+		ShareTokenSecret = []byte(raw)
+		return
+	}
+
+	ShareTokenSecret = make([]byte, 32)
+	if _, err := rand.Read(ShareTokenSecret); err != nil {
+		log.Fatalf("failed to generate share token secret: %v\n", err)
+	}
+	log.Println("SHARE_TOKEN_SECRET is not set, share links will stop working on restart")
+}
@@ -0,0 +1,28 @@
+package configs
+
+import (
+	"log"
+	"os"
+	"time"
+)
+
+// How far a device recorded_at may drift from the server received_at
+var TrackFutureTolerance = 5 * time.Minute
+var TrackPastTolerance = 7 * 24 * time.Hour
+
+func InitTrackTolerance() {
+	if raw := os.Getenv("TRACK_FUTURE_TOLERANCE"); raw != "" {
+		tolerance, err := time.ParseDuration(raw)
+		if err != nil || tolerance < 0 {
+			log.Fatalf("TRACK_FUTURE_TOLERANCE is not a valid duration: %s\n", raw)
+		}
+		TrackFutureTolerance = tolerance
+	}
+	if raw := os.Getenv("TRACK_PAST_TOLERANCE"); raw != "" {
+		tolerance, err := time.ParseDuration(raw)
+		if err != nil || tolerance < 0 {
+			log.Fatalf("TRACK_PAST_TOLERANCE is not a valid duration: %s\n", raw)
+		}
+		TrackPastTolerance = tolerance
+	}
+}
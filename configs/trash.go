@@ -0,0 +1,20 @@
+package configs
+
+import (
+	"log"
+	"os"
+	"strconv"
+)
+
+// Days a deleted track stays in the trash before it is purged for good
+var TrackTrashRetentionDays = 30
+
+func InitTrash() {
+	if raw := os.Getenv("TRASH_RETENTION_DAYS"); raw != "" {
+		days, err := strconv.Atoi(raw)
+		if err != nil || days < 1 {
+			log.Fatalf("TRASH_RETENTION_DAYS is not a valid number of days: %s\n", raw)
+		}
+		TrackTrashRetentionDays = days
+	}
+}
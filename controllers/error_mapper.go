@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"pinmarker/middlewares"
+	"pinmarker/repositories"
+	"pinmarker/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// responseError writes the failed response of a service error, every controller maps
+// repository errors to their status code here
+func responseError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrTrackNotFound), errors.Is(err, repositories.ErrGeofenceNotFound),
+		errors.Is(err, repositories.ErrShareNotFound), errors.Is(err, utils.ErrShareTokenInvalid):
+		utils.MessageResponseErrorBuild(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, utils.ErrShareExpired):
+		utils.MessageResponseErrorBuild(c, http.StatusGone, err.Error())
+	case errors.Is(err, repositories.ErrQuotaExceeded):
+		c.Header("Retry-After", utils.RetryAfter(time.Until(utils.QuotaResetsAt(time.Now()))))
+		utils.MessageResponseErrorBuild(c, http.StatusTooManyRequests, err.Error())
+	case errors.Is(err, repositories.ErrTrackConflict):
+		utils.MessageResponseErrorBuild(c, http.StatusConflict, err.Error())
+	case errors.Is(err, repositories.ErrTrackInvalid):
+		utils.MessageResponseErrorBuild(c, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, repositories.ErrBackendUnavailable):
+		utils.MessageResponseErrorBuild(c, http.StatusServiceUnavailable, err.Error())
+	case errors.Is(err, utils.ErrCursorInvalid):
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+	default:
+		utils.BuildErrorMessage(c, err.Error())
+	}
+}
+
+// authorizeOwner writes the forbidden response when the token's subject is not createdBy
+// and carries no admin claim
+func authorizeOwner(c *gin.Context, createdBy uuid.UUID) bool {
+	if middlewares.AuthOwner(c, createdBy) {
+		return true
+	}
+	utils.MessageResponseErrorBuild(c, http.StatusForbidden, "created by does not match the token")
+
+	return false
+}
+
+// authorizeApp writes the forbidden response when the API key belongs to another app
+func authorizeApp(c *gin.Context, appsSource string) bool {
+	if middlewares.ApiKeyApp(c, appsSource) {
+		return true
+	}
+	utils.MessageResponseErrorBuild(c, http.StatusForbidden, utils.ErrApiKeyForbidden.Error())
+
+	return false
+}
+
+// authorizeAdmin writes the forbidden response when the token carries no admin claim, always
+// true when the route is not behind AuthMiddleware
+func authorizeAdmin(c *gin.Context) bool {
+	if middlewares.AuthSubject(c) == nil || middlewares.AuthAdmin(c) {
+		return true
+	}
+	utils.MessageResponseErrorBuild(c, http.StatusForbidden, "admin token is required")
+
+	return false
+}
+
+// authorizeSpatial scopes an area query to the token's subject unless it carries the admin
+// claim, a created_by of another user is forbidden
+func authorizeSpatial(c *gin.Context, query *utils.SpatialQuery) bool {
+	if query.CreatedBy != nil && !authorizeOwner(c, *query.CreatedBy) {
+		return false
+	}
+	if subject := middlewares.AuthSubject(c); subject != nil && !middlewares.AuthAdmin(c) {
+		query.CreatedBy = subject
+	}
+
+	return true
+}
@@ -0,0 +1,356 @@
+package controllers
+
+import (
+	"net/http"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"pinmarker/services"
+	"pinmarker/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type GeofenceController struct {
+	GeofenceService services.GeofenceService
+}
+
+func NewGeofenceController(geofenceService services.GeofenceService) *GeofenceController {
+	return &GeofenceController{GeofenceService: geofenceService}
+}
+
+// @Summary      Create Geofence
+// @Description  Create a circle or polygon geofence, the user's next live track sets whether they are inside
+// @Tags         Geofence
+// @Accept       json
+// @Produce      json
+// @Param        request  body  entities.RequestCreateGeofence  true  "Post Geofence Request Body"
+// @Success      201  {object}  entities.ResponseCreateGeofence
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/geofences [post]
+func (gc *GeofenceController) CreateGeofence(c *gin.Context) {
+	// Model
+	var req entities.RequestCreateGeofence
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Validator Field
+	if err := utils.ValidatorCreateGeofence(req); err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Authorization : Own App & Data
+	if !authorizeApp(c, req.AppsSource) || !authorizeOwner(c, req.CreatedBy) {
+		return
+	}
+
+	// Service : Create Geofence
+	fence := &entities.Geofence{
+		AppsSource: req.AppsSource,
+		CreatedBy:  req.CreatedBy,
+	}
+	utils.ConverterRequestToGeofence(req.RequestUpdateGeofence, fence)
+	if err := gc.GeofenceService.CreateGeofence(fence); err != nil {
+		responseError(c, err)
+		return
+	}
+
+	// Response
+	utils.MessageResponseBuild(c, "success", "geofence", "post", http.StatusCreated, fence, nil)
+}
+
+// @Summary      Get All Geofence
+// @Description  Returns every geofence of the user, oldest first
+// @Tags         Geofence
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entities.ResponseGetAllGeofence
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/geofences/{app_source}/{created_by} [get]
+// @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
+// @Param        created_by  path  string  true  "created_by must be UUID"
+func (gc *GeofenceController) GetAllGeofence(c *gin.Context) {
+	// Param
+	appsSource := c.Param("app_source")
+	createdByRaw := c.Param("created_by")
+
+	// Convert to UUID
+	createdBy, err := uuid.Parse(createdByRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
+		return
+	}
+
+	// Validator : App Source
+	if !utils.ValidatorContains(configs.AppsSources, appsSource) {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is not valid")
+		return
+	}
+
+	// Authorization : Own Data
+	if !authorizeOwner(c, createdBy) {
+		return
+	}
+
+	// Service : Get All Geofence
+	fences, err := gc.GeofenceService.GetAllGeofence(appsSource, createdBy)
+	if err != nil {
+		responseError(c, err)
+		return
+	}
+
+	// Response
+	utils.MessageResponseBuild(c, "success", "geofence", "get", http.StatusOK, fences, nil)
+}
+
+// @Summary      Get Geofence By ID
+// @Description  Returns one geofence with the user's last known state
+// @Tags         Geofence
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entities.ResponseGetGeofence
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      404  {object}  entities.ResponseNotFound
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/geofences/{app_source}/{created_by}/{geofence_id} [get]
+// @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
+// @Param        created_by  path  string  true  "created_by must be UUID"
+// @Param        geofence_id  path  string  true  "geofence_id must be UUID"
+func (gc *GeofenceController) GetGeofenceById(c *gin.Context) {
+	// Param
+	appsSource := c.Param("app_source")
+	createdByRaw := c.Param("created_by")
+	geofenceIdRaw := c.Param("geofence_id")
+
+	// Convert to UUID
+	createdBy, err := uuid.Parse(createdByRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
+		return
+	}
+	geofenceID, err := uuid.Parse(geofenceIdRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "geofence id is not valid")
+		return
+	}
+
+	// Validator : App Source
+	if !utils.ValidatorContains(configs.AppsSources, appsSource) {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is not valid")
+		return
+	}
+
+	// Authorization : Own Data
+	if !authorizeOwner(c, createdBy) {
+		return
+	}
+
+	// Service : Get Geofence By ID
+	fence, err := gc.GeofenceService.GetGeofenceByID(appsSource, createdBy, geofenceID)
+	if err != nil {
+		responseError(c, err)
+		return
+	}
+
+	// Response
+	utils.MessageResponseBuild(c, "success", "geofence", "get", http.StatusOK, fence, nil)
+}
+
+// @Summary      Update Geofence By ID
+// @Description  Replace the name and shape of a geofence, the user's state is decided again by the next live track
+// @Tags         Geofence
+// @Accept       json
+// @Produce      json
+// @Param        request  body  entities.RequestUpdateGeofence  true  "Put Geofence Request Body"
+// @Success      200  {object}  entities.ResponseUpdateGeofence
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      404  {object}  entities.ResponseNotFound
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/geofences/{app_source}/{created_by}/{geofence_id} [put]
+// @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
+// @Param        created_by  path  string  true  "created_by must be UUID"
+// @Param        geofence_id  path  string  true  "geofence_id must be UUID"
+func (gc *GeofenceController) UpdateGeofenceById(c *gin.Context) {
+	// Param
+	appsSource := c.Param("app_source")
+	createdByRaw := c.Param("created_by")
+	geofenceIdRaw := c.Param("geofence_id")
+
+	// Convert to UUID
+	createdBy, err := uuid.Parse(createdByRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
+		return
+	}
+	geofenceID, err := uuid.Parse(geofenceIdRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "geofence id is not valid")
+		return
+	}
+
+	// Validator : App Source
+	if !utils.ValidatorContains(configs.AppsSources, appsSource) {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is not valid")
+		return
+	}
+
+	// Authorization : Own Data
+	if !authorizeOwner(c, createdBy) {
+		return
+	}
+
+	// Model
+	var req entities.RequestUpdateGeofence
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Validator Field
+	if err := utils.ValidatorGeofence(req); err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Update Geofence
+	fence, err := gc.GeofenceService.UpdateGeofence(req, appsSource, createdBy, geofenceID)
+	if err != nil {
+		responseError(c, err)
+		return
+	}
+
+	// Response
+	utils.MessageResponseBuild(c, "success", "geofence", "put", http.StatusOK, fence, nil)
+}
+
+// @Summary      Delete Geofence By ID
+// @Description  Delete geofence by given id, its past events are kept
+// @Tags         Geofence
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entities.ResponseDeleteGeofence
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      404  {object}  entities.ResponseNotFound
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/geofences/{app_source}/{created_by}/{geofence_id} [delete]
+// @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
+// @Param        created_by  path  string  true  "created_by must be UUID"
+// @Param        geofence_id  path  string  true  "geofence_id must be UUID"
+func (gc *GeofenceController) DeleteGeofenceById(c *gin.Context) {
+	// Param
+	appsSource := c.Param("app_source")
+	createdByRaw := c.Param("created_by")
+	geofenceIdRaw := c.Param("geofence_id")
+
+	// Convert to UUID
+	createdBy, err := uuid.Parse(createdByRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
+		return
+	}
+	geofenceID, err := uuid.Parse(geofenceIdRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "geofence id is not valid")
+		return
+	}
+
+	// Validator : App Source
+	if !utils.ValidatorContains(configs.AppsSources, appsSource) {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is not valid")
+		return
+	}
+
+	// Authorization : Own Data
+	if !authorizeOwner(c, createdBy) {
+		return
+	}
+
+	// Service : Delete Geofence By ID
+	if err := gc.GeofenceService.DeleteGeofenceByID(appsSource, createdBy, geofenceID); err != nil {
+		responseError(c, err)
+		return
+	}
+
+	utils.MessageResponseBuild(c, "success", "geofence", "soft delete", http.StatusOK, nil, nil)
+}
+
+// @Summary      Get All Geofence Event
+// @Description  Returns the user's enter and exit events, newest first
+// @Tags         Geofence
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entities.ResponseGetAllGeofenceEvent
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/geofences/{app_source}/{created_by}/events [get]
+// @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
+// @Param        created_by  path  string  true  "created_by must be UUID"
+// @Param        geofence_id  query  string  false  "only the events of this geofence"
+// @Param        from  query  string  false  "RFC3339 timestamp, only events recorded at or after it"
+// @Param        to  query  string  false  "RFC3339 timestamp, only events recorded at or before it"
+// @Param        limit  query  int  false  "maximum events, up to 1000 (default: 100)"
+func (gc *GeofenceController) GetAllGeofenceEvent(c *gin.Context) {
+	// Param
+	appsSource := c.Param("app_source")
+	createdByRaw := c.Param("created_by")
+
+	// Convert to UUID
+	createdBy, err := uuid.Parse(createdByRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
+		return
+	}
+
+	// Validator : App Source
+	if !utils.ValidatorContains(configs.AppsSources, appsSource) {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is not valid")
+		return
+	}
+
+	// Authorization : Own Data
+	if !authorizeOwner(c, createdBy) {
+		return
+	}
+
+	// Query
+	query, err := utils.GeofenceEventQueryBuilder(c)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Get All Geofence Event
+	events, err := gc.GeofenceService.GetAllGeofenceEvent(query, appsSource, createdBy)
+	if err != nil {
+		responseError(c, err)
+		return
+	}
+
+	// Response
+	metadata := gin.H{
+		"total": len(events),
+		"limit": query.Limit,
+	}
+	utils.MessageResponseBuild(c, "success", "geofence event", "get", http.StatusOK, events, metadata)
+}
@@ -0,0 +1,199 @@
+package controllers
+
+import (
+	"net/http"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"pinmarker/services"
+	"pinmarker/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ShareController struct {
+	ShareService services.ShareService
+}
+
+func NewShareController(shareService services.ShareService) *ShareController {
+	return &ShareController{ShareService: shareService}
+}
+
+// @Summary      Create Share
+// @Description  Mint a signed share token for the user's points, optionally limited to a time window. The token is only returned here.
+// @Tags         Share
+// @Accept       json
+// @Produce      json
+// @Param        request  body  entities.RequestCreateShare  true  "Post Share Request Body"
+// @Success      201  {object}  entities.ResponseCreateShare
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/shares [post]
+func (sc *ShareController) CreateShare(c *gin.Context) {
+	// Model
+	var req entities.RequestCreateShare
+
+	// Validator JSON
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Validator Field
+	if err := utils.ValidatorCreateShare(req); err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Authorization : Own App & Data
+	if !authorizeApp(c, req.AppsSource) || !authorizeOwner(c, req.CreatedBy) {
+		return
+	}
+
+	// Service : Create Share
+	share := utils.ConverterRequestToShare(req, time.Now())
+	token, err := sc.ShareService.CreateShare(share)
+	if err != nil {
+		responseError(c, err)
+		return
+	}
+
+	// Response
+	data := entities.ShareLinkToken{ShareLink: *share, Token: token}
+	utils.MessageResponseBuild(c, "success", "share", "post", http.StatusCreated, data, nil)
+}
+
+// @Summary      Get All Share
+// @Description  Returns the user's shares newest first, revoked and expired ones included, without their token
+// @Tags         Share
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entities.ResponseGetAllShare
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/shares/{app_source}/{created_by} [get]
+// @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
+// @Param        created_by  path  string  true  "created_by must be UUID"
+func (sc *ShareController) GetAllShare(c *gin.Context) {
+	// Param
+	appsSource := c.Param("app_source")
+	createdByRaw := c.Param("created_by")
+
+	// Convert to UUID
+	createdBy, err := uuid.Parse(createdByRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
+		return
+	}
+
+	// Validator : App Source
+	if !utils.ValidatorContains(configs.AppsSources, appsSource) {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is not valid")
+		return
+	}
+
+	// Authorization : Own Data
+	if !authorizeOwner(c, createdBy) {
+		return
+	}
+
+	// Service : Get All Share
+	shares, err := sc.ShareService.GetAllShare(appsSource, createdBy)
+	if err != nil {
+		responseError(c, err)
+		return
+	}
+
+	// Response
+	utils.MessageResponseBuild(c, "success", "share", "get", http.StatusOK, shares, nil)
+}
+
+// @Summary      Revoke Share By ID
+// @Description  Revoke a share before it expires, its token stops resolving right away
+// @Tags         Share
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entities.ResponseRevokeShare
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      404  {object}  entities.ResponseNotFound
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/shares/{app_source}/{created_by}/{share_id} [delete]
+// @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
+// @Param        created_by  path  string  true  "created_by must be UUID"
+// @Param        share_id  path  string  true  "share_id must be UUID"
+func (sc *ShareController) RevokeShareById(c *gin.Context) {
+	// Param
+	appsSource := c.Param("app_source")
+	createdByRaw := c.Param("created_by")
+	shareIdRaw := c.Param("share_id")
+
+	// Convert to UUID
+	createdBy, err := uuid.Parse(createdByRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
+		return
+	}
+	shareID, err := uuid.Parse(shareIdRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "share id is not valid")
+		return
+	}
+
+	// Validator : App Source
+	if !utils.ValidatorContains(configs.AppsSources, appsSource) {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is not valid")
+		return
+	}
+
+	// Authorization : Own Data
+	if !authorizeOwner(c, createdBy) {
+		return
+	}
+
+	// Service : Revoke Share
+	if err := sc.ShareService.RevokeShare(appsSource, createdBy, shareID); err != nil {
+		responseError(c, err)
+		return
+	}
+
+	utils.MessageResponseBuild(c, "success", "share", "soft delete", http.StatusOK, nil, nil)
+}
+
+// @Summary      Get Shared Track
+// @Description  Public read of a share token, returns the newest share-loc points of the shared window. Without a from, the window starts an hour before the share was created.
+// @Tags         Share
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entities.ResponseGetSharedTrack
+// @Failure      404  {object}  entities.ResponseNotFound
+// @Failure      410  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/shared/{token} [get]
+// @Param        token  path  string  true  "share token"
+func (sc *ShareController) GetSharedTrack(c *gin.Context) {
+	// Param
+	token := c.Param("token")
+
+	// Service : Get Shared Track
+	share, tracks, err := sc.ShareService.GetSharedTrack(token)
+	if err != nil {
+		responseError(c, err)
+		return
+	}
+
+	// Response
+	metadata := gin.H{
+		"total":      len(tracks),
+		"app_source": share.AppsSource,
+		"created_by": share.CreatedBy,
+		"expires_at": share.ExpiresAt,
+	}
+	utils.MessageResponseBuild(c, "success", "track", "get", http.StatusOK, tracks, metadata)
+}
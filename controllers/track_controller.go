@@ -1,26 +1,30 @@
 package controllers
 
 import (
-	"errors"
 	"fmt"
+	"io"
+	"log"
 	"math"
 	"net/http"
 	"pinmarker/configs"
 	"pinmarker/entities"
+	"pinmarker/middlewares"
 	"pinmarker/services"
 	"pinmarker/utils"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"gorm.io/gorm"
 )
 
 type TrackController struct {
 	TrackService services.TrackService
+	TrackHub     services.TrackHub
+	QuotaService services.QuotaService
 }
 
-func NewTrackController(trackService services.TrackService) *TrackController {
-	return &TrackController{TrackService: trackService}
+func NewTrackController(trackService services.TrackService, trackHub services.TrackHub, quotaService services.QuotaService) *TrackController {
+	return &TrackController{TrackService: trackService, TrackHub: trackHub, QuotaService: quotaService}
 }
 
 // @Summary      Create Track
@@ -29,12 +33,18 @@ func NewTrackController(trackService services.TrackService) *TrackController {
 // @Accept       json
 // @Produce      json
 // @Param        request  body  entities.RequestCreateTrack  true  "Post Track Request Body"
+// @Param        Idempotency-Key  header  string  false  "retrying with the same key returns the original track instead of a duplicate"
 // @Success      201  {object}  entities.ResponseCreateTrack
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
 // @Failure      404  {object}  entities.ResponseBadRequest
+// @Failure      409  {object}  entities.ResponseBadRequest
+// @Failure      422  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
 // @Router       /api/v1/tracks [post]
 func (tr *TrackController) CreateTrack(c *gin.Context) {
 	// Model
-	var req entities.Track
+	var req entities.RequestCreateTrack
 
 	// Validator JSON
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -43,48 +53,35 @@ func (tr *TrackController) CreateTrack(c *gin.Context) {
 	}
 
 	// Validator Field
-	if req.TrackLat == "" {
-		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "track latitude is required")
-		return
-	}
-	if req.TrackLong == "" {
-		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "track longitude is required")
-		return
-	}
-	if req.TrackType == "" {
-		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "track type is required")
-		return
-	}
-	if req.AppsSource == "" {
-		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is required")
+	if err := utils.ValidatorTrack(req); err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Validator UUID
-	if req.CreatedBy == uuid.Nil {
-		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is required and must be a valid UUID")
+	// Authorization : Own App & Data
+	if !authorizeApp(c, req.AppsSource) || !authorizeOwner(c, req.CreatedBy) {
 		return
 	}
 
-	// Validator : Track Type & Apps Source
-	if !utils.ValidatorContains(configs.TrackTypes, req.TrackType) {
-		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "track type is not valid")
+	// Idempotency : Derive the id from the key when the client sent none
+	key := c.GetHeader("Idempotency-Key")
+	if err := utils.ValidatorIdempotencyKey(key); err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
 		return
 	}
-	if !utils.ValidatorContains(configs.AppsSources, req.AppsSource) {
-		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is not valid")
-		return
+	track := utils.ConverterRequestToTrack(req)
+	if key != "" && track.ID == uuid.Nil {
+		track.ID, _ = utils.IdempotencyTrackID(key, "track", track.AppsSource, track.CreatedBy, 0)
 	}
 
 	// Service : Create Track
-	err := tr.TrackService.CreateTrack(&req)
-	if err != nil {
-		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+	if err := tr.TrackService.CreateTrack(track); err != nil {
+		responseError(c, err)
 		return
 	}
 
 	// Response
-	utils.MessageResponseBuild(c, "success", "track", "post", http.StatusCreated, &req, nil)
+	utils.MessageResponseBuild(c, "success", "track", "post", http.StatusCreated, track, nil)
 }
 
 // @Summary      Create Track Multiple
@@ -93,64 +90,176 @@ func (tr *TrackController) CreateTrack(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        request  body  entities.RequestCreateTrackMulti  true  "Post Track Multiple Request Body"
+// @Param        partial  query  bool  false  "write the valid items and report a result per index instead of rejecting the whole batch"
+// @Param        Idempotency-Key  header  string  false  "retrying with the same key returns the original tracks instead of duplicates"
 // @Success      201  {object}  entities.ResponseCreateTrackMulti
+// @Success      207  {object}  entities.ResponseCreateTrackMultiPartial
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
 // @Failure      404  {object}  entities.ResponseBadRequest
+// @Failure      409  {object}  entities.ResponseBadRequest
+// @Failure      422  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
 // @Router       /api/v1/tracks/multi [post]
 func (tr *TrackController) CreateTrackMulti(c *gin.Context) {
 	// Validator JSON
-	var req []*entities.Track
+	var req entities.RequestCreateTrackMulti
 	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Validate each item
-	for i, track := range req {
-		// Validator Field
-		if track.TrackLat == "" {
-			utils.MessageResponseErrorBuild(c, http.StatusBadRequest, fmt.Sprintf("track latitude is required at index %d", i))
-			return
-		}
-		if track.TrackLong == "" {
-			utils.MessageResponseErrorBuild(c, http.StatusBadRequest, fmt.Sprintf("track longitude is required at index %d", i))
-			return
-		}
-		if track.TrackType == "" {
-			utils.MessageResponseErrorBuild(c, http.StatusBadRequest, fmt.Sprintf("track type is required at index %d", i))
-			return
-		}
-		if track.AppsSource == "" {
-			utils.MessageResponseErrorBuild(c, http.StatusBadRequest, fmt.Sprintf("app source is required at index %d", i))
+	// Authorization : Own App & Data
+	for _, item := range req {
+		if !authorizeApp(c, item.AppsSource) || !authorizeOwner(c, item.CreatedBy) {
 			return
 		}
-		if track.CreatedAt.String() == "" {
-			utils.MessageResponseErrorBuild(c, http.StatusBadRequest, fmt.Sprintf("created at is required at index %d", i))
-			return
+	}
+
+	// Idempotency : Derive the ids from the key for the items without one
+	key := c.GetHeader("Idempotency-Key")
+	if err := utils.ValidatorIdempotencyKey(key); err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if key != "" {
+		for i := range req {
+			if req[i].ID == nil {
+				id, _ := utils.IdempotencyTrackID(key, "track_multi", req[i].AppsSource, req[i].CreatedBy, i)
+				req[i].ID = &id
+			}
 		}
-		if track.CreatedBy == uuid.Nil {
-			utils.MessageResponseErrorBuild(c, http.StatusBadRequest, fmt.Sprintf("created by must be a valid UUID at index %d", i))
+	}
+
+	// Partial Mode
+	if c.Query("partial") == "true" {
+		// Service : Create Track Multi Partial
+		results, summary, err := tr.TrackService.CreateTrackMultiPartial(req)
+		if err != nil {
+			responseError(c, err)
 			return
 		}
 
-		// Validator : Track Type & Apps Source
-		if !utils.ValidatorContains(configs.TrackTypes, track.TrackType) {
-			utils.MessageResponseErrorBuild(c, http.StatusBadRequest, fmt.Sprintf("track type is not valid at index %d", i))
-			return
+		// Response
+		statusCode := http.StatusCreated
+		if summary.Failed > 0 {
+			statusCode = http.StatusMultiStatus
 		}
-		if !utils.ValidatorContains(configs.AppsSources, track.AppsSource) {
-			utils.MessageResponseErrorBuild(c, http.StatusBadRequest, fmt.Sprintf("app source is not valid at index %d", i))
+		utils.MessageResponseBuild(c, "success", "track", "post", statusCode, results, summary)
+		return
+	}
+
+	// Validate each item
+	tracks := make([]*entities.Track, 0, len(req))
+	for i, item := range req {
+		// Validator Field
+		if err := utils.ValidatorTrackMultiItem(item); err != nil {
+			utils.MessageResponseErrorBuild(c, http.StatusBadRequest, fmt.Sprintf("%s at index %d", err.Error(), i))
 			return
 		}
+
+		track := utils.ConverterRequestToTrackMulti(item)
+		tracks = append(tracks, track)
 	}
 
 	// Service : Create Track Multi
-	if err := tr.TrackService.CreateTrackMulti(req); err != nil {
-		utils.MessageResponseErrorBuild(c, http.StatusInternalServerError, err.Error())
+	if err := tr.TrackService.CreateTrackMulti(tracks); err != nil {
+		responseError(c, err)
+		return
+	}
+
+	// Response
+	utils.MessageResponseBuild(c, "success", "track", "post", http.StatusCreated, tracks, nil)
+}
+
+// @Summary      Import Track
+// @Description  Imports the points of a GPX or GeoJSON file as the user's track
+// @Tags         Track
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
+// @Param        created_by  path  string  true  "created_by must be UUID"
+// @Param        file  formData  file  true  "GPX or GeoJSON file, up to 20 MB"
+// @Param        format  query  string  false  "gpx or geojson, taken from the file extension when empty"
+// @Param        track_type  query  string  false  "track_type of the points without their own (default: live)"
+// @Success      201  {object}  entities.ResponseImportTrack
+// @Success      207  {object}  entities.ResponseImportTrack
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/tracks/{app_source}/{created_by}/import [post]
+func (tr *TrackController) ImportTrack(c *gin.Context) {
+	// Param
+	appsSource := c.Param("app_source")
+	createdByRaw := c.Param("created_by")
+	trackType := c.DefaultQuery("track_type", "live")
+
+	// Convert to UUID
+	createdBy, err := uuid.Parse(createdByRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
+		return
+	}
+
+	// Validator : App Source & Track Type
+	if !utils.ValidatorContains(configs.AppsSources, appsSource) {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is not valid")
+		return
+	}
+	if !utils.ValidatorContains(configs.TrackTypes, trackType) {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "track type is not valid")
+		return
+	}
+
+	// Authorization : Own Data
+	if !authorizeOwner(c, createdBy) {
+		return
+	}
+
+	// Validator : File
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, configs.ImportMaxSize+1<<20)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "file is required")
+		return
+	}
+	if fileHeader.Size > configs.ImportMaxSize {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, fmt.Sprintf("file must not be larger than %d MB", configs.ImportMaxSize>>20))
+		return
+	}
+	format, err := utils.ImportTrackFormat(c.Query("format"), fileHeader.Filename)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Converter : File To Track Request
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "file is required")
+		return
+	}
+	defer file.Close()
+	items, err := utils.ImportTrackParse(format, file, appsSource, createdBy, trackType)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Import Track, a backfill of past points is not taken from the daily quota
+	rejected, summary, err := tr.TrackService.ImportTrack(items)
+	if err != nil {
+		responseError(c, err)
 		return
 	}
 
 	// Response
-	utils.MessageResponseBuild(c, "success", "track", "post", http.StatusCreated, req, nil)
+	statusCode := http.StatusCreated
+	if summary.Rejected > 0 {
+		statusCode = http.StatusMultiStatus
+	}
+	utils.MessageResponseBuild(c, "success", "track", "post", statusCode, rejected, summary)
 }
 
 // @Summary      Get All Track
@@ -159,10 +268,24 @@ func (tr *TrackController) CreateTrackMulti(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Success      200  {object}  entities.ResponseGetAllTrack
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
 // @Failure      404  {object}  entities.ResponseNotFound
+// @Failure      503  {object}  entities.ResponseBadRequest
 // @Router       /api/v1/tracks/{app_source}/{created_by} [get]
 // @Param        created_by  path  string  true  "created_by must be UUID"
 // @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
+// @Param        page  query  int  false  "page number, ignored when cursor is given"
+// @Param        limit  query  int  false  "number of track per page, up to 500"
+// @Param        cursor  query  string  false  "opaque cursor from next_cursor or prev_cursor, send it empty to start cursor pagination"
+// @Param        sort  query  string  false  "sort by created_at (asc or desc)"
+// @Param        from  query  string  false  "RFC3339 timestamp, only track created at or after it"
+// @Param        to  query  string  false  "RFC3339 timestamp, only track created at or before it"
+// @Param        track_type  query  string  false  "track_type (such as: live or share-loc)"
+// @Param        battery_min  query  int  false  "minimum battery_indicator"
+// @Param        battery_max  query  int  false  "maximum battery_indicator"
+// @Param        simplify  query  number  false  "Douglas-Peucker tolerance in meters, the page keeps its first and last track"
+// @Param        max_points  query  int  false  "keep at most this many of the page's most significant track"
 func (tr *TrackController) GetAllTrack(c *gin.Context) {
 	// Param
 	appsSource := c.Param("app_source")
@@ -181,13 +304,62 @@ func (tr *TrackController) GetAllTrack(c *gin.Context) {
 		return
 	}
 
+	// Authorization : Own Data
+	if !authorizeOwner(c, createdBy) {
+		return
+	}
+
 	// Pagination
-	pagination := utils.PaginationBuilder(c)
+	pagination, err := utils.PaginationBuilder(c)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Filter
+	filter, err := utils.TrackFilterBuilder(c)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	pagination.Filter = filter
+
+	// Simplify
+	simplify, err := utils.SimplifyQueryBuilder(c)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	pagination.Simplify = simplify
+
+	if pagination.UseCursor {
+		if _, err := utils.CursorDecode(pagination.Cursor); err != nil {
+			utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Service : Get All Track By Cursor
+		track, next, prev, err := tr.TrackService.GetAllTrackByCursor(pagination, appsSource, createdBy)
+		if err != nil {
+			responseError(c, err)
+			return
+		}
+
+		// Response
+		metadata := gin.H{
+			"limit":       pagination.Limit,
+			"next_cursor": next,
+			"prev_cursor": prev,
+		}
+		utils.MessageResponseBuild(c, "success", "track", "get", http.StatusOK, track, metadata)
+		return
+	}
 
 	// Service : Get All Track
 	track, total, err := tr.TrackService.GetAllTrack(pagination, appsSource, createdBy)
 	if err != nil {
-		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		responseError(c, err)
+		return
 	}
 
 	// Response
@@ -201,22 +373,135 @@ func (tr *TrackController) GetAllTrack(c *gin.Context) {
 	utils.MessageResponseBuild(c, "success", "track", "get", http.StatusOK, track, metadata)
 }
 
-// @Summary      Delete Track By ID
-// @Description  Delete track by given id
+// @Summary      Get Track Within Area
+// @Description  Returns the track inside a bounding box, newest first
 // @Tags         Track
 // @Accept       json
 // @Produce      json
-// @Success      200  {object}  entities.ResponseDeleteTrackById
-// @Failure      404  {object}  entities.ResponseNotFound
-// @Router       /api/v1/tracks/{app_source}/{created_by}/{track_id} [delete]
-// @Param        created_by  path  string  true  "created_by must be UUID"
+// @Success      200  {object}  entities.ResponseGetTrackArea
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/tracks/{app_source}/area [get]
 // @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
-// @Param        track_id  path  string  true  "track_id must be UUID"
-func (tr *TrackController) DeleteTrackById(c *gin.Context) {
+// @Param        min_lat  query  number  true  "south edge latitude"
+// @Param        min_long  query  number  true  "west edge longitude, greater than max_long for a box across the antimeridian"
+// @Param        max_lat  query  number  true  "north edge latitude"
+// @Param        max_long  query  number  true  "east edge longitude"
+// @Param        created_by  query  string  false  "created_by must be UUID"
+// @Param        from  query  string  false  "RFC3339 timestamp, only track created at or after it"
+// @Param        to  query  string  false  "RFC3339 timestamp, only track created at or before it"
+// @Param        limit  query  int  false  "maximum number of track, up to 1000"
+func (tr *TrackController) GetTrackWithinBox(c *gin.Context) {
+	// Validator : App Source
+	if !utils.ValidatorContains(configs.AppsSources, c.Param("app_source")) {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is not valid")
+		return
+	}
+
+	// Query
+	query, err := utils.SpatialBoxBuilder(c)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Authorization : Own Data
+	if !authorizeSpatial(c, &query) {
+		return
+	}
+
+	// Service : Get Track Within Box
+	track, err := tr.TrackService.GetTrackWithinBox(query)
+	if err != nil {
+		responseError(c, err)
+		return
+	}
+
+	// Response
+	metadata := gin.H{
+		"total": len(track),
+		"limit": query.Limit,
+	}
+	utils.MessageResponseBuild(c, "success", "track", "get", http.StatusOK, track, metadata)
+}
+
+// @Summary      Get Track Nearby
+// @Description  Returns the track within radius meters of a coordinate, nearest first
+// @Tags         Track
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entities.ResponseGetTrackNearby
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/tracks/{app_source}/nearby [get]
+// @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
+// @Param        lat  query  number  true  "center latitude"
+// @Param        long  query  number  true  "center longitude"
+// @Param        radius  query  number  true  "radius in meters, up to 50000"
+// @Param        created_by  query  string  false  "created_by must be UUID"
+// @Param        from  query  string  false  "RFC3339 timestamp, only track created at or after it"
+// @Param        to  query  string  false  "RFC3339 timestamp, only track created at or before it"
+// @Param        limit  query  int  false  "maximum number of track, up to 1000"
+func (tr *TrackController) GetTrackWithinRadius(c *gin.Context) {
+	// Validator : App Source
+	if !utils.ValidatorContains(configs.AppsSources, c.Param("app_source")) {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is not valid")
+		return
+	}
+
+	// Query
+	query, err := utils.SpatialRadiusBuilder(c)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Authorization : Own Data
+	if !authorizeSpatial(c, &query) {
+		return
+	}
+
+	// Service : Get Track Within Radius
+	track, err := tr.TrackService.GetTrackWithinRadius(query)
+	if err != nil {
+		responseError(c, err)
+		return
+	}
+
+	// Response
+	metadata := gin.H{
+		"total": len(track),
+		"limit": query.Limit,
+	}
+	utils.MessageResponseBuild(c, "success", "track", "get", http.StatusOK, track, metadata)
+}
+
+// @Summary      Get Track Trips
+// @Description  Splits the user's track into trips on long pauses or jumps, oldest first
+// @Tags         Track
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entities.ResponseGetTrackTrip
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/tracks/{app_source}/{created_by}/trips [get]
+// @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
+// @Param        created_by  path  string  true  "created_by must be UUID"
+// @Param        gap  query  string  false  "longest pause inside a trip, 1m up to 24h (default: 10m)"
+// @Param        distance  query  number  false  "longest jump in meters between two points of a trip, up to 100000 (default: 2000)"
+// @Param        track_type  query  string  false  "track_type of the points (default: live)"
+// @Param        from  query  string  false  "RFC3339 timestamp, only track recorded at or after it"
+// @Param        to  query  string  false  "RFC3339 timestamp, only track recorded at or before it"
+func (tr *TrackController) GetTrackTrips(c *gin.Context) {
 	// Param
 	appsSource := c.Param("app_source")
 	createdByRaw := c.Param("created_by")
-	trackIdRaw := c.Param("track_id")
 
 	// Convert to UUID
 	createdBy, err := uuid.Parse(createdByRaw)
@@ -224,9 +509,67 @@ func (tr *TrackController) DeleteTrackById(c *gin.Context) {
 		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
 		return
 	}
-	trackID, err := uuid.Parse(trackIdRaw)
+
+	// Validator : App Source
+	if !utils.ValidatorContains(configs.AppsSources, appsSource) {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is not valid")
+		return
+	}
+
+	// Authorization : Own Data
+	if !authorizeOwner(c, createdBy) {
+		return
+	}
+
+	// Query
+	query, err := utils.TripQueryBuilder(c)
 	if err != nil {
-		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "track id is not valid")
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Get Track Trips
+	trips, err := tr.TrackService.GetTrackTrips(query, appsSource, createdBy)
+	if err != nil {
+		responseError(c, err)
+		return
+	}
+
+	// Response
+	metadata := gin.H{
+		"total":    len(trips),
+		"gap":      query.Gap.String(),
+		"distance": query.Distance,
+	}
+	utils.MessageResponseBuild(c, "success", "track", "get", http.StatusOK, trips, metadata)
+}
+
+// @Summary      Get Track Stats
+// @Description  Returns the user's distance, moving time, speed, point count and battery range per day
+// @Tags         Track
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entities.ResponseGetTrackStats
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/tracks/{app_source}/{created_by}/stats [get]
+// @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
+// @Param        created_by  path  string  true  "created_by must be UUID"
+// @Param        tz  query  string  false  "IANA time zone the days are cut in (default: server zone)"
+// @Param        track_type  query  string  false  "track_type (such as: live or share-loc)"
+// @Param        from  query  string  false  "RFC3339 timestamp, only track recorded at or after it"
+// @Param        to  query  string  false  "RFC3339 timestamp, only track recorded at or before it"
+func (tr *TrackController) GetTrackStats(c *gin.Context) {
+	// Param
+	appsSource := c.Param("app_source")
+	createdByRaw := c.Param("created_by")
+
+	// Convert to UUID
+	createdBy, err := uuid.Parse(createdByRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
 		return
 	}
 
@@ -236,33 +579,553 @@ func (tr *TrackController) DeleteTrackById(c *gin.Context) {
 		return
 	}
 
-	// Service : Get All Track
-	err = tr.TrackService.DeleteTrackByID(appsSource, createdBy, trackID)
+	// Authorization : Own Data
+	if !authorizeOwner(c, createdBy) {
+		return
+	}
+
+	// Query
+	query, err := utils.StatsQueryBuilder(c)
 	if err != nil {
 		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	utils.MessageResponseBuild(c, "success", "track", "soft delete", http.StatusOK, nil, nil)
+	// Service : Get Track Stats
+	stats, err := tr.TrackService.GetTrackStats(query, appsSource, createdBy)
+	if err != nil {
+		responseError(c, err)
+		return
+	}
+
+	// Response
+	metadata := gin.H{
+		"total": len(stats),
+		"tz":    query.Location.String(),
+	}
+	utils.MessageResponseBuild(c, "success", "track", "get", http.StatusOK, stats, metadata)
 }
 
-// @Summary      Get All Apps Track Summary
-// @Description  Returns a list of track in pagination format
+// @Summary      Export Track
+// @Description  Streams the user's track oldest first as GPX, KML, GeoJSON or CSV
 // @Tags         Track
-// @Accept       json
-// @Produce      json
-// @Success      200  {object}  entities.ResponseGetAppCount
-// @Failure      404  {object}  entities.ResponseNotFound
-// @Router       /api/v1/tracks/summary [get]
-func (tr *TrackController) GetAppsUserTotal(c *gin.Context) {
-	// Service : Get Apps User Total
-	track, err := tr.TrackService.GetAppsUserTotal()
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		utils.BuildResponseMessage(c, "failed", "track", "empty", http.StatusNotFound, nil, nil)
+// @Produce      application/gpx+xml,application/vnd.google-earth.kml+xml,application/geo+json,text/csv
+// @Success      200  {file}  file
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/tracks/{app_source}/{created_by}/export [get]
+// @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
+// @Param        created_by  path  string  true  "created_by must be UUID"
+// @Param        format  query  string  true  "gpx, kml, geojson or csv"
+// @Param        from  query  string  false  "RFC3339 timestamp, only track recorded at or after it"
+// @Param        to  query  string  false  "RFC3339 timestamp, only track recorded at or before it"
+// @Param        simplify  query  number  false  "Douglas-Peucker tolerance in meters, the route keeps its first and last track"
+// @Param        max_points  query  int  false  "keep at most this many of the route's most significant track"
+func (tr *TrackController) ExportTrack(c *gin.Context) {
+	// Param
+	appsSource := c.Param("app_source")
+	createdByRaw := c.Param("created_by")
+
+	// Convert to UUID
+	createdBy, err := uuid.Parse(createdByRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
+		return
+	}
+
+	// Validator : App Source
+	if !utils.ValidatorContains(configs.AppsSources, appsSource) {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is not valid")
 		return
 	}
+
+	// Authorization : Own Data
+	if !authorizeOwner(c, createdBy) {
+		return
+	}
+
+	// Exporter
+	exporter, err := utils.NewTrackExporter(c.Query("format"), c.Writer, fmt.Sprintf("%s %s", appsSource, createdBy.String()))
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Filter
+	filter, err := utils.TrackFilterBuilder(c)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Simplify
+	simplify, err := utils.SimplifyQueryBuilder(c)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Response : Headers go out with the first page, an error before it still gets a status code
+	started := false
+	begin := func() error {
+		started = true
+		c.Header("Content-Type", exporter.ContentType())
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"tracks_%s_%s.%s\"", appsSource, createdBy.String(), exporter.Extension()))
+		c.Status(http.StatusOK)
+		return exporter.Begin()
+	}
+
+	// Service : Export Track
+	err = tr.TrackService.ExportTrack(filter, simplify, appsSource, createdBy, func(tracks []*entities.Track) error {
+		if !started {
+			if err := begin(); err != nil {
+				return err
+			}
+		}
+		for _, track := range tracks {
+			if err := exporter.Write(track); err != nil {
+				return err
+			}
+		}
+		c.Writer.Flush()
+		return nil
+	})
+	if err != nil {
+		if !started {
+			responseError(c, err)
+			return
+		}
+		log.Printf("Export of %s/%s stopped: %v", appsSource, createdBy.String(), err)
+		return
+	}
+	if !started {
+		if err := begin(); err != nil {
+			log.Printf("Export of %s/%s stopped: %v", appsSource, createdBy.String(), err)
+			return
+		}
+	}
+	if err := exporter.End(); err != nil {
+		log.Printf("Export of %s/%s stopped: %v", appsSource, createdBy.String(), err)
+	}
+}
+
+// @Summary      Get Latest Track
+// @Description  Returns the user's newest track without reading their history
+// @Tags         Track
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entities.ResponseGetLatestTrack
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      404  {object}  entities.ResponseNotFound
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/tracks/{app_source}/{created_by}/latest [get]
+// @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
+// @Param        created_by  path  string  true  "created_by must be UUID"
+func (tr *TrackController) GetLatestTrack(c *gin.Context) {
+	// Param
+	appsSource := c.Param("app_source")
+	createdByRaw := c.Param("created_by")
+
+	// Convert to UUID
+	createdBy, err := uuid.Parse(createdByRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
+		return
+	}
+
+	// Validator : App Source
+	if !utils.ValidatorContains(configs.AppsSources, appsSource) {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is not valid")
+		return
+	}
+
+	// Authorization : Own Data
+	if !authorizeOwner(c, createdBy) {
+		return
+	}
+
+	// Service : Get Latest Track
+	track, err := tr.TrackService.GetLatestTrack(appsSource, createdBy)
+	if err != nil {
+		responseError(c, err)
+		return
+	}
+
+	// Response
+	utils.MessageResponseBuild(c, "success", "track", "get", http.StatusOK, track, nil)
+}
+
+// @Summary      Get Latest Track Bulk
+// @Description  Returns the newest track of each given user, users without any track are listed as not found
+// @Tags         Track
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entities.ResponseGetLatestTrackBulk
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/tracks/{app_source}/latest [get]
+// @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
+// @Param        created_by  query  string  true  "comma separated UUIDs, up to 100 users"
+func (tr *TrackController) GetLatestTrackBulk(c *gin.Context) {
+	// Param
+	appsSource := c.Param("app_source")
+
+	// Validator : App Source
+	if !utils.ValidatorContains(configs.AppsSources, appsSource) {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is not valid")
+		return
+	}
+
+	// Query
+	createdBy, err := utils.LatestTrackUsersBuilder(c)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Authorization : Own Data
+	for _, user := range createdBy {
+		if !authorizeOwner(c, user) {
+			return
+		}
+	}
+
+	// Service : Get Latest Track Bulk
+	tracks, err := tr.TrackService.GetLatestTrackBulk(appsSource, createdBy)
+	if err != nil {
+		responseError(c, err)
+		return
+	}
+
+	// Response
+	found := make(map[uuid.UUID]bool, len(tracks))
+	for _, track := range tracks {
+		found[track.CreatedBy] = true
+	}
+	notFound := make([]string, 0)
+	for _, user := range createdBy {
+		if !found[user] {
+			notFound = append(notFound, user.String())
+		}
+	}
+	metadata := gin.H{
+		"total":     len(tracks),
+		"not_found": notFound,
+	}
+	utils.MessageResponseBuild(c, "success", "track", "get", http.StatusOK, tracks, metadata)
+}
+
+// @Summary      Stream Track
+// @Description  Pushes the user's new tracks as Server-Sent Events while the connection is open. Each point is a "track" event, idle connections get a "heartbeat" event, and a "closed" event ends the stream when the client fell too far behind.
+// @Tags         Track
+// @Produce      text/event-stream
+// @Success      200  {object}  entities.Track
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/tracks/{app_source}/{created_by}/stream [get]
+// @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
+// @Param        created_by  path  string  true  "created_by must be UUID"
+func (tr *TrackController) StreamTrack(c *gin.Context) {
+	// Param
+	appsSource := c.Param("app_source")
+	createdByRaw := c.Param("created_by")
+
+	// Convert to UUID
+	createdBy, err := uuid.Parse(createdByRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
+		return
+	}
+
+	// Validator : App Source
+	if !utils.ValidatorContains(configs.AppsSources, appsSource) {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is not valid")
+		return
+	}
+
+	// Authorization : Own Data
+	if !authorizeOwner(c, createdBy) {
+		return
+	}
+
+	// Hub : Subscribe until the client leaves
+	subscription := tr.TrackHub.Subscribe(appsSource, createdBy)
+	defer tr.TrackHub.Unsubscribe(subscription)
+	heartbeat := time.NewTicker(configs.TrackStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	// Response : Headers go out now so the client knows it is subscribed
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case track, ok := <-subscription.Tracks:
+			if !ok {
+				c.SSEvent("closed", gin.H{"reason": "too many tracks were not read in time, reconnect and read the latest track"})
+				return false
+			}
+			c.SSEvent("track", track)
+			heartbeat.Reset(configs.TrackStreamHeartbeat)
+			return true
+		case at := <-heartbeat.C:
+			c.SSEvent("heartbeat", gin.H{"at": at.Format(time.RFC3339)})
+			return true
+		}
+	})
+}
+
+// @Summary      Get Track Quota
+// @Description  Returns the points the user created today (UTC) against the daily quota, a zero limit means no quota
+// @Tags         Track
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entities.ResponseGetTrackQuota
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/tracks/{app_source}/{created_by}/usage [get]
+// @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
+// @Param        created_by  path  string  true  "created_by must be UUID"
+func (tr *TrackController) GetTrackQuota(c *gin.Context) {
+	// Param
+	appsSource := c.Param("app_source")
+	createdByRaw := c.Param("created_by")
+
+	// Convert to UUID
+	createdBy, err := uuid.Parse(createdByRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
+		return
+	}
+
+	// Validator : App Source
+	if !utils.ValidatorContains(configs.AppsSources, appsSource) {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is not valid")
+		return
+	}
+
+	// Authorization : Own Data
+	if !authorizeOwner(c, createdBy) {
+		return
+	}
+
+	// Service : Get Quota
+	quota, err := tr.QuotaService.GetQuota(appsSource, createdBy)
+	if err != nil {
+		responseError(c, err)
+		return
+	}
+
+	// Response
+	utils.MessageResponseBuild(c, "success", "track", "get", http.StatusOK, quota, nil)
+}
+
+// @Summary      Delete Track By ID
+// @Description  Delete track by given id
+// @Tags         Track
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entities.ResponseDeleteTrackById
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      404  {object}  entities.ResponseNotFound
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/tracks/{app_source}/{created_by}/{track_id} [delete]
+// @Param        created_by  path  string  true  "created_by must be UUID"
+// @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
+// @Param        track_id  path  string  true  "track_id must be UUID"
+func (tr *TrackController) DeleteTrackById(c *gin.Context) {
+	// Param
+	appsSource := c.Param("app_source")
+	createdByRaw := c.Param("created_by")
+	trackIdRaw := c.Param("track_id")
+
+	// Convert to UUID
+	createdBy, err := uuid.Parse(createdByRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
+		return
+	}
+	trackID, err := uuid.Parse(trackIdRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "track id is not valid")
+		return
+	}
+
+	// Validator : App Source
+	if !utils.ValidatorContains(configs.AppsSources, appsSource) {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is not valid")
+		return
+	}
+
+	// Authorization : Own Data
+	if !authorizeOwner(c, createdBy) {
+		return
+	}
+
+	// Service : Delete Track By ID
+	if err := tr.TrackService.DeleteTrackByID(appsSource, createdBy, trackID); err != nil {
+		responseError(c, err)
+		return
+	}
+
+	utils.MessageResponseBuild(c, "success", "track", "soft delete", http.StatusOK, nil, nil)
+}
+
+// @Summary      Get Trash Track
+// @Description  Returns the user's deleted track, most recently deleted first, until they are purged
+// @Tags         Track
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entities.ResponseGetTrashTrack
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/tracks/{app_source}/{created_by}/trash [get]
+// @Param        created_by  path  string  true  "created_by must be UUID"
+// @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
+// @Param        page  query  int  false  "page number"
+// @Param        limit  query  int  false  "number of track per page, up to 500"
+func (tr *TrackController) GetTrashTrack(c *gin.Context) {
+	// Param
+	appsSource := c.Param("app_source")
+	createdByRaw := c.Param("created_by")
+
+	// Convert to UUID
+	createdBy, err := uuid.Parse(createdByRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
+		return
+	}
+
+	// Validator : App Source
+	if !utils.ValidatorContains(configs.AppsSources, appsSource) {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is not valid")
+		return
+	}
+
+	// Authorization : Own Data
+	if !authorizeOwner(c, createdBy) {
+		return
+	}
+
+	// Pagination
+	pagination, err := utils.PaginationBuilder(c)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Service : Get Trash Track
+	track, total, err := tr.TrackService.GetTrashTrack(pagination, appsSource, createdBy)
+	if err != nil {
+		responseError(c, err)
+		return
+	}
+
+	// Response
+	totalPages := int(math.Ceil(float64(total) / float64(pagination.Limit)))
+	metadata := gin.H{
+		"total":          total,
+		"page":           pagination.Page,
+		"limit":          pagination.Limit,
+		"total_pages":    totalPages,
+		"retention_days": configs.TrackTrashRetentionDays,
+	}
+	utils.MessageResponseBuild(c, "success", "track", "get", http.StatusOK, track, metadata)
+}
+
+// @Summary      Recover Track By ID
+// @Description  Moves a deleted track out of the trash, back into the user's track
+// @Tags         Track
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entities.ResponseRecoverTrackById
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      404  {object}  entities.ResponseNotFound
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/tracks/{app_source}/{created_by}/{track_id}/recover [put]
+// @Param        created_by  path  string  true  "created_by must be UUID"
+// @Param        app_source  path  string  true  "app_source (such as: pinmarker, mi-fik, myride, or kumande)"
+// @Param        track_id  path  string  true  "track_id must be UUID"
+func (tr *TrackController) RecoverTrackById(c *gin.Context) {
+	// Param
+	appsSource := c.Param("app_source")
+	createdByRaw := c.Param("created_by")
+	trackIdRaw := c.Param("track_id")
+
+	// Convert to UUID
+	createdBy, err := uuid.Parse(createdByRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
+		return
+	}
+	trackID, err := uuid.Parse(trackIdRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "track id is not valid")
+		return
+	}
+
+	// Validator : App Source
+	if !utils.ValidatorContains(configs.AppsSources, appsSource) {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "app source is not valid")
+		return
+	}
+
+	// Authorization : Own Data
+	if !authorizeOwner(c, createdBy) {
+		return
+	}
+
+	// Service : Recover Track By ID
+	track, err := tr.TrackService.RecoverTrackByID(appsSource, createdBy, trackID)
+	if err != nil {
+		responseError(c, err)
+		return
+	}
+
+	utils.MessageResponseBuild(c, "success", "track", "recover", http.StatusOK, track, nil)
+}
+
+// @Summary      Get All Apps Track Summary
+// @Description  Returns the users total of each app, only to an admin token, an API key only sees its own app
+// @Tags         Track
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entities.ResponseGetAppCount
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      404  {object}  entities.ResponseNotFound
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/tracks/summary [get]
+func (tr *TrackController) GetAppsUserTotal(c *gin.Context) {
+	// Authorization : Admin
+	if !authorizeAdmin(c) {
+		return
+	}
+
+	// Service : Get Apps User Total, of the API key's app only
+	track, err := tr.TrackService.GetAppsUserTotal(middlewares.ApiKeyAppSource(c))
 	if err != nil {
-		utils.BuildErrorMessage(c, err.Error())
+		responseError(c, err)
 		return
 	}
 
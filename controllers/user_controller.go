@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"pinmarker/middlewares"
+	"pinmarker/services"
+	"pinmarker/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type UserController struct {
+	UserDataService services.UserDataService
+}
+
+func NewUserController(userDataService services.UserDataService) *UserController {
+	return &UserController{UserDataService: userDataService}
+}
+
+// @Summary      Get User Apps
+// @Description  Returns every app source a track of the user is stored under, trashed ones included. Admin only.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entities.ResponseGetUserApps
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/users/{created_by}/apps [get]
+// @Param        created_by  path  string  true  "created_by must be UUID"
+func (uc *UserController) GetUserApps(c *gin.Context) {
+	// Param
+	createdByRaw := c.Param("created_by")
+
+	// Convert to UUID
+	createdBy, err := uuid.Parse(createdByRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
+		return
+	}
+
+	// Service : Get User Apps
+	apps, err := uc.UserDataService.GetUserApps(createdBy)
+	if err != nil {
+		responseError(c, err)
+		return
+	}
+
+	utils.MessageResponseBuild(c, "success", "user", "get", http.StatusOK, apps, gin.H{"total": len(apps)})
+}
+
+// @Summary      Export User Data
+// @Description  Downloads a zip of everything stored about the user in every app source, one JSON array per app and document with a manifest.json of the counts. Admin only.
+// @Tags         User
+// @Produce      application/zip
+// @Success      200  {file}    file
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/users/{created_by}/export [get]
+// @Param        created_by  path  string  true  "created_by must be UUID"
+func (uc *UserController) ExportUserData(c *gin.Context) {
+	// Param
+	createdByRaw := c.Param("created_by")
+
+	// Convert to UUID
+	createdBy, err := uuid.Parse(createdByRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
+		return
+	}
+
+	// Response : Zip archive, its headers are dropped when the export fails before the first byte
+	filename := fmt.Sprintf("user_%s_%s.zip", createdBy.String(), time.Now().Format("20060102"))
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	// Service : Export User Data
+	if err := uc.UserDataService.ExportUserData(createdBy, c.Writer); err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			responseError(c, err)
+			return
+		}
+		log.Printf("Export of user %s stopped: %v", createdBy.String(), err)
+	}
+}
+
+// @Summary      Erase User Data
+// @Description  Permanently deletes everything stored about the user in every app source, reads it all again to verify nothing is left and keeps an audit record of the counts. Admin only.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entities.ResponseEraseUser
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      500  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/users/{created_by} [delete]
+// @Param        created_by  path  string  true  "created_by must be UUID"
+func (uc *UserController) EraseUserData(c *gin.Context) {
+	// Param
+	createdByRaw := c.Param("created_by")
+
+	// Convert to UUID
+	createdBy, err := uuid.Parse(createdByRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
+		return
+	}
+
+	// Service : Erase User Data
+	erasure, err := uc.UserDataService.EraseUserData(createdBy, middlewares.AuthSubject(c))
+	if err != nil {
+		responseError(c, err)
+		return
+	}
+
+	utils.MessageResponseBuild(c, "success", "user", "hard delete", http.StatusOK, erasure, nil)
+}
+
+// @Summary      Get All User Erasure
+// @Description  Returns the audit records of the user's erasures, newest first. Admin only.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  entities.ResponseGetAllUserErasure
+// @Failure      400  {object}  entities.ResponseBadRequest
+// @Failure      401  {object}  entities.ResponseBadRequest
+// @Failure      403  {object}  entities.ResponseBadRequest
+// @Failure      503  {object}  entities.ResponseBadRequest
+// @Router       /api/v1/users/{created_by}/erasures [get]
+// @Param        created_by  path  string  true  "created_by must be UUID"
+func (uc *UserController) GetAllUserErasure(c *gin.Context) {
+	// Param
+	createdByRaw := c.Param("created_by")
+
+	// Convert to UUID
+	createdBy, err := uuid.Parse(createdByRaw)
+	if err != nil {
+		utils.MessageResponseErrorBuild(c, http.StatusBadRequest, "created by is not valid")
+		return
+	}
+
+	// Service : Get All User Erasure
+	erasures, err := uc.UserDataService.GetAllUserErasure(createdBy)
+	if err != nil {
+		responseError(c, err)
+		return
+	}
+
+	utils.MessageResponseBuild(c, "success", "user", "get", http.StatusOK, erasures, nil)
+}
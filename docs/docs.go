@@ -1,4 +1,5 @@
-// Package docs Code generated by swaggo/swag. DO NOT EDIT
+// Code generated by swaggo/swag. DO NOT EDIT.
+
 package docs
 
 import "github.com/swaggo/swag"
@@ -15,9 +16,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/tracks": {
+        "/api/v1/geofences": {
             "post": {
-                "description": "Create an track",
+                "description": "Create a circle or polygon geofence, the user's next live track sets whether they are inside",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,17 +26,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Track"
+                    "Geofence"
                 ],
-                "summary": "Create Track",
+                "summary": "Create Geofence",
                 "parameters": [
                     {
-                        "description": "Post Track Request Body",
+                        "description": "Post Geofence Request Body",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entities.RequestCreateTrack"
+                            "$ref": "#/definitions/entities.RequestCreateGeofence"
                         }
                     }
                 ],
@@ -43,11 +44,29 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/entities.ResponseCreateTrack"
+                            "$ref": "#/definitions/entities.ResponseCreateGeofence"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/entities.ResponseBadRequest"
                         }
@@ -55,9 +74,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/tracks/multi": {
-            "post": {
-                "description": "Create multiple track",
+        "/api/v1/geofences/{app_source}/{created_by}": {
+            "get": {
+                "description": "Returns every geofence of the user, oldest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -65,62 +84,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Track"
+                    "Geofence"
                 ],
-                "summary": "Create Track Multiple",
+                "summary": "Get All Geofence",
                 "parameters": [
                     {
-                        "description": "Post Track Multiple Request Body",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "object",
-                                "properties": {
-                                    "app_source": {
-                                        "type": "string",
-                                        "example": "pinmarker"
-                                    },
-                                    "battery_indicator": {
-                                        "type": "integer",
-                                        "example": 85
-                                    },
-                                    "created_at": {
-                                        "type": "string",
-                                        "example": "2025-06-23T11:30:15.913505+07:00"
-                                    },
-                                    "created_by": {
-                                        "type": "string",
-                                        "example": "123e4567-e89b-12d3-a456-426614174000"
-                                    },
-                                    "track_lat": {
-                                        "type": "string",
-                                        "example": "-6.200000"
-                                    },
-                                    "track_long": {
-                                        "type": "string",
-                                        "example": "106.816666"
-                                    },
-                                    "track_type": {
-                                        "type": "string",
-                                        "example": "live"
-                                    }
-                                }
-                            }
-                        }
+                        "type": "string",
+                        "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
+                        "name": "app_source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entities.ResponseCreateTrackMulti"
+                            "$ref": "#/definitions/entities.ResponseGetAllGeofence"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/entities.ResponseBadRequest"
                         }
@@ -128,9 +137,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/tracks/{app_source}/{created_by}": {
+        "/api/v1/geofences/{app_source}/{created_by}/events": {
             "get": {
-                "description": "Returns a list of track in pagination format",
+                "description": "Returns the user's enter and exit events, newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -138,10 +147,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Track"
+                    "Geofence"
                 ],
-                "summary": "Get All Track",
+                "summary": "Get All Geofence Event",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
+                        "name": "app_source",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "created_by must be UUID",
@@ -149,19 +165,124 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "only the events of this geofence",
+                        "name": "geofence_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp, only events recorded at or after it",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp, only events recorded at or before it",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum events, up to 1000 (default: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseGetAllGeofenceEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/geofences/{app_source}/{created_by}/{geofence_id}": {
+            "get": {
+                "description": "Returns one geofence with the user's last known state",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Geofence"
+                ],
+                "summary": "Get Geofence By ID",
+                "parameters": [
                     {
                         "type": "string",
                         "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
                         "name": "app_source",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "geofence_id must be UUID",
+                        "name": "geofence_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entities.ResponseGetAllTrack"
+                            "$ref": "#/definitions/entities.ResponseGetGeofence"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
                         }
                     },
                     "404": {
@@ -169,13 +290,17 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/entities.ResponseNotFound"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
                     }
                 }
-            }
-        },
-        "/api/v1/tracks/{app_source}/{created_by}/{track_id}": {
-            "delete": {
-                "description": "Delete track by given id",
+            },
+            "put": {
+                "description": "Replace the name and shape of a geofence, the user's state is decided again by the next live track",
                 "consumes": [
                     "application/json"
                 ],
@@ -183,10 +308,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Track"
+                    "Geofence"
                 ],
-                "summary": "Delete Track By ID",
+                "summary": "Update Geofence By ID",
                 "parameters": [
+                    {
+                        "description": "Put Geofence Request Body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.RequestUpdateGeofence"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
+                        "name": "app_source",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "created_by must be UUID",
@@ -194,6 +335,66 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "geofence_id must be UUID",
+                        "name": "geofence_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseUpdateGeofence"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseNotFound"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete geofence by given id, its past events are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Geofence"
+                ],
+                "summary": "Delete Geofence By ID",
+                "parameters": [
                     {
                         "type": "string",
                         "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
@@ -203,8 +404,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "track_id must be UUID",
-                        "name": "track_id",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "geofence_id must be UUID",
+                        "name": "geofence_id",
                         "in": "path",
                         "required": true
                     }
@@ -213,7 +421,25 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entities.ResponseDeleteTrackById"
+                            "$ref": "#/definitions/entities.ResponseDeleteGeofence"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
                         }
                     },
                     "404": {
@@ -221,63 +447,2768 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/entities.ResponseNotFound"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "entities.RequestCreateTrack": {
-            "type": "object",
-            "properties": {
-                "app_source": {
-                    "type": "string",
-                    "example": "pinmarker"
+        },
+        "/api/v1/shared/{token}": {
+            "get": {
+                "description": "Public read of a share token, returns the newest share-loc points of the shared window. Without a from, the window starts an hour before the share was created.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Share"
+                ],
+                "summary": "Get Shared Track",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "share token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseGetSharedTrack"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseNotFound"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shares": {
+            "post": {
+                "description": "Mint a signed share token for the user's points, optionally limited to a time window. The token is only returned here.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Share"
+                ],
+                "summary": "Create Share",
+                "parameters": [
+                    {
+                        "description": "Post Share Request Body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.RequestCreateShare"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseCreateShare"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shares/{app_source}/{created_by}": {
+            "get": {
+                "description": "Returns the user's shares newest first, revoked and expired ones included, without their token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Share"
+                ],
+                "summary": "Get All Share",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
+                        "name": "app_source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseGetAllShare"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shares/{app_source}/{created_by}/{share_id}": {
+            "delete": {
+                "description": "Revoke a share before it expires, its token stops resolving right away",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Share"
+                ],
+                "summary": "Revoke Share By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
+                        "name": "app_source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "share_id must be UUID",
+                        "name": "share_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseRevokeShare"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseNotFound"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tracks": {
+            "post": {
+                "description": "Create an track",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Track"
+                ],
+                "summary": "Create Track",
+                "parameters": [
+                    {
+                        "description": "Post Track Request Body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.RequestCreateTrack"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "retrying with the same key returns the original track instead of a duplicate",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseCreateTrack"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tracks/multi": {
+            "post": {
+                "description": "Create multiple track",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Track"
+                ],
+                "summary": "Create Track Multiple",
+                "parameters": [
+                    {
+                        "description": "Post Track Multiple Request Body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.RequestCreateTrackMultiItem"
+                            }
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "write the valid items and report a result per index instead of rejecting the whole batch",
+                        "name": "partial",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "retrying with the same key returns the original tracks instead of duplicates",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseCreateTrackMulti"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseCreateTrackMultiPartial"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tracks/summary": {
+            "get": {
+                "description": "Returns the users total of each app, only to an admin token, an API key only sees its own app",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Track"
+                ],
+                "summary": "Get All Apps Track Summary",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseGetAppCount"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseNotFound"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tracks/{app_source}/area": {
+            "get": {
+                "description": "Returns the track inside a bounding box, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Track"
+                ],
+                "summary": "Get Track Within Area",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
+                        "name": "app_source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "south edge latitude",
+                        "name": "min_lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "west edge longitude, greater than max_long for a box across the antimeridian",
+                        "name": "min_long",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "north edge latitude",
+                        "name": "max_lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "east edge longitude",
+                        "name": "max_long",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp, only track created at or after it",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp, only track created at or before it",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of track, up to 1000",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseGetTrackArea"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tracks/{app_source}/latest": {
+            "get": {
+                "description": "Returns the newest track of each given user, users without any track are listed as not found",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Track"
+                ],
+                "summary": "Get Latest Track Bulk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
+                        "name": "app_source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated UUIDs, up to 100 users",
+                        "name": "created_by",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseGetLatestTrackBulk"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tracks/{app_source}/nearby": {
+            "get": {
+                "description": "Returns the track within radius meters of a coordinate, nearest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Track"
+                ],
+                "summary": "Get Track Nearby",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
+                        "name": "app_source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "center latitude",
+                        "name": "lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "center longitude",
+                        "name": "long",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "radius in meters, up to 50000",
+                        "name": "radius",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp, only track created at or after it",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp, only track created at or before it",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of track, up to 1000",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseGetTrackNearby"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tracks/{app_source}/{created_by}": {
+            "get": {
+                "description": "Returns a list of track in pagination format",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Track"
+                ],
+                "summary": "Get All Track",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
+                        "name": "app_source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number, ignored when cursor is given",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of track per page, up to 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "opaque cursor from next_cursor or prev_cursor, send it empty to start cursor pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sort by created_at (asc or desc)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp, only track created at or after it",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp, only track created at or before it",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "track_type (such as: live or share-loc)",
+                        "name": "track_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "minimum battery_indicator",
+                        "name": "battery_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum battery_indicator",
+                        "name": "battery_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Douglas-Peucker tolerance in meters, the page keeps its first and last track",
+                        "name": "simplify",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "keep at most this many of the page's most significant track",
+                        "name": "max_points",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseGetAllTrack"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseNotFound"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tracks/{app_source}/{created_by}/export": {
+            "get": {
+                "description": "Streams the user's track oldest first as GPX, KML, GeoJSON or CSV",
+                "produces": [
+                    "application/gpx+xml",
+                    "application/vnd.google-earth.kml+xml",
+                    "application/geo+json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Track"
+                ],
+                "summary": "Export Track",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
+                        "name": "app_source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "gpx, kml, geojson or csv",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp, only track recorded at or after it",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp, only track recorded at or before it",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Douglas-Peucker tolerance in meters, the route keeps its first and last track",
+                        "name": "simplify",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "keep at most this many of the route's most significant track",
+                        "name": "max_points",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tracks/{app_source}/{created_by}/import": {
+            "post": {
+                "description": "Imports the points of a GPX or GeoJSON file as the user's track",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Track"
+                ],
+                "summary": "Import Track",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
+                        "name": "app_source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "GPX or GeoJSON file, up to 20 MB",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "gpx or geojson, taken from the file extension when empty",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "track_type of the points without their own (default: live)",
+                        "name": "track_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseImportTrack"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseImportTrack"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tracks/{app_source}/{created_by}/latest": {
+            "get": {
+                "description": "Returns the user's newest track without reading their history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Track"
+                ],
+                "summary": "Get Latest Track",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
+                        "name": "app_source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseGetLatestTrack"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseNotFound"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tracks/{app_source}/{created_by}/stats": {
+            "get": {
+                "description": "Returns the user's distance, moving time, speed, point count and battery range per day",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Track"
+                ],
+                "summary": "Get Track Stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
+                        "name": "app_source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone the days are cut in (default: server zone)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "track_type (such as: live or share-loc)",
+                        "name": "track_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp, only track recorded at or after it",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp, only track recorded at or before it",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseGetTrackStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tracks/{app_source}/{created_by}/stream": {
+            "get": {
+                "description": "Pushes the user's new tracks as Server-Sent Events while the connection is open. Each point is a \"track\" event, idle connections get a \"heartbeat\" event, and a \"closed\" event ends the stream when the client fell too far behind.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Track"
+                ],
+                "summary": "Stream Track",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
+                        "name": "app_source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Track"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tracks/{app_source}/{created_by}/trash": {
+            "get": {
+                "description": "Returns the user's deleted track, most recently deleted first, until they are purged",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Track"
+                ],
+                "summary": "Get Trash Track",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
+                        "name": "app_source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of track per page, up to 500",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseGetTrashTrack"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tracks/{app_source}/{created_by}/trips": {
+            "get": {
+                "description": "Splits the user's track into trips on long pauses or jumps, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Track"
+                ],
+                "summary": "Get Track Trips",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
+                        "name": "app_source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "longest pause inside a trip, 1m up to 24h (default: 10m)",
+                        "name": "gap",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "longest jump in meters between two points of a trip, up to 100000 (default: 2000)",
+                        "name": "distance",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "track_type of the points (default: live)",
+                        "name": "track_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp, only track recorded at or after it",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp, only track recorded at or before it",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseGetTrackTrip"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tracks/{app_source}/{created_by}/usage": {
+            "get": {
+                "description": "Returns the points the user created today (UTC) against the daily quota, a zero limit means no quota",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Track"
+                ],
+                "summary": "Get Track Quota",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
+                        "name": "app_source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseGetTrackQuota"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tracks/{app_source}/{created_by}/{track_id}": {
+            "delete": {
+                "description": "Delete track by given id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Track"
+                ],
+                "summary": "Delete Track By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
+                        "name": "app_source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "track_id must be UUID",
+                        "name": "track_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseDeleteTrackById"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseNotFound"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tracks/{app_source}/{created_by}/{track_id}/recover": {
+            "put": {
+                "description": "Moves a deleted track out of the trash, back into the user's track",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Track"
+                ],
+                "summary": "Recover Track By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "app_source (such as: pinmarker, mi-fik, myride, or kumande)",
+                        "name": "app_source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "track_id must be UUID",
+                        "name": "track_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseRecoverTrackById"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseNotFound"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{created_by}": {
+            "delete": {
+                "description": "Permanently deletes everything stored about the user in every app source, reads it all again to verify nothing is left and keeps an audit record of the counts. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Erase User Data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseEraseUser"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{created_by}/apps": {
+            "get": {
+                "description": "Returns every app source a track of the user is stored under, trashed ones included. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get User Apps",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseGetUserApps"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{created_by}/erasures": {
+            "get": {
+                "description": "Returns the audit records of the user's erasures, newest first. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get All User Erasure",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseGetAllUserErasure"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{created_by}/export": {
+            "get": {
+                "description": "Downloads a zip of everything stored about the user in every app source, one JSON array per app and document with a manifest.json of the counts. Admin only.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Export User Data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "created_by must be UUID",
+                        "name": "created_by",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ResponseBadRequest"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entities.AppCount": {
+            "type": "object",
+            "properties": {
+                "app_name": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.Geofence": {
+            "type": "object",
+            "properties": {
+                "app_source": {
+                    "type": "string"
+                },
+                "center_lat": {
+                    "type": "number"
+                },
+                "center_long": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "fence_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "inside": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "polygon": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.GeofencePoint"
+                    }
+                },
+                "radius": {
+                    "type": "number"
+                },
+                "state_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.GeofenceEvent": {
+            "type": "object",
+            "properties": {
+                "app_source": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "geofence_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "recorded_at": {
+                    "type": "string"
+                },
+                "track_id": {
+                    "type": "string"
+                },
+                "track_lat": {
+                    "type": "number"
+                },
+                "track_long": {
+                    "type": "number"
+                }
+            }
+        },
+        "entities.GeofencePoint": {
+            "type": "object",
+            "properties": {
+                "lat": {
+                    "type": "number",
+                    "example": -6.228755
+                },
+                "long": {
+                    "type": "number",
+                    "example": 106.820035
+                }
+            }
+        },
+        "entities.RequestCreateGeofence": {
+            "type": "object",
+            "properties": {
+                "app_source": {
+                    "type": "string",
+                    "example": "myride"
+                },
+                "center_lat": {
+                    "type": "number",
+                    "example": -6.228755
+                },
+                "center_long": {
+                    "type": "number",
+                    "example": 106.820035
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "fence_type": {
+                    "type": "string",
+                    "example": "circle"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Home"
+                },
+                "polygon": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.GeofencePoint"
+                    }
+                },
+                "radius": {
+                    "type": "number",
+                    "example": 150
+                }
+            }
+        },
+        "entities.RequestCreateShare": {
+            "type": "object",
+            "properties": {
+                "app_source": {
+                    "type": "string",
+                    "example": "pinmarker"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "expires_in": {
+                    "type": "string",
+                    "example": "1h"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2025-06-23T11:30:15+07:00"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-06-23T13:30:15+07:00"
+                }
+            }
+        },
+        "entities.RequestCreateTrack": {
+            "type": "object",
+            "properties": {
+                "app_source": {
+                    "type": "string",
+                    "example": "pinmarker"
+                },
+                "battery_indicator": {
+                    "type": "integer",
+                    "example": 85
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "4dfabee1-e620-4b78-ab3a-93d71e51103c"
+                },
+                "recorded_at": {
+                    "type": "string",
+                    "example": "2025-06-23T11:30:15.913505+07:00"
+                },
+                "track_lat": {
+                    "type": "number",
+                    "example": -6.2
+                },
+                "track_long": {
+                    "type": "number",
+                    "example": 106.816666
+                },
+                "track_type": {
+                    "type": "string",
+                    "example": "live"
+                }
+            }
+        },
+        "entities.RequestCreateTrackMultiItem": {
+            "type": "object",
+            "properties": {
+                "app_source": {
+                    "type": "string",
+                    "example": "pinmarker"
+                },
+                "battery_indicator": {
+                    "type": "integer",
+                    "example": 85
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "4dfabee1-e620-4b78-ab3a-93d71e51103c"
+                },
+                "recorded_at": {
+                    "type": "string",
+                    "example": "2025-06-23T11:30:15.913505+07:00"
+                },
+                "track_lat": {
+                    "type": "number",
+                    "example": -6.2
+                },
+                "track_long": {
+                    "type": "number",
+                    "example": 106.816666
+                },
+                "track_type": {
+                    "type": "string",
+                    "example": "live"
+                }
+            }
+        },
+        "entities.RequestUpdateGeofence": {
+            "type": "object",
+            "properties": {
+                "center_lat": {
+                    "type": "number",
+                    "example": -6.228755
+                },
+                "center_long": {
+                    "type": "number",
+                    "example": 106.820035
+                },
+                "fence_type": {
+                    "type": "string",
+                    "example": "circle"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Home"
+                },
+                "polygon": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.GeofencePoint"
+                    }
+                },
+                "radius": {
+                    "type": "number",
+                    "example": 150
+                }
+            }
+        },
+        "entities.ResponseBadRequest": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "app_source is not valid"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "failed"
+                }
+            }
+        },
+        "entities.ResponseCreateGeofence": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/entities.Geofence"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Geofence created"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseCreateShare": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/entities.ShareLinkToken"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Share created"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseCreateTrack": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/entities.Track"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Track created"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseCreateTrackMulti": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Track"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Track created"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseCreateTrackMultiPartial": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.TrackBatchResult"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Track created"
+                },
+                "metadata": {
+                    "$ref": "#/definitions/entities.TrackBatchSummary"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseDeleteGeofence": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Geofence deleted"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseDeleteTrackById": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Track deleted"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseEraseUser": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/entities.UserErasure"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "User permanentally deleted"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseGetAllGeofence": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Geofence"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Geofence fetched"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseGetAllGeofenceEvent": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.GeofenceEvent"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Geofence event fetched"
+                },
+                "metadata": {
+                    "type": "object",
+                    "properties": {
+                        "limit": {
+                            "type": "integer",
+                            "example": 100
+                        },
+                        "total": {
+                            "type": "integer",
+                            "example": 2
+                        }
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseGetAllShare": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.ShareLink"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Share fetched"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseGetAllTrack": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Track"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Track fetched"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseGetAllUserErasure": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.UserErasure"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "User fetched"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseGetAppCount": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.AppCount"
+                    }
                 },
-                "battery_indicator": {
-                    "type": "integer",
-                    "example": 85
+                "message": {
+                    "type": "string",
+                    "example": "Track fetched"
                 },
-                "created_by": {
+                "status": {
                     "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseGetGeofence": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/entities.Geofence"
                 },
-                "track_lat": {
+                "message": {
                     "type": "string",
-                    "example": "-6.200000"
+                    "example": "Geofence fetched"
                 },
-                "track_long": {
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseGetLatestTrack": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/entities.Track"
+                },
+                "message": {
                     "type": "string",
-                    "example": "106.816666"
+                    "example": "Track fetched"
                 },
-                "track_type": {
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseGetLatestTrackBulk": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Track"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Track fetched"
+                },
+                "metadata": {
+                    "type": "object",
+                    "properties": {
+                        "not_found": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            },
+                            "example": [
+                                "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+                            ]
+                        },
+                        "total": {
+                            "type": "integer",
+                            "example": 2
+                        }
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseGetSharedTrack": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Track"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Track fetched"
+                },
+                "metadata": {
+                    "type": "object",
+                    "properties": {
+                        "app_source": {
+                            "type": "string",
+                            "example": "pinmarker"
+                        },
+                        "created_by": {
+                            "type": "string",
+                            "example": "123e4567-e89b-12d3-a456-426614174000"
+                        },
+                        "expires_at": {
+                            "type": "string",
+                            "example": "2025-06-23T12:30:15+07:00"
+                        },
+                        "total": {
+                            "type": "integer",
+                            "example": 42
+                        }
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseGetTrackArea": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Track"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Track fetched"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseGetTrackNearby": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.TrackNearby"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Track fetched"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseGetTrackQuota": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/entities.TrackQuota"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Track fetched"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseGetTrackStats": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.TrackDayStats"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Track fetched"
+                },
+                "metadata": {
+                    "type": "object",
+                    "properties": {
+                        "total": {
+                            "type": "integer",
+                            "example": 7
+                        },
+                        "tz": {
+                            "type": "string",
+                            "example": "Asia/Jakarta"
+                        }
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseGetTrackTrip": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Trip"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Track fetched"
+                },
+                "metadata": {
+                    "type": "object",
+                    "properties": {
+                        "distance": {
+                            "type": "number",
+                            "example": 2000
+                        },
+                        "gap": {
+                            "type": "string",
+                            "example": "10m0s"
+                        },
+                        "total": {
+                            "type": "integer",
+                            "example": 3
+                        }
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseGetTrashTrack": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.TrackTrash"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Track fetched"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseGetUserApps": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "pinmarker",
+                        "myride"
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "User fetched"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseImportTrack": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.TrackBatchResult"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Track created"
+                },
+                "metadata": {
+                    "$ref": "#/definitions/entities.TrackImportSummary"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "entities.ResponseNotFound": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "track not found"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "failed"
+                }
+            }
+        },
+        "entities.ResponseRecoverTrackById": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/entities.Track"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Track recovered"
+                },
+                "status": {
                     "type": "string",
-                    "example": "live"
+                    "example": "success"
                 }
             }
         },
-        "entities.ResponseBadRequest": {
+        "entities.ResponseRevokeShare": {
             "type": "object",
             "properties": {
                 "message": {
                     "type": "string",
-                    "example": "app_source is not valid"
+                    "example": "Share deleted"
                 },
                 "status": {
                     "type": "string",
-                    "example": "failed"
+                    "example": "success"
                 }
             }
         },
-        "entities.ResponseCreateTrack": {
+        "entities.ResponseUpdateGeofence": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/entities.Track"
+                    "$ref": "#/definitions/entities.Geofence"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Track created"
+                    "example": "Geofence updated"
                 },
                 "status": {
                     "type": "string",
@@ -285,71 +3216,273 @@ const docTemplate = `{
                 }
             }
         },
-        "entities.ResponseCreateTrackMulti": {
+        "entities.ShareLink": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entities.Track"
-                    }
+                "app_source": {
+                    "type": "string"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "Track created"
+                "created_at": {
+                    "type": "string"
                 },
-                "status": {
+                "created_by": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.ShareLinkToken": {
+            "type": "object",
+            "properties": {
+                "app_source": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "token": {
                     "type": "string",
-                    "example": "success"
+                    "example": "eyJpZCI6IjRkZmFiZWUxIn0.T0tFTg"
                 }
             }
         },
-        "entities.ResponseDeleteTrackById": {
+        "entities.Track": {
             "type": "object",
             "properties": {
-                "message": {
+                "app_source": {
+                    "type": "string"
+                },
+                "battery_indicator": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "geohash": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "received_at": {
+                    "type": "string"
+                },
+                "recorded_at": {
+                    "type": "string"
+                },
+                "track_lat": {
+                    "type": "number"
+                },
+                "track_long": {
+                    "type": "number"
+                },
+                "track_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.TrackBatchResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "track latitude is required"
+                },
+                "id": {
                     "type": "string",
-                    "example": "Track permanentally deleted"
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
                 },
                 "status": {
                     "type": "string",
-                    "example": "success"
+                    "example": "created"
                 }
             }
         },
-        "entities.ResponseGetAllTrack": {
+        "entities.TrackBatchSummary": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entities.Track"
-                    }
+                "created": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "message": {
-                    "type": "string",
-                    "example": "Track fetched"
+                "failed": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "status": {
+                "total": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "entities.TrackDayStats": {
+            "type": "object",
+            "properties": {
+                "avg_speed": {
+                    "type": "number",
+                    "example": 4.12
+                },
+                "battery_max": {
+                    "type": "integer",
+                    "example": 98
+                },
+                "battery_min": {
+                    "type": "integer",
+                    "example": 41
+                },
+                "date": {
                     "type": "string",
-                    "example": "success"
+                    "example": "2025-06-23"
+                },
+                "distance": {
+                    "type": "number",
+                    "example": 12840.5
+                },
+                "max_speed": {
+                    "type": "number",
+                    "example": 16.4
+                },
+                "moving_time": {
+                    "type": "number",
+                    "example": 3120
+                },
+                "point_count": {
+                    "type": "integer",
+                    "example": 312
                 }
             }
         },
-        "entities.ResponseNotFound": {
+        "entities.TrackImportSummary": {
             "type": "object",
             "properties": {
-                "message": {
+                "imported": {
+                    "type": "integer",
+                    "example": 118
+                },
+                "rejected": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "entities.TrackNearby": {
+            "type": "object",
+            "properties": {
+                "app_source": {
+                    "type": "string"
+                },
+                "battery_indicator": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "distance": {
+                    "type": "number"
+                },
+                "geohash": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "received_at": {
+                    "type": "string"
+                },
+                "recorded_at": {
+                    "type": "string"
+                },
+                "track_lat": {
+                    "type": "number"
+                },
+                "track_long": {
+                    "type": "number"
+                },
+                "track_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.TrackQuota": {
+            "type": "object",
+            "properties": {
+                "app_source": {
                     "type": "string",
-                    "example": "track not found"
+                    "example": "myride"
                 },
-                "status": {
+                "created_by": {
                     "type": "string",
-                    "example": "failed"
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "day": {
+                    "type": "string",
+                    "example": "2025-06-23"
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10000
+                },
+                "remaining": {
+                    "type": "integer",
+                    "example": 9880
+                },
+                "resets_at": {
+                    "type": "string",
+                    "example": "2025-06-24T00:00:00Z"
+                },
+                "used": {
+                    "type": "integer",
+                    "example": 120
                 }
             }
         },
-        "entities.Track": {
+        "entities.TrackTrash": {
             "type": "object",
             "properties": {
                 "app_source": {
@@ -364,19 +3497,123 @@ const docTemplate = `{
                 "created_by": {
                     "type": "string"
                 },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "geohash": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
-                "track_lat": {
+                "received_at": {
                     "type": "string"
                 },
-                "track_long": {
+                "recorded_at": {
                     "type": "string"
                 },
+                "track_lat": {
+                    "type": "number"
+                },
+                "track_long": {
+                    "type": "number"
+                },
                 "track_type": {
                     "type": "string"
                 }
             }
+        },
+        "entities.Trip": {
+            "type": "object",
+            "properties": {
+                "bearing": {
+                    "type": "number",
+                    "example": 7.6
+                },
+                "distance": {
+                    "type": "number",
+                    "example": 6120.45
+                },
+                "duration": {
+                    "type": "number",
+                    "example": 1666.2
+                },
+                "end_at": {
+                    "type": "string",
+                    "example": "2025-06-23T11:58:02.113505+07:00"
+                },
+                "end_lat": {
+                    "type": "number",
+                    "example": -6.175392
+                },
+                "end_long": {
+                    "type": "number",
+                    "example": 106.827153
+                },
+                "point_count": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "start_at": {
+                    "type": "string",
+                    "example": "2025-06-23T11:30:15.913505+07:00"
+                },
+                "start_lat": {
+                    "type": "number",
+                    "example": -6.228755
+                },
+                "start_long": {
+                    "type": "number",
+                    "example": 106.820035
+                }
+            }
+        },
+        "entities.UserDataApp": {
+            "type": "object",
+            "properties": {
+                "app_source": {
+                    "type": "string",
+                    "example": "pinmarker"
+                },
+                "records": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "entities.UserErasure": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "deleted": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.UserDataApp"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "remaining": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.UserDataApp"
+                    }
+                },
+                "requested_by": {
+                    "type": "string"
+                },
+                "verified": {
+                    "type": "boolean"
+                }
+            }
         }
     }
 }`
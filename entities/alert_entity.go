@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// TrackAlert is the last alert sent for a user, one per alert type, kept until the
+	// condition clears so the same alert is not sent again. Pending lists the recipients
+	// that have not got it yet.
+	TrackAlert struct {
+		AppsSource       string    `json:"app_source" gorm:"type:varchar(36);primaryKey"`
+		CreatedBy        uuid.UUID `json:"created_by" gorm:"type:varchar(36);primaryKey"`
+		AlertType        string    `json:"alert_type" gorm:"type:varchar(36);primaryKey"`
+		TrackID          uuid.UUID `json:"track_id" gorm:"type:varchar(36);not null"`
+		BatteryIndicator int       `json:"battery_indicator" gorm:"type:int;not null"`
+		RecordedAt       time.Time `json:"recorded_at" gorm:"type:timestamp;not null"`
+		SentAt           time.Time `json:"sent_at" gorm:"type:timestamp;not null"`
+		Pending          []string  `json:"pending,omitempty" gorm:"type:text;serializer:json"`
+	}
+)
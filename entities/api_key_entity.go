@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	ApiKey struct {
+		ID         uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+		AppsSource string     `json:"app_source" gorm:"type:varchar(36);not null;index"`
+		KeyHash    string     `json:"key_hash" gorm:"type:varchar(64);not null;uniqueIndex"`
+		Prefix     string     `json:"prefix" gorm:"type:varchar(16);not null"`
+		CreatedAt  time.Time  `json:"created_at" gorm:"type:timestamp;not null"`
+		ExpiresAt  *time.Time `json:"expires_at,omitempty" gorm:"type:timestamp"`
+		RevokedAt  *time.Time `json:"revoked_at,omitempty" gorm:"type:timestamp"`
+	}
+)
+
+// Active tells if the key is still accepted at the given time
+func (k *ApiKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+)
+
+// Coordinate is a latitude or longitude in degrees. It is stored and returned as a number,
+// but older app versions still send it as a string so both are accepted
+type Coordinate float64
+
+var ErrCoordinateNotNumber = errors.New("coordinate must be a number")
+
+func (c *Coordinate) UnmarshalJSON(data []byte) error {
+	raw := bytes.TrimSpace(data)
+	if bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+
+	// Legacy : String Coordinate
+	if len(raw) > 0 && raw[0] == '"' {
+		var str string
+		if err := json.Unmarshal(raw, &str); err != nil {
+			return ErrCoordinateNotNumber
+		}
+		raw = bytes.TrimSpace([]byte(str))
+	}
+
+	val, err := strconv.ParseFloat(string(raw), 64)
+	if err != nil || math.IsNaN(val) || math.IsInf(val, 0) {
+		return ErrCoordinateNotNumber
+	}
+	*c = Coordinate(val)
+
+	return nil
+}
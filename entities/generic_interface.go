@@ -7,6 +7,11 @@ type (
 		Total      int `json:"total"`
 		TotalPages int `json:"total_pages"`
 	}
+	MetadataCursor struct {
+		Limit      int    `json:"limit"`
+		NextCursor string `json:"next_cursor"`
+		PrevCursor string `json:"prev_cursor"`
+	}
 	// For Response
 	ResponseBadRequest struct {
 		Message string `json:"message" example:"app_source is not valid"`
@@ -0,0 +1,120 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	Geofence struct {
+		ID         uuid.UUID       `json:"id" gorm:"type:varchar(36);primaryKey"`
+		Name       string          `json:"name" gorm:"type:varchar(100);not null"`
+		FenceType  string          `json:"fence_type" gorm:"type:varchar(36);not null"`
+		CenterLat  *Coordinate     `json:"center_lat,omitempty" swaggertype:"number" gorm:"type:double precision"`
+		CenterLong *Coordinate     `json:"center_long,omitempty" swaggertype:"number" gorm:"type:double precision"`
+		Radius     float64         `json:"radius,omitempty" gorm:"type:double precision"`
+		Polygon    GeofencePolygon `json:"polygon,omitempty" gorm:"type:text"`
+		Inside     *bool           `json:"inside,omitempty"`
+		StateAt    *time.Time      `json:"state_at,omitempty" gorm:"type:timestamp"`
+		AppsSource string          `json:"app_source" gorm:"type:varchar(36);not null;index:idx_geofences_app_user,priority:1"`
+		CreatedAt  time.Time       `json:"created_at" gorm:"type:timestamp;not null"`
+		UpdatedAt  time.Time       `json:"updated_at" gorm:"type:timestamp;not null"`
+		CreatedBy  uuid.UUID       `json:"created_by" gorm:"type:varchar(36);not null;index:idx_geofences_app_user,priority:2"`
+	}
+	GeofencePoint struct {
+		Lat  Coordinate `json:"lat" swaggertype:"number" example:"-6.228755"`
+		Long Coordinate `json:"long" swaggertype:"number" example:"106.820035"`
+	}
+	GeofencePolygon []GeofencePoint
+	GeofenceEvent   struct {
+		ID         uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+		GeofenceID uuid.UUID  `json:"geofence_id" gorm:"type:varchar(36);not null;index"`
+		EventType  string     `json:"event_type" gorm:"type:varchar(36);not null"`
+		TrackID    uuid.UUID  `json:"track_id" gorm:"type:varchar(36);not null"`
+		TrackLat   Coordinate `json:"track_lat" swaggertype:"number" gorm:"type:double precision;not null"`
+		TrackLong  Coordinate `json:"track_long" swaggertype:"number" gorm:"type:double precision;not null"`
+		AppsSource string     `json:"app_source" gorm:"type:varchar(36);not null;index:idx_geofence_events_app_user_recorded,priority:1"`
+		RecordedAt time.Time  `json:"recorded_at" gorm:"type:timestamp;not null;index:idx_geofence_events_app_user_recorded,priority:3"`
+		CreatedAt  time.Time  `json:"created_at" gorm:"type:timestamp;not null"`
+		CreatedBy  uuid.UUID  `json:"created_by" gorm:"type:varchar(36);not null;index:idx_geofence_events_app_user_recorded,priority:2"`
+	}
+	// For Response
+	ResponseCreateGeofence struct {
+		Message string   `json:"message" example:"Geofence created"`
+		Status  string   `json:"status" example:"success"`
+		Data    Geofence `json:"data"`
+	}
+	ResponseGetAllGeofence struct {
+		Message string     `json:"message" example:"Geofence fetched"`
+		Status  string     `json:"status" example:"success"`
+		Data    []Geofence `json:"data"`
+	}
+	ResponseGetGeofence struct {
+		Message string   `json:"message" example:"Geofence fetched"`
+		Status  string   `json:"status" example:"success"`
+		Data    Geofence `json:"data"`
+	}
+	ResponseUpdateGeofence struct {
+		Message string   `json:"message" example:"Geofence updated"`
+		Status  string   `json:"status" example:"success"`
+		Data    Geofence `json:"data"`
+	}
+	ResponseDeleteGeofence struct {
+		Message string `json:"message" example:"Geofence deleted"`
+		Status  string `json:"status" example:"success"`
+	}
+	ResponseGetAllGeofenceEvent struct {
+		Message  string          `json:"message" example:"Geofence event fetched"`
+		Status   string          `json:"status" example:"success"`
+		Data     []GeofenceEvent `json:"data"`
+		Metadata struct {
+			Total int `json:"total" example:"2"`
+			Limit int `json:"limit" example:"100"`
+		} `json:"metadata"`
+	}
+	// For Request
+	RequestUpdateGeofence struct {
+		Name       string          `json:"name" example:"Home"`
+		FenceType  string          `json:"fence_type" example:"circle"`
+		CenterLat  *Coordinate     `json:"center_lat,omitempty" swaggertype:"number" example:"-6.228755"`
+		CenterLong *Coordinate     `json:"center_long,omitempty" swaggertype:"number" example:"106.820035"`
+		Radius     float64         `json:"radius,omitempty" example:"150"`
+		Polygon    GeofencePolygon `json:"polygon,omitempty"`
+	}
+	RequestCreateGeofence struct {
+		RequestUpdateGeofence
+		AppsSource string    `json:"app_source" example:"myride"`
+		CreatedBy  uuid.UUID `json:"created_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+	}
+)
+
+// Value stores the polygon as a JSON array in a text column
+func (p GeofencePolygon) Value() (driver.Value, error) {
+	if len(p) == 0 {
+		return nil, nil
+	}
+	j, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(j), nil
+}
+
+func (p *GeofencePolygon) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*p = nil
+		return nil
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	case []byte:
+		return json.Unmarshal(v, p)
+	default:
+		return errors.New("geofence polygon must be stored as text")
+	}
+}
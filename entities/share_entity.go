@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// ShareLink scopes a share token to one user's points, the token itself is never stored
+	ShareLink struct {
+		ID         uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+		From       *time.Time `json:"from,omitempty" gorm:"type:timestamp"`
+		To         *time.Time `json:"to,omitempty" gorm:"type:timestamp"`
+		ExpiresAt  time.Time  `json:"expires_at" gorm:"type:timestamp;not null"`
+		RevokedAt  *time.Time `json:"revoked_at,omitempty" gorm:"type:timestamp"`
+		AppsSource string     `json:"app_source" gorm:"type:varchar(36);not null;index:idx_share_links_app_user,priority:1"`
+		CreatedAt  time.Time  `json:"created_at" gorm:"type:timestamp;not null"`
+		CreatedBy  uuid.UUID  `json:"created_by" gorm:"type:varchar(36);not null;index:idx_share_links_app_user,priority:2"`
+	}
+	ShareLinkToken struct {
+		ShareLink
+		Token string `json:"token" example:"eyJpZCI6IjRkZmFiZWUxIn0.T0tFTg"`
+	}
+	// For Response
+	ResponseCreateShare struct {
+		Message string         `json:"message" example:"Share created"`
+		Status  string         `json:"status" example:"success"`
+		Data    ShareLinkToken `json:"data"`
+	}
+	ResponseGetAllShare struct {
+		Message string      `json:"message" example:"Share fetched"`
+		Status  string      `json:"status" example:"success"`
+		Data    []ShareLink `json:"data"`
+	}
+	ResponseRevokeShare struct {
+		Message string `json:"message" example:"Share deleted"`
+		Status  string `json:"status" example:"success"`
+	}
+	ResponseGetSharedTrack struct {
+		Message  string  `json:"message" example:"Track fetched"`
+		Status   string  `json:"status" example:"success"`
+		Data     []Track `json:"data"`
+		Metadata struct {
+			Total      int       `json:"total" example:"42"`
+			AppsSource string    `json:"app_source" example:"pinmarker"`
+			CreatedBy  uuid.UUID `json:"created_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+			ExpiresAt  time.Time `json:"expires_at" example:"2025-06-23T12:30:15+07:00"`
+		} `json:"metadata"`
+	}
+	// For Request
+	RequestCreateShare struct {
+		AppsSource string     `json:"app_source" example:"pinmarker"`
+		CreatedBy  uuid.UUID  `json:"created_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+		ExpiresIn  string     `json:"expires_in,omitempty" example:"1h"`
+		From       *time.Time `json:"from,omitempty" example:"2025-06-23T11:30:15+07:00"`
+		To         *time.Time `json:"to,omitempty" example:"2025-06-23T13:30:15+07:00"`
+	}
+)
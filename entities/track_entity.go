@@ -4,18 +4,82 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type (
 	Track struct {
-		ID               uuid.UUID `json:"id" gorm:"type:varchar(36);primaryKey"`
-		BatteryIndicator int       `json:"battery_indicator" gorm:"type:varchar(144);not null"`
-		TrackLat         string    `json:"track_lat" gorm:"type:varchar(255);not null"`
-		TrackLong        string    `json:"track_long" gorm:"type:varchar(255);not null"`
-		TrackType        string    `json:"track_type" gorm:"type:varchar(36);not null"`
-		AppsSource       string    `json:"app_source" gorm:"type:varchar(36);not null"`
-		CreatedAt        time.Time `json:"created_at" gorm:"type:timestamp;not null"`
-		CreatedBy        uuid.UUID `json:"created_by" gorm:"not null"`
+		ID               uuid.UUID  `json:"id" gorm:"type:varchar(36);primaryKey"`
+		BatteryIndicator int        `json:"battery_indicator" gorm:"type:int;not null"`
+		TrackLat         Coordinate `json:"track_lat" swaggertype:"number" gorm:"type:double precision;not null"`
+		TrackLong        Coordinate `json:"track_long" swaggertype:"number" gorm:"type:double precision;not null"`
+		Geohash          string     `json:"geohash,omitempty" gorm:"type:varchar(12);index"`
+		TrackType        string     `json:"track_type" gorm:"type:varchar(36);not null"`
+		AppsSource       string     `json:"app_source" gorm:"type:varchar(36);not null;index:idx_tracks_app_user_created,priority:1"`
+		RecordedAt       time.Time  `json:"recorded_at" gorm:"type:timestamp"`
+		ReceivedAt       time.Time  `json:"received_at" gorm:"type:timestamp;index"`
+		CreatedAt        time.Time  `json:"created_at" gorm:"type:timestamp;not null;index:idx_tracks_app_user_created,priority:3;index"`
+		CreatedBy        uuid.UUID  `json:"created_by" gorm:"type:varchar(36);not null;index:idx_tracks_app_user_created,priority:2"`
+		// Soft Delete : Only set on the tracks in the trash, listed as TrackTrash
+		DeletedAt gorm.DeletedAt `json:"-" swaggerignore:"true" gorm:"index"`
+		// Replayed : Set by Create & CreateBatch when the client id was already stored, never saved
+		Replayed bool `json:"-" swaggerignore:"true" gorm:"-"`
+	}
+	// TrackTrash is a soft deleted track until it is recovered or purged
+	TrackTrash struct {
+		Track
+		DeletedAt time.Time `json:"deleted_at"`
+	}
+	// TrackLatest points at the newest track of each app & user
+	TrackLatest struct {
+		AppsSource string    `json:"app_source" gorm:"type:varchar(36);primaryKey"`
+		CreatedBy  uuid.UUID `json:"created_by" gorm:"type:varchar(36);primaryKey"`
+		TrackID    uuid.UUID `json:"track_id" gorm:"type:varchar(36);not null"`
+		CreatedAt  time.Time `json:"created_at" gorm:"type:timestamp;not null;index"`
+	}
+	TrackNearby struct {
+		Track
+		Distance float64 `json:"distance"`
+	}
+	Trip struct {
+		StartAt    time.Time  `json:"start_at" example:"2025-06-23T11:30:15.913505+07:00"`
+		EndAt      time.Time  `json:"end_at" example:"2025-06-23T11:58:02.113505+07:00"`
+		StartLat   Coordinate `json:"start_lat" swaggertype:"number" example:"-6.228755"`
+		StartLong  Coordinate `json:"start_long" swaggertype:"number" example:"106.820035"`
+		EndLat     Coordinate `json:"end_lat" swaggertype:"number" example:"-6.175392"`
+		EndLong    Coordinate `json:"end_long" swaggertype:"number" example:"106.827153"`
+		PointCount int        `json:"point_count" example:"42"`
+		Distance   float64    `json:"distance" example:"6120.45"`
+		Duration   float64    `json:"duration" example:"1666.2"`
+		Bearing    float64    `json:"bearing" example:"7.6"`
+	}
+	TrackDayStats struct {
+		Date       string  `json:"date" example:"2025-06-23"`
+		Distance   float64 `json:"distance" example:"12840.5"`
+		MovingTime float64 `json:"moving_time" example:"3120"`
+		AvgSpeed   float64 `json:"avg_speed" example:"4.12"`
+		MaxSpeed   float64 `json:"max_speed" example:"16.4"`
+		PointCount int     `json:"point_count" example:"312"`
+		BatteryMin int     `json:"battery_min" example:"41"`
+		BatteryMax int     `json:"battery_max" example:"98"`
+	}
+	TrackBatchResult struct {
+		Index  int        `json:"index" example:"0"`
+		Status string     `json:"status" example:"created"`
+		ID     *uuid.UUID `json:"id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+		Error  string     `json:"error,omitempty" example:"track latitude is required"`
+		// Replayed : The client id was already stored, the point is not counted again
+		Replayed bool `json:"-" swaggerignore:"true"`
+	}
+	TrackBatchSummary struct {
+		Total   int `json:"total" example:"3"`
+		Created int `json:"created" example:"2"`
+		Failed  int `json:"failed" example:"1"`
+	}
+	TrackImportSummary struct {
+		Total    int `json:"total" example:"120"`
+		Imported int `json:"imported" example:"118"`
+		Rejected int `json:"rejected" example:"2"`
 	}
 	// For Response
 	ResponseCreateTrack struct {
@@ -28,31 +92,95 @@ type (
 		Status  string  `json:"status" example:"success"`
 		Data    []Track `json:"data"`
 	}
+	ResponseCreateTrackMultiPartial struct {
+		Message  string             `json:"message" example:"Track created"`
+		Status   string             `json:"status" example:"success"`
+		Data     []TrackBatchResult `json:"data"`
+		Metadata TrackBatchSummary  `json:"metadata"`
+	}
+	ResponseImportTrack struct {
+		Message  string             `json:"message" example:"Track created"`
+		Status   string             `json:"status" example:"success"`
+		Data     []TrackBatchResult `json:"data"`
+		Metadata TrackImportSummary `json:"metadata"`
+	}
 	ResponseGetAllTrack struct {
 		Message string  `json:"message" example:"Track fetched"`
 		Status  string  `json:"status" example:"success"`
 		Data    []Track `json:"data"`
 	}
+	ResponseGetTrashTrack struct {
+		Message string       `json:"message" example:"Track fetched"`
+		Status  string       `json:"status" example:"success"`
+		Data    []TrackTrash `json:"data"`
+	}
+	ResponseRecoverTrackById struct {
+		Message string `json:"message" example:"Track recovered"`
+		Status  string `json:"status" example:"success"`
+		Data    Track  `json:"data"`
+	}
+	ResponseGetTrackArea struct {
+		Message string  `json:"message" example:"Track fetched"`
+		Status  string  `json:"status" example:"success"`
+		Data    []Track `json:"data"`
+	}
+	ResponseGetTrackNearby struct {
+		Message string        `json:"message" example:"Track fetched"`
+		Status  string        `json:"status" example:"success"`
+		Data    []TrackNearby `json:"data"`
+	}
+	ResponseGetTrackTrip struct {
+		Message  string `json:"message" example:"Track fetched"`
+		Status   string `json:"status" example:"success"`
+		Data     []Trip `json:"data"`
+		Metadata struct {
+			Total    int     `json:"total" example:"3"`
+			Gap      string  `json:"gap" example:"10m0s"`
+			Distance float64 `json:"distance" example:"2000"`
+		} `json:"metadata"`
+	}
+	ResponseGetTrackStats struct {
+		Message  string          `json:"message" example:"Track fetched"`
+		Status   string          `json:"status" example:"success"`
+		Data     []TrackDayStats `json:"data"`
+		Metadata struct {
+			Total    int    `json:"total" example:"7"`
+			TimeZone string `json:"tz" example:"Asia/Jakarta"`
+		} `json:"metadata"`
+	}
+	ResponseGetLatestTrack struct {
+		Message string `json:"message" example:"Track fetched"`
+		Status  string `json:"status" example:"success"`
+		Data    Track  `json:"data"`
+	}
+	ResponseGetLatestTrackBulk struct {
+		Message  string  `json:"message" example:"Track fetched"`
+		Status   string  `json:"status" example:"success"`
+		Data     []Track `json:"data"`
+		Metadata struct {
+			Total    int      `json:"total" example:"2"`
+			NotFound []string `json:"not_found" example:"fcd3f23e-e5aa-11ee-892a-3216422910e9"`
+		} `json:"metadata"`
+	}
 	ResponseDeleteTrackById struct {
-		Message string `json:"message" example:"Track permanentally deleted"`
+		Message string `json:"message" example:"Track deleted"`
 		Status  string `json:"status" example:"success"`
 	}
 	// For Request
 	RequestCreateTrack struct {
-		BatteryIndicator int       `json:"battery_indicator" example:"85"`
-		TrackLat         string    `json:"track_lat" example:"-6.200000"`
-		TrackLong        string    `json:"track_long" example:"106.816666"`
-		TrackType        string    `json:"track_type" example:"live"`
-		AppsSource       string    `json:"app_source" example:"pinmarker"`
-		CreatedBy        uuid.UUID `json:"created_by" example:"123e4567-e89b-12d3-a456-426614174000"`
-	}
-	RequestCreateTrackMulti []struct {
-		BatteryIndicator int       `json:"battery_indicator" example:"85"`
-		TrackLat         string    `json:"track_lat" example:"-6.200000"`
-		TrackLong        string    `json:"track_long" example:"106.816666"`
-		TrackType        string    `json:"track_type" example:"live"`
-		AppsSource       string    `json:"app_source" example:"pinmarker"`
-		CreatedAt        time.Time `json:"created_at" example:"2025-06-23T11:30:15.913505+07:00"`
-		CreatedBy        uuid.UUID `json:"created_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+		ID               *uuid.UUID  `json:"id,omitempty" example:"4dfabee1-e620-4b78-ab3a-93d71e51103c"`
+		BatteryIndicator int         `json:"battery_indicator" example:"85"`
+		TrackLat         *Coordinate `json:"track_lat" swaggertype:"number" example:"-6.2"`
+		TrackLong        *Coordinate `json:"track_long" swaggertype:"number" example:"106.816666"`
+		TrackType        string      `json:"track_type" example:"live"`
+		AppsSource       string      `json:"app_source" example:"pinmarker"`
+		RecordedAt       *time.Time  `json:"recorded_at,omitempty" example:"2025-06-23T11:30:15.913505+07:00"`
+		CreatedBy        uuid.UUID   `json:"created_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+	}
+	RequestCreateTrackMultiItem struct {
+		RequestCreateTrack
+		// Deprecated : Legacy name of recorded_at
+		CreatedAt time.Time `json:"created_at,omitempty" swaggerignore:"true"`
 	}
+	RequestCreateTrackMulti []RequestCreateTrackMultiItem
 )
@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// TrackUsage counts the points a user created on a UTC day, only the current day is kept
+	TrackUsage struct {
+		AppsSource string    `json:"app_source" gorm:"type:varchar(36);primaryKey"`
+		CreatedBy  uuid.UUID `json:"created_by" gorm:"type:varchar(36);primaryKey"`
+		Day        string    `json:"day" gorm:"type:varchar(10);not null"`
+		Points     int       `json:"points" gorm:"not null"`
+	}
+	TrackQuota struct {
+		AppsSource string    `json:"app_source" example:"myride"`
+		CreatedBy  uuid.UUID `json:"created_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+		Day        string    `json:"day" example:"2025-06-23"`
+		Used       int       `json:"used" example:"120"`
+		Limit      int       `json:"limit" example:"10000"`
+		Remaining  int       `json:"remaining" example:"9880"`
+		ResetsAt   time.Time `json:"resets_at" example:"2025-06-24T00:00:00Z"`
+	}
+	// For Response
+	ResponseGetTrackQuota struct {
+		Message string     `json:"message" example:"Track fetched"`
+		Status  string     `json:"status" example:"success"`
+		Data    TrackQuota `json:"data"`
+	}
+)
@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// UserDataApp counts a user's records of one app source, keyed by document name
+	UserDataApp struct {
+		AppsSource string         `json:"app_source" example:"pinmarker"`
+		Records    map[string]int `json:"records"`
+	}
+	// UserDataManifest describes a user data archive, it is written as manifest.json
+	UserDataManifest struct {
+		CreatedBy  uuid.UUID     `json:"created_by"`
+		ExportedAt time.Time     `json:"exported_at"`
+		Apps       []UserDataApp `json:"apps"`
+	}
+	// UserErasure is the audit record of a user's data erasure, it keeps the counts but none of the data
+	UserErasure struct {
+		ID          uuid.UUID     `json:"id" gorm:"type:varchar(36);primaryKey"`
+		CreatedBy   uuid.UUID     `json:"created_by" gorm:"type:varchar(36);not null;index"`
+		RequestedBy *uuid.UUID    `json:"requested_by,omitempty" gorm:"type:varchar(36)"`
+		Deleted     []UserDataApp `json:"deleted" gorm:"type:text;serializer:json"`
+		Remaining   []UserDataApp `json:"remaining" gorm:"type:text;serializer:json"`
+		Verified    bool          `json:"verified" gorm:"not null"`
+		CreatedAt   time.Time     `json:"created_at" gorm:"type:timestamp;not null"`
+	}
+	// For Response
+	ResponseGetUserApps struct {
+		Message string   `json:"message" example:"User fetched"`
+		Status  string   `json:"status" example:"success"`
+		Data    []string `json:"data" example:"pinmarker,myride"`
+	}
+	ResponseEraseUser struct {
+		Message string      `json:"message" example:"User permanentally deleted"`
+		Status  string      `json:"status" example:"success"`
+		Data    UserErasure `json:"data"`
+	}
+	ResponseGetAllUserErasure struct {
+		Message string        `json:"message" example:"User fetched"`
+		Status  string        `json:"status" example:"success"`
+		Data    []UserErasure `json:"data"`
+	}
+)
@@ -5,6 +5,7 @@ import (
 	"os"
 	"pinmarker/configs"
 	"pinmarker/routes"
+	"pinmarker/utils"
 	"time"
 
 	_ "pinmarker/docs"
@@ -44,8 +45,47 @@ func main() {
 		panic("error loading ENV")
 	}
 
-	// Init Firebase
-	configs.InitFirebaseApp()
+	// Init Track Tolerance
+	configs.InitTrackTolerance()
+
+	// Init Alert
+	configs.InitAlert()
+
+	// Init Share Secret
+	configs.InitShareSecret()
+
+	// Init Auth
+	configs.InitAuth()
+	configs.InitApiKey()
+
+	// Init Rate Limit
+	configs.InitRateLimit()
+
+	// Init Trash
+	configs.InitTrash()
+
+	// Init Repository Driver
+	driver := os.Getenv("REPOSITORY_DRIVER")
+	if driver == "" {
+		driver = "firebase"
+	}
+	if !utils.ValidatorContains(configs.RepositoryDrivers, driver) {
+		log.Fatalf("Repository driver %s is not valid", driver)
+	}
+	switch driver {
+	case "gorm":
+		configs.InitDatabase()
+	case "memory":
+		log.Println("Pinmarker is using in-memory repository, data will be lost on restart")
+	default:
+		configs.InitFirebaseApp()
+	}
+
+	// Command
+	if len(os.Args) > 1 {
+		routes.SetUpCommand(os.Args[1:])
+		return
+	}
 
 	// Init Gin
 	router := gin.Default()
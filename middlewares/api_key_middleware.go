@@ -0,0 +1,63 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"pinmarker/repositories"
+	"pinmarker/services"
+	"pinmarker/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Context key set by ApiKeyMiddleware
+const ApiKeyAppKey = "api_key_app"
+
+// ApiKeyMiddleware accepts a request only with an active X-API-Key, and only for the key's
+// own app source when the route has one in its path
+func ApiKeyMiddleware(apiKeyService services.ApiKeyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Service : Authenticate API Key
+		appsSource, err := apiKeyService.AuthenticateApiKey(c.GetHeader("X-API-Key"))
+		if errors.Is(err, repositories.ErrBackendUnavailable) {
+			utils.MessageResponseErrorBuild(c, http.StatusServiceUnavailable, err.Error())
+			c.Abort()
+			return
+		}
+		if err != nil {
+			utils.MessageResponseErrorBuild(c, http.StatusUnauthorized, utils.ErrApiKeyInvalid.Error())
+			c.Abort()
+			return
+		}
+		c.Set(ApiKeyAppKey, appsSource)
+
+		// Path : App Source
+		if !ApiKeyApp(c, c.Param("app_source")) {
+			utils.MessageResponseErrorBuild(c, http.StatusForbidden, utils.ErrApiKeyForbidden.Error())
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// ApiKeyAppSource is the app source of the request's API key, empty when the route is not
+// behind ApiKeyMiddleware
+func ApiKeyAppSource(c *gin.Context) string {
+	return c.GetString(ApiKeyAppKey)
+}
+
+// ApiKeyApp tells if the request may touch appsSource, always true when the route is not
+// behind ApiKeyMiddleware or has no app source
+func ApiKeyApp(c *gin.Context, appsSource string) bool {
+	if appsSource == "" {
+		return true
+	}
+	value, exists := c.Get(ApiKeyAppKey)
+	if !exists {
+		return true
+	}
+
+	return value.(string) == appsSource
+}
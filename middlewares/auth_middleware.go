@@ -0,0 +1,113 @@
+package middlewares
+
+import (
+	"net/http"
+	"pinmarker/utils"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// Context keys set by AuthMiddleware
+const (
+	AuthSubjectKey = "auth_subject"
+	AuthAdminKey   = "auth_admin"
+)
+
+// AuthMiddleware accepts a request only with a bearer JWT verified by keyFunc. The token must
+// expire and its subject must be a user UUID, it is stored in the context with the admin claim.
+func AuthMiddleware(keyFunc jwt.Keyfunc, adminClaim string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Header
+		raw, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || raw == "" {
+			authFailed(c, "authorization bearer token is required")
+			return
+		}
+
+		// Token
+		claims := jwt.MapClaims{}
+		if _, err := jwt.ParseWithClaims(raw, claims, keyFunc); err != nil {
+			authFailed(c, "token is not valid")
+			return
+		}
+		if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+			authFailed(c, "token is not valid")
+			return
+		}
+
+		// Subject
+		sub, _ := claims["sub"].(string)
+		subject, err := uuid.Parse(sub)
+		if err != nil {
+			authFailed(c, "token subject is not valid")
+			return
+		}
+		admin, _ := claims[adminClaim].(bool)
+
+		c.Set(AuthSubjectKey, subject)
+		c.Set(AuthAdminKey, admin)
+		c.Next()
+	}
+}
+
+// AuthOwner tells if the request may touch createdBy's data, always true when the route
+// is not behind AuthMiddleware
+func AuthOwner(c *gin.Context, createdBy uuid.UUID) bool {
+	value, exists := c.Get(AuthSubjectKey)
+	if !exists {
+		return true
+	}
+	if c.GetBool(AuthAdminKey) {
+		return true
+	}
+
+	return value.(uuid.UUID) == createdBy
+}
+
+// AuthAdmin tells if the request may touch any user's data in every app, only a token verified
+// by AuthMiddleware carrying the admin claim may
+func AuthAdmin(c *gin.Context) bool {
+	if _, exists := c.Get(AuthSubjectKey); !exists {
+		return false
+	}
+
+	return c.GetBool(AuthAdminKey)
+}
+
+// AuthSubject is the token's subject, nil when the route is not behind AuthMiddleware
+func AuthSubject(c *gin.Context) *uuid.UUID {
+	value, exists := c.Get(AuthSubjectKey)
+	if !exists {
+		return nil
+	}
+	subject := value.(uuid.UUID)
+
+	return &subject
+}
+
+// AdminMiddleware accepts a request only when AuthAdmin allows it, it must run after AuthMiddleware
+func AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, exists := c.Get(AuthSubjectKey); !exists {
+			authFailed(c, "admin token is required")
+			return
+		}
+		if !AuthAdmin(c) {
+			utils.MessageResponseErrorBuild(c, http.StatusForbidden, "admin token is required")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func authFailed(c *gin.Context, message string) {
+	c.Header("WWW-Authenticate", "Bearer")
+	utils.MessageResponseErrorBuild(c, http.StatusUnauthorized, message)
+	c.Abort()
+}
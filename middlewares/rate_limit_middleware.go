@@ -0,0 +1,45 @@
+package middlewares
+
+import (
+	"net/http"
+	"pinmarker/configs"
+	"pinmarker/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RateLimitMiddleware takes a token of the route's limit per app source, user & client IP.
+// They come from the path, or from the API key & the token when the path has none.
+func RateLimitMiddleware(name string, limiter *utils.RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, ok := configs.RateLimits[name]
+		if !ok || limit.Rate <= 0 {
+			c.Next()
+			return
+		}
+
+		// Key
+		appsSource := c.Param("app_source")
+		if appsSource == "" {
+			appsSource = c.GetString(ApiKeyAppKey)
+		}
+		createdBy := c.Param("created_by")
+		if subject, exists := c.Get(AuthSubjectKey); createdBy == "" && exists {
+			createdBy = subject.(uuid.UUID).String()
+		}
+		key := name + "|" + appsSource + "|" + createdBy + "|" + c.ClientIP()
+
+		// Limit
+		allowed, wait := limiter.Allow(key, limit, time.Now())
+		if !allowed {
+			c.Header("Retry-After", utils.RetryAfter(wait))
+			utils.MessageResponseErrorBuild(c, http.StatusTooManyRequests, "too many requests, slow down")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
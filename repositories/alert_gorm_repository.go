@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"fmt"
+	"pinmarker/configs"
+	"pinmarker/entities"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Alert Struct
+type alertGormRepository struct {
+	db *gorm.DB
+}
+
+// Alert Constructor
+func NewAlertGormRepository() AlertRepository {
+	db, err := configs.GormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize database: %v", err))
+	}
+
+	// Migrate Table
+	if err := db.AutoMigrate(&entities.TrackAlert{}); err != nil {
+		panic(fmt.Sprintf("failed to migrate track alert table: %v", err))
+	}
+
+	return &alertGormRepository{
+		db: db,
+	}
+}
+
+func (r *alertGormRepository) FindByUser(appsSource string, createdBy uuid.UUID) ([]*entities.TrackAlert, error) {
+	alerts := make([]*entities.TrackAlert, 0)
+
+	// Query
+	if err := r.db.
+		Where("apps_source = ? AND created_by = ?", appsSource, createdBy.String()).
+		Find(&alerts).Error; err != nil {
+		return nil, errGorm("failed to read from database", err)
+	}
+
+	return alerts, nil
+}
+
+func (r *alertGormRepository) Save(alert *entities.TrackAlert) error {
+	// Query : Replace the previous alert of the type
+	if err := r.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(alert).Error; err != nil {
+		return errGorm("failed to save to database", err)
+	}
+
+	return nil
+}
+
+func (r *alertGormRepository) Delete(appsSource string, createdBy uuid.UUID, alertType string) error {
+	// Query
+	if err := r.db.
+		Where("apps_source = ? AND created_by = ? AND alert_type = ?", appsSource, createdBy.String(), alertType).
+		Delete(&entities.TrackAlert{}).Error; err != nil {
+		return errGorm("failed to delete from database", err)
+	}
+
+	return nil
+}
+
+func (r *alertGormRepository) DeleteByUser(appsSource string, createdBy uuid.UUID) error {
+	// Query
+	if err := r.db.
+		Where("apps_source = ? AND created_by = ?", appsSource, createdBy.String()).
+		Delete(&entities.TrackAlert{}).Error; err != nil {
+		return errGorm("failed to delete from database", err)
+	}
+
+	return nil
+}
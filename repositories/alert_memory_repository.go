@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"pinmarker/entities"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// alertKey is the user & alert type an alert is kept under
+type alertKey struct {
+	appsSource string
+	createdBy  uuid.UUID
+	alertType  string
+}
+
+// Alert Struct
+type alertMemoryRepository struct {
+	mu     sync.RWMutex
+	alerts map[alertKey]entities.TrackAlert
+}
+
+// Alert Constructor
+func NewAlertMemoryRepository() AlertRepository {
+	return &alertMemoryRepository{
+		alerts: make(map[alertKey]entities.TrackAlert),
+	}
+}
+
+func (r *alertMemoryRepository) FindByUser(appsSource string, createdBy uuid.UUID) ([]*entities.TrackAlert, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	alerts := make([]*entities.TrackAlert, 0)
+	for key, alert := range r.alerts {
+		if key.appsSource == appsSource && key.createdBy == createdBy {
+			alert := alert
+			alerts = append(alerts, &alert)
+		}
+	}
+
+	return alerts, nil
+}
+
+func (r *alertMemoryRepository) Save(alert *entities.TrackAlert) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.alerts[alertKey{alert.AppsSource, alert.CreatedBy, alert.AlertType}] = *alert
+
+	return nil
+}
+
+func (r *alertMemoryRepository) Delete(appsSource string, createdBy uuid.UUID, alertType string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.alerts, alertKey{appsSource, createdBy, alertType})
+
+	return nil
+}
+
+func (r *alertMemoryRepository) DeleteByUser(appsSource string, createdBy uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key := range r.alerts {
+		if key.appsSource == appsSource && key.createdBy == createdBy {
+			delete(r.alerts, key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"pinmarker/configs"
+	"pinmarker/entities"
+
+	"firebase.google.com/go/v4/db"
+	"github.com/google/uuid"
+)
+
+// Alert Interface
+type AlertRepository interface {
+	FindByUser(appsSource string, createdBy uuid.UUID) ([]*entities.TrackAlert, error)
+	Save(alert *entities.TrackAlert) error
+	Delete(appsSource string, createdBy uuid.UUID, alertType string) error
+	DeleteByUser(appsSource string, createdBy uuid.UUID) error
+}
+
+// Alert Struct
+type alertRepository struct {
+	firebaseClient *db.Client
+	firebaseCtx    context.Context
+}
+
+// Alert Constructor
+func NewAlertRepository() AlertRepository {
+	client, ctx, err := configs.FirebaseDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize Firebase DB: %v", err))
+	}
+
+	return &alertRepository{
+		firebaseClient: client,
+		firebaseCtx:    ctx,
+	}
+}
+
+// alertPath is the user's node of the alert type
+func alertPath(appsSource string, createdBy uuid.UUID, alertType string) string {
+	return fmt.Sprintf("%s/%s/user_%s/%s", configs.TrackAlertDoc, appsSource, createdBy.String(), alertType)
+}
+
+func (r *alertRepository) FindByUser(appsSource string, createdBy uuid.UUID) ([]*entities.TrackAlert, error) {
+	// Doc Name
+	ref := r.firebaseClient.NewRef(fmt.Sprintf("%s/%s/user_%s", configs.TrackAlertDoc, appsSource, createdBy.String()))
+
+	// Query
+	var result map[string]*entities.TrackAlert
+	if err := ref.Get(r.firebaseCtx, &result); err != nil {
+		return nil, errBackend("failed to read from Firebase", err)
+	}
+	alerts := make([]*entities.TrackAlert, 0, len(result))
+	for _, alert := range result {
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, nil
+}
+
+func (r *alertRepository) Save(alert *entities.TrackAlert) error {
+	// Query
+	if err := r.firebaseClient.NewRef(alertPath(alert.AppsSource, alert.CreatedBy, alert.AlertType)).Set(r.firebaseCtx, alert); err != nil {
+		return errBackend("failed to save to Firebase", err)
+	}
+
+	return nil
+}
+
+func (r *alertRepository) Delete(appsSource string, createdBy uuid.UUID, alertType string) error {
+	// Query
+	if err := r.firebaseClient.NewRef(alertPath(appsSource, createdBy, alertType)).Delete(r.firebaseCtx); err != nil {
+		return errBackend("failed to delete from Firebase", err)
+	}
+
+	return nil
+}
+
+func (r *alertRepository) DeleteByUser(appsSource string, createdBy uuid.UUID) error {
+	// Query
+	ref := r.firebaseClient.NewRef(fmt.Sprintf("%s/%s/user_%s", configs.TrackAlertDoc, appsSource, createdBy.String()))
+	if err := ref.Delete(r.firebaseCtx); err != nil {
+		return errBackend("failed to delete from Firebase", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"pinmarker/configs"
+	"pinmarker/entities"
+
+	"gorm.io/gorm"
+)
+
+// API Key Struct
+type apiKeyGormRepository struct {
+	db *gorm.DB
+}
+
+// API Key Constructor
+func NewApiKeyGormRepository() ApiKeyRepository {
+	db, err := configs.GormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize database: %v", err))
+	}
+
+	// Migrate Table
+	if err := db.AutoMigrate(&entities.ApiKey{}); err != nil {
+		panic(fmt.Sprintf("failed to migrate api key table: %v", err))
+	}
+
+	return &apiKeyGormRepository{
+		db: db,
+	}
+}
+
+func (r *apiKeyGormRepository) Save(key *entities.ApiKey) error {
+	// Query
+	if err := r.db.Save(key).Error; err != nil {
+		return errGorm("failed to save to database", err)
+	}
+
+	return nil
+}
+
+func (r *apiKeyGormRepository) FindByHash(hash string) (*entities.ApiKey, error) {
+	var key entities.ApiKey
+
+	// Query
+	err := r.db.Where("key_hash = ?", hash).First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrApiKeyNotFound
+	}
+	if err != nil {
+		return nil, errGorm("failed to read from database", err)
+	}
+
+	return &key, nil
+}
+
+func (r *apiKeyGormRepository) FindAll(appsSource string) ([]*entities.ApiKey, error) {
+	keys := make([]*entities.ApiKey, 0)
+
+	// Query
+	query := r.db.Order("created_at DESC")
+	if appsSource != "" {
+		query = query.Where("apps_source = ?", appsSource)
+	}
+	if err := query.Find(&keys).Error; err != nil {
+		return nil, errGorm("failed to read from database", err)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"pinmarker/entities"
+	"sync"
+)
+
+// API Key Struct
+type apiKeyMemoryRepository struct {
+	mu   sync.RWMutex
+	keys map[string]entities.ApiKey
+}
+
+// API Key Constructor
+func NewApiKeyMemoryRepository() ApiKeyRepository {
+	return &apiKeyMemoryRepository{
+		keys: make(map[string]entities.ApiKey),
+	}
+}
+
+func (r *apiKeyMemoryRepository) Save(key *entities.ApiKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.keys[key.KeyHash] = *key
+
+	return nil
+}
+
+func (r *apiKeyMemoryRepository) FindByHash(hash string) (*entities.ApiKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.keys[hash]
+	if !ok {
+		return nil, ErrApiKeyNotFound
+	}
+
+	return &key, nil
+}
+
+func (r *apiKeyMemoryRepository) FindAll(appsSource string) ([]*entities.ApiKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]*entities.ApiKey, 0)
+	for _, key := range r.keys {
+		if appsSource == "" || key.AppsSource == appsSource {
+			key := key
+			keys = append(keys, &key)
+		}
+	}
+	apiKeySort(keys)
+
+	return keys, nil
+}
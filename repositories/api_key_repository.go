@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"sort"
+
+	"firebase.google.com/go/v4/db"
+)
+
+// API Key Interface
+type ApiKeyRepository interface {
+	Save(key *entities.ApiKey) error
+	FindByHash(hash string) (*entities.ApiKey, error)
+	FindAll(appsSource string) ([]*entities.ApiKey, error)
+}
+
+// API Key Struct
+type apiKeyRepository struct {
+	firebaseClient *db.Client
+	firebaseCtx    context.Context
+}
+
+// API Key Constructor
+func NewApiKeyRepository() ApiKeyRepository {
+	client, ctx, err := configs.FirebaseDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize Firebase DB: %v", err))
+	}
+
+	return &apiKeyRepository{
+		firebaseClient: client,
+		firebaseCtx:    ctx,
+	}
+}
+
+// apiKeyPath keys the record by its hash, a request looks its key up in one read
+func apiKeyPath(hash string) string {
+	return fmt.Sprintf("%s/%s", configs.ApiKeyDoc, hash)
+}
+
+func (r *apiKeyRepository) Save(key *entities.ApiKey) error {
+	// Query
+	if err := r.firebaseClient.NewRef(apiKeyPath(key.KeyHash)).Set(r.firebaseCtx, key); err != nil {
+		return errBackend("failed to save to Firebase", err)
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepository) FindByHash(hash string) (*entities.ApiKey, error) {
+	// Query
+	var key *entities.ApiKey
+	if err := r.firebaseClient.NewRef(apiKeyPath(hash)).Get(r.firebaseCtx, &key); err != nil {
+		return nil, errBackend("failed to read from Firebase", err)
+	}
+	if key == nil {
+		return nil, ErrApiKeyNotFound
+	}
+
+	return key, nil
+}
+
+func (r *apiKeyRepository) FindAll(appsSource string) ([]*entities.ApiKey, error) {
+	// Query : A handful of keys per app, read them all
+	var result map[string]*entities.ApiKey
+	if err := r.firebaseClient.NewRef(configs.ApiKeyDoc).Get(r.firebaseCtx, &result); err != nil {
+		return nil, errBackend("failed to read from Firebase", err)
+	}
+	keys := make([]*entities.ApiKey, 0, len(result))
+	for _, key := range result {
+		if appsSource == "" || key.AppsSource == appsSource {
+			keys = append(keys, key)
+		}
+	}
+	apiKeySort(keys)
+
+	return keys, nil
+}
+
+// apiKeySort orders the keys newest first
+func apiKeySort(keys []*entities.ApiKey) {
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.After(keys[j].CreatedAt)
+	})
+}
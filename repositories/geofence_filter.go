@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"pinmarker/entities"
+	"pinmarker/utils"
+	"sort"
+)
+
+// geofenceEventPage keeps the events matching the query, newest first up to the limit
+func geofenceEventPage(candidates []*entities.GeofenceEvent, query utils.GeofenceEventQuery) []*entities.GeofenceEvent {
+	events := make([]*entities.GeofenceEvent, 0, len(candidates))
+	for _, event := range candidates {
+		if query.GeofenceID != nil && event.GeofenceID != *query.GeofenceID {
+			continue
+		}
+		if query.From != nil && event.RecordedAt.Before(*query.From) {
+			continue
+		}
+		if query.To != nil && event.RecordedAt.After(*query.To) {
+			continue
+		}
+		events = append(events, event)
+	}
+
+	// Sort : Newest First
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].RecordedAt.Equal(events[j].RecordedAt) {
+			return events[i].RecordedAt.After(events[j].RecordedAt)
+		}
+		return events[i].ID.String() > events[j].ID.String()
+	})
+	if len(events) > query.Limit {
+		events = events[:query.Limit]
+	}
+
+	return events
+}
+
+// geofenceSort orders fences oldest first
+func geofenceSort(fences []*entities.Geofence) {
+	sort.Slice(fences, func(i, j int) bool {
+		if !fences[i].CreatedAt.Equal(fences[j].CreatedAt) {
+			return fences[i].CreatedAt.Before(fences[j].CreatedAt)
+		}
+		return fences[i].ID.String() < fences[j].ID.String()
+	})
+}
@@ -0,0 +1,184 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"pinmarker/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Geofence Struct
+type geofenceGormRepository struct {
+	db *gorm.DB
+}
+
+// Geofence Constructor
+func NewGeofenceGormRepository() GeofenceRepository {
+	db, err := configs.GormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize database: %v", err))
+	}
+
+	// Migrate Table
+	if err := db.AutoMigrate(&entities.Geofence{}, &entities.GeofenceEvent{}); err != nil {
+		panic(fmt.Sprintf("failed to migrate geofence tables: %v", err))
+	}
+
+	return &geofenceGormRepository{
+		db: db,
+	}
+}
+
+func (r *geofenceGormRepository) Create(fence *entities.Geofence) error {
+	// Default Field
+	fence.ID = uuid.New()
+	fence.CreatedAt = time.Now()
+	fence.UpdatedAt = fence.CreatedAt
+
+	// Query
+	if err := r.db.Create(fence).Error; err != nil {
+		return errGorm("failed to save to database", err)
+	}
+
+	return nil
+}
+
+func (r *geofenceGormRepository) FindAll(appsSource string, createdBy uuid.UUID) ([]*entities.Geofence, error) {
+	fences := make([]*entities.Geofence, 0)
+
+	// Query
+	if err := r.db.
+		Where("apps_source = ? AND created_by = ?", appsSource, createdBy.String()).
+		Order("created_at ASC, id ASC").
+		Find(&fences).Error; err != nil {
+		return nil, errGorm("failed to read from database", err)
+	}
+
+	return fences, nil
+}
+
+func (r *geofenceGormRepository) FindByID(appsSource string, createdBy uuid.UUID, geofenceID uuid.UUID) (*entities.Geofence, error) {
+	// Query
+	var fence entities.Geofence
+	err := r.db.
+		Where("id = ? AND apps_source = ? AND created_by = ?", geofenceID.String(), appsSource, createdBy.String()).
+		First(&fence).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrGeofenceNotFound
+	}
+	if err != nil {
+		return nil, errGorm("failed to read from database", err)
+	}
+
+	return &fence, nil
+}
+
+func (r *geofenceGormRepository) Update(fence *entities.Geofence) error {
+	// Default Field
+	fence.UpdatedAt = time.Now()
+
+	// Query : Select all so cleared fields are written too
+	result := r.db.Model(&entities.Geofence{}).
+		Where("id = ? AND apps_source = ? AND created_by = ?", fence.ID.String(), fence.AppsSource, fence.CreatedBy.String()).
+		Select("*").Omit("id", "created_at").
+		Updates(fence)
+	if result.Error != nil {
+		return errGorm("failed to save to database", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrGeofenceNotFound
+	}
+
+	return nil
+}
+
+func (r *geofenceGormRepository) DeleteByID(appsSource string, createdBy uuid.UUID, geofenceID uuid.UUID) error {
+	// Query
+	result := r.db.
+		Where("id = ? AND apps_source = ? AND created_by = ?", geofenceID.String(), appsSource, createdBy.String()).
+		Delete(&entities.Geofence{})
+	if result.Error != nil {
+		return errGorm("failed to delete from database", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrGeofenceNotFound
+	}
+
+	return nil
+}
+
+func (r *geofenceGormRepository) CreateEvents(events []*entities.GeofenceEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	// Default Field
+	now := time.Now()
+	for _, event := range events {
+		event.ID = uuid.New()
+		event.CreatedAt = now
+	}
+
+	// Query
+	if err := r.db.Create(events).Error; err != nil {
+		return errGorm("failed to save to database", err)
+	}
+
+	return nil
+}
+
+func (r *geofenceGormRepository) FindEvents(query utils.GeofenceEventQuery, appsSource string, createdBy uuid.UUID) ([]*entities.GeofenceEvent, error) {
+	events := make([]*entities.GeofenceEvent, 0)
+
+	// Query
+	db := r.db.Where("apps_source = ? AND created_by = ?", appsSource, createdBy.String())
+	if query.GeofenceID != nil {
+		db = db.Where("geofence_id = ?", query.GeofenceID.String())
+	}
+	if query.From != nil {
+		db = db.Where("recorded_at >= ?", *query.From)
+	}
+	if query.To != nil {
+		db = db.Where("recorded_at <= ?", *query.To)
+	}
+	if err := db.Order("recorded_at DESC, id DESC").Limit(query.Limit).Find(&events).Error; err != nil {
+		return nil, errGorm("failed to read from database", err)
+	}
+
+	return events, nil
+}
+
+func (r *geofenceGormRepository) FindEventsByUser(appsSource string, createdBy uuid.UUID) ([]*entities.GeofenceEvent, error) {
+	events := make([]*entities.GeofenceEvent, 0)
+
+	// Query
+	if err := r.db.Where("apps_source = ? AND created_by = ?", appsSource, createdBy.String()).
+		Order("recorded_at DESC, id DESC").
+		Find(&events).Error; err != nil {
+		return nil, errGorm("failed to read from database", err)
+	}
+
+	return events, nil
+}
+
+func (r *geofenceGormRepository) DeleteByUser(appsSource string, createdBy uuid.UUID) error {
+	// Query : Fences & Events together
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("apps_source = ? AND created_by = ?", appsSource, createdBy.String()).
+			Delete(&entities.GeofenceEvent{}).Error; err != nil {
+			return errGorm("failed to delete from database", err)
+		}
+		if err := tx.Where("apps_source = ? AND created_by = ?", appsSource, createdBy.String()).
+			Delete(&entities.Geofence{}).Error; err != nil {
+			return errGorm("failed to delete from database", err)
+		}
+
+		return nil
+	})
+}
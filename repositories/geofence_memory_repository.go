@@ -0,0 +1,172 @@
+package repositories
+
+import (
+	"pinmarker/entities"
+	"pinmarker/utils"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Geofence Struct
+type geofenceMemoryRepository struct {
+	mu     sync.RWMutex
+	fences map[uuid.UUID]entities.Geofence
+	events []entities.GeofenceEvent
+}
+
+// Geofence Constructor
+func NewGeofenceMemoryRepository() GeofenceRepository {
+	return &geofenceMemoryRepository{
+		fences: make(map[uuid.UUID]entities.Geofence),
+	}
+}
+
+// owned reports whether the fence belongs to the app and user
+func (r *geofenceMemoryRepository) owned(fence entities.Geofence, appsSource string, createdBy uuid.UUID) bool {
+	return fence.AppsSource == appsSource && fence.CreatedBy == createdBy
+}
+
+func (r *geofenceMemoryRepository) Create(fence *entities.Geofence) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Default Field
+	fence.ID = uuid.New()
+	fence.CreatedAt = time.Now()
+	fence.UpdatedAt = fence.CreatedAt
+
+	// Query
+	r.fences[fence.ID] = *fence
+
+	return nil
+}
+
+func (r *geofenceMemoryRepository) FindAll(appsSource string, createdBy uuid.UUID) ([]*entities.Geofence, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fences := make([]*entities.Geofence, 0)
+	for _, fence := range r.fences {
+		if r.owned(fence, appsSource, createdBy) {
+			fence := fence
+			fences = append(fences, &fence)
+		}
+	}
+	geofenceSort(fences)
+
+	return fences, nil
+}
+
+func (r *geofenceMemoryRepository) FindByID(appsSource string, createdBy uuid.UUID, geofenceID uuid.UUID) (*entities.Geofence, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fence, ok := r.fences[geofenceID]
+	if !ok || !r.owned(fence, appsSource, createdBy) {
+		return nil, ErrGeofenceNotFound
+	}
+
+	return &fence, nil
+}
+
+func (r *geofenceMemoryRepository) Update(fence *entities.Geofence) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.fences[fence.ID]
+	if !ok || !r.owned(stored, fence.AppsSource, fence.CreatedBy) {
+		return ErrGeofenceNotFound
+	}
+
+	// Default Field
+	fence.CreatedAt = stored.CreatedAt
+	fence.UpdatedAt = time.Now()
+
+	// Query
+	r.fences[fence.ID] = *fence
+
+	return nil
+}
+
+func (r *geofenceMemoryRepository) DeleteByID(appsSource string, createdBy uuid.UUID, geofenceID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fence, ok := r.fences[geofenceID]
+	if !ok || !r.owned(fence, appsSource, createdBy) {
+		return ErrGeofenceNotFound
+	}
+	delete(r.fences, geofenceID)
+
+	return nil
+}
+
+func (r *geofenceMemoryRepository) CreateEvents(events []*entities.GeofenceEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, event := range events {
+		// Default Field
+		event.ID = uuid.New()
+		event.CreatedAt = now
+
+		// Query
+		r.events = append(r.events, *event)
+	}
+
+	return nil
+}
+
+func (r *geofenceMemoryRepository) FindEvents(query utils.GeofenceEventQuery, appsSource string, createdBy uuid.UUID) ([]*entities.GeofenceEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	candidates := make([]*entities.GeofenceEvent, 0)
+	for _, event := range r.events {
+		if event.AppsSource == appsSource && event.CreatedBy == createdBy {
+			event := event
+			candidates = append(candidates, &event)
+		}
+	}
+
+	return geofenceEventPage(candidates, query), nil
+}
+
+func (r *geofenceMemoryRepository) FindEventsByUser(appsSource string, createdBy uuid.UUID) ([]*entities.GeofenceEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := make([]*entities.GeofenceEvent, 0)
+	for _, event := range r.events {
+		if event.AppsSource == appsSource && event.CreatedBy == createdBy {
+			event := event
+			events = append(events, &event)
+		}
+	}
+
+	return geofenceEventPage(events, utils.GeofenceEventQuery{Limit: len(events)}), nil
+}
+
+func (r *geofenceMemoryRepository) DeleteByUser(appsSource string, createdBy uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Query : Fences & Events
+	for geofenceID, fence := range r.fences {
+		if r.owned(fence, appsSource, createdBy) {
+			delete(r.fences, geofenceID)
+		}
+	}
+	events := r.events[:0]
+	for _, event := range r.events {
+		if event.AppsSource != appsSource || event.CreatedBy != createdBy {
+			events = append(events, event)
+		}
+	}
+	r.events = events
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"pinmarker/utils"
+	"time"
+
+	"firebase.google.com/go/v4/db"
+	"github.com/google/uuid"
+)
+
+// Geofence Interface
+type GeofenceRepository interface {
+	Create(fence *entities.Geofence) error
+	FindAll(appsSource string, createdBy uuid.UUID) ([]*entities.Geofence, error)
+	FindByID(appsSource string, createdBy uuid.UUID, geofenceID uuid.UUID) (*entities.Geofence, error)
+	Update(fence *entities.Geofence) error
+	DeleteByID(appsSource string, createdBy uuid.UUID, geofenceID uuid.UUID) error
+	CreateEvents(events []*entities.GeofenceEvent) error
+	FindEvents(query utils.GeofenceEventQuery, appsSource string, createdBy uuid.UUID) ([]*entities.GeofenceEvent, error)
+	FindEventsByUser(appsSource string, createdBy uuid.UUID) ([]*entities.GeofenceEvent, error)
+	DeleteByUser(appsSource string, createdBy uuid.UUID) error
+}
+
+// Geofence Struct
+type geofenceRepository struct {
+	firebaseClient *db.Client
+	firebaseCtx    context.Context
+}
+
+// Geofence Constructor
+func NewGeofenceRepository() GeofenceRepository {
+	client, ctx, err := configs.FirebaseDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize Firebase DB: %v", err))
+	}
+
+	return &geofenceRepository{
+		firebaseClient: client,
+		firebaseCtx:    ctx,
+	}
+}
+
+// geofencePath is the fence's node under its app and user
+func geofencePath(appsSource string, createdBy uuid.UUID, geofenceID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/user_%s/%s", configs.GeofenceDoc, appsSource, createdBy.String(), geofenceID.String())
+}
+
+// geofenceEventPath is the event's node under its app and user
+func geofenceEventPath(appsSource string, createdBy uuid.UUID, eventID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/user_%s/%s", configs.GeofenceEventDoc, appsSource, createdBy.String(), eventID.String())
+}
+
+func (r *geofenceRepository) Create(fence *entities.Geofence) error {
+	// Default Field
+	fence.ID = uuid.New()
+	fence.CreatedAt = time.Now()
+	fence.UpdatedAt = fence.CreatedAt
+
+	// Query
+	ref := r.firebaseClient.NewRef(geofencePath(fence.AppsSource, fence.CreatedBy, fence.ID))
+	if err := ref.Set(r.firebaseCtx, fence); err != nil {
+		return errBackend("failed to save to Firebase", err)
+	}
+
+	return nil
+}
+
+func (r *geofenceRepository) FindAll(appsSource string, createdBy uuid.UUID) ([]*entities.Geofence, error) {
+	// Doc Name
+	ref := r.firebaseClient.NewRef(fmt.Sprintf("%s/%s/user_%s", configs.GeofenceDoc, appsSource, createdBy.String()))
+
+	// Query
+	var result map[string]*entities.Geofence
+	if err := ref.Get(r.firebaseCtx, &result); err != nil {
+		return nil, errBackend("failed to read from Firebase", err)
+	}
+	fences := make([]*entities.Geofence, 0, len(result))
+	for _, fence := range result {
+		fences = append(fences, fence)
+	}
+	geofenceSort(fences)
+
+	return fences, nil
+}
+
+func (r *geofenceRepository) FindByID(appsSource string, createdBy uuid.UUID, geofenceID uuid.UUID) (*entities.Geofence, error) {
+	// Query
+	var fence *entities.Geofence
+	ref := r.firebaseClient.NewRef(geofencePath(appsSource, createdBy, geofenceID))
+	if err := ref.Get(r.firebaseCtx, &fence); err != nil {
+		return nil, errBackend("failed to read from Firebase", err)
+	}
+	if fence == nil {
+		return nil, ErrGeofenceNotFound
+	}
+
+	return fence, nil
+}
+
+func (r *geofenceRepository) Update(fence *entities.Geofence) error {
+	// Default Field
+	fence.UpdatedAt = time.Now()
+
+	// Query : Only replace a fence that still exists
+	missing := false
+	ref := r.firebaseClient.NewRef(geofencePath(fence.AppsSource, fence.CreatedBy, fence.ID))
+	err := ref.Transaction(r.firebaseCtx, func(node db.TransactionNode) (interface{}, error) {
+		var existing map[string]interface{}
+		if err := node.Unmarshal(&existing); err != nil {
+			return nil, err
+		}
+		missing = existing == nil
+		if missing {
+			return nil, nil
+		}
+		return fence, nil
+	})
+	if err != nil {
+		return errBackend("failed to save to Firebase", err)
+	}
+	if missing {
+		return ErrGeofenceNotFound
+	}
+
+	return nil
+}
+
+func (r *geofenceRepository) DeleteByID(appsSource string, createdBy uuid.UUID, geofenceID uuid.UUID) error {
+	// Check Existence
+	if _, err := r.FindByID(appsSource, createdBy, geofenceID); err != nil {
+		return err
+	}
+
+	// Query
+	if err := r.firebaseClient.NewRef(geofencePath(appsSource, createdBy, geofenceID)).Delete(r.firebaseCtx); err != nil {
+		return errBackend("failed to delete from Firebase", err)
+	}
+
+	return nil
+}
+
+func (r *geofenceRepository) CreateEvents(events []*entities.GeofenceEvent) error {
+	// Prepare multi-path data
+	updates := make(map[string]interface{})
+	for _, event := range events {
+		// Default Field
+		event.ID = uuid.New()
+		event.CreatedAt = time.Now()
+
+		updates[geofenceEventPath(event.AppsSource, event.CreatedBy, event.ID)] = event
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	// Query
+	if err := r.firebaseClient.NewRef("/").Update(r.firebaseCtx, updates); err != nil {
+		return errBackend("failed to save to Firebase", err)
+	}
+
+	return nil
+}
+
+func (r *geofenceRepository) FindEvents(query utils.GeofenceEventQuery, appsSource string, createdBy uuid.UUID) ([]*entities.GeofenceEvent, error) {
+	// Doc Name
+	ref := r.firebaseClient.NewRef(fmt.Sprintf("%s/%s/user_%s", configs.GeofenceEventDoc, appsSource, createdBy.String()))
+
+	// Range Query : recorded_at, written in the server's zone like created_at
+	ordered := ref.OrderByChild("recorded_at")
+	if query.From != nil {
+		ordered = ordered.StartAt(query.From.Local().Format(time.RFC3339Nano))
+	}
+	if query.To != nil {
+		ordered = ordered.EndAt(query.To.Local().Format(time.RFC3339Nano))
+	}
+	if query.GeofenceID == nil {
+		ordered = ordered.LimitToLast(query.Limit)
+	}
+	nodes, err := ordered.GetOrdered(r.firebaseCtx)
+	if err != nil {
+		return nil, errBackend("failed to read from Firebase", err)
+	}
+
+	// Converter : Node To Struct
+	events := make([]*entities.GeofenceEvent, 0, len(nodes))
+	for _, node := range nodes {
+		var event entities.GeofenceEvent
+		if err := node.Unmarshal(&event); err != nil {
+			continue
+		}
+		events = append(events, &event)
+	}
+
+	return geofenceEventPage(events, query), nil
+}
+
+func (r *geofenceRepository) FindEventsByUser(appsSource string, createdBy uuid.UUID) ([]*entities.GeofenceEvent, error) {
+	// Doc Name
+	ref := r.firebaseClient.NewRef(fmt.Sprintf("%s/%s/user_%s", configs.GeofenceEventDoc, appsSource, createdBy.String()))
+
+	// Query
+	var result map[string]*entities.GeofenceEvent
+	if err := ref.Get(r.firebaseCtx, &result); err != nil {
+		return nil, errBackend("failed to read from Firebase", err)
+	}
+	events := make([]*entities.GeofenceEvent, 0, len(result))
+	for _, event := range result {
+		events = append(events, event)
+	}
+
+	return geofenceEventPage(events, utils.GeofenceEventQuery{Limit: len(events)}), nil
+}
+
+func (r *geofenceRepository) DeleteByUser(appsSource string, createdBy uuid.UUID) error {
+	// Query : Fences & Events
+	updates := map[string]interface{}{
+		fmt.Sprintf("%s/%s/user_%s", configs.GeofenceDoc, appsSource, createdBy.String()):      nil,
+		fmt.Sprintf("%s/%s/user_%s", configs.GeofenceEventDoc, appsSource, createdBy.String()): nil,
+	}
+	if err := r.firebaseClient.NewRef("/").Update(r.firebaseCtx, updates); err != nil {
+		return errBackend("failed to delete from Firebase", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Repository Error
+var (
+	ErrTrackNotFound      = errors.New("Track not found")
+	ErrGeofenceNotFound   = errors.New("Geofence not found")
+	ErrShareNotFound      = errors.New("Share not found")
+	ErrApiKeyNotFound     = errors.New("API key not found")
+	ErrQuotaExceeded      = errors.New("daily point quota exceeded")
+	ErrTrackConflict      = errors.New("track id was already used for a different track")
+	ErrTrackInvalid       = errors.New("track is not valid")
+	ErrBackendUnavailable = errors.New("storage backend is unavailable")
+)
+
+// RepositoryError keeps the backend message while matching one of the repository errors
+type RepositoryError struct {
+	Kind    error
+	Message string
+	Err     error
+}
+
+func (e *RepositoryError) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %v", e.Message, e.Err)
+}
+
+func (e *RepositoryError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// errBackend reports a failed call to Firebase or the database
+func errBackend(message string, err error) error {
+	return &RepositoryError{Kind: ErrBackendUnavailable, Message: message, Err: err}
+}
+
+// errInvalid reports a track the backend cannot store or read back
+func errInvalid(message string, err error) error {
+	return &RepositoryError{Kind: ErrTrackInvalid, Message: message, Err: err}
+}
+
+// errGorm classifies a database error, gorm must run with TranslateError for the
+// driver specific errors to be recognized
+func errGorm(message string, err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return &RepositoryError{Kind: ErrTrackNotFound, Message: message, Err: err}
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return &RepositoryError{Kind: ErrTrackConflict, Message: message, Err: err}
+	case errors.Is(err, gorm.ErrInvalidData), errors.Is(err, gorm.ErrInvalidValue),
+		errors.Is(err, gorm.ErrCheckConstraintViolated), errors.Is(err, gorm.ErrForeignKeyViolated):
+		return errInvalid(message, err)
+	default:
+		return errBackend(message, err)
+	}
+}
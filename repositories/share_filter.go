@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"pinmarker/entities"
+	"sort"
+)
+
+// shareSort orders shares newest first
+func shareSort(shares []*entities.ShareLink) {
+	sort.Slice(shares, func(i, j int) bool {
+		if !shares[i].CreatedAt.Equal(shares[j].CreatedAt) {
+			return shares[i].CreatedAt.After(shares[j].CreatedAt)
+		}
+		return shares[i].ID.String() > shares[j].ID.String()
+	})
+}
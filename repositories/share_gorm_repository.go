@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Share Struct
+type shareGormRepository struct {
+	db *gorm.DB
+}
+
+// Share Constructor
+func NewShareGormRepository() ShareRepository {
+	db, err := configs.GormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize database: %v", err))
+	}
+
+	// Migrate Table
+	if err := db.AutoMigrate(&entities.ShareLink{}); err != nil {
+		panic(fmt.Sprintf("failed to migrate share table: %v", err))
+	}
+
+	return &shareGormRepository{
+		db: db,
+	}
+}
+
+func (r *shareGormRepository) Create(share *entities.ShareLink) error {
+	// Default Field
+	share.ID = uuid.New()
+	share.CreatedAt = time.Now()
+
+	// Query
+	if err := r.db.Create(share).Error; err != nil {
+		return errGorm("failed to save to database", err)
+	}
+
+	return nil
+}
+
+func (r *shareGormRepository) FindAll(appsSource string, createdBy uuid.UUID) ([]*entities.ShareLink, error) {
+	shares := make([]*entities.ShareLink, 0)
+
+	// Query
+	if err := r.db.
+		Where("apps_source = ? AND created_by = ?", appsSource, createdBy.String()).
+		Order("created_at DESC, id DESC").
+		Find(&shares).Error; err != nil {
+		return nil, errGorm("failed to read from database", err)
+	}
+
+	return shares, nil
+}
+
+func (r *shareGormRepository) FindByID(appsSource string, createdBy uuid.UUID, shareID uuid.UUID) (*entities.ShareLink, error) {
+	// Query
+	var share entities.ShareLink
+	err := r.db.
+		Where("id = ? AND apps_source = ? AND created_by = ?", shareID.String(), appsSource, createdBy.String()).
+		First(&share).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrShareNotFound
+	}
+	if err != nil {
+		return nil, errGorm("failed to read from database", err)
+	}
+
+	return &share, nil
+}
+
+func (r *shareGormRepository) Revoke(appsSource string, createdBy uuid.UUID, shareID uuid.UUID) error {
+	// Check Existence
+	if _, err := r.FindByID(appsSource, createdBy, shareID); err != nil {
+		return err
+	}
+
+	// Query : Keep the first revocation time
+	if err := r.db.Model(&entities.ShareLink{}).
+		Where("id = ? AND revoked_at IS NULL", shareID.String()).
+		Update("revoked_at", time.Now()).Error; err != nil {
+		return errGorm("failed to save to database", err)
+	}
+
+	return nil
+}
+
+func (r *shareGormRepository) DeleteByUser(appsSource string, createdBy uuid.UUID) error {
+	// Query
+	if err := r.db.
+		Where("apps_source = ? AND created_by = ?", appsSource, createdBy.String()).
+		Delete(&entities.ShareLink{}).Error; err != nil {
+		return errGorm("failed to delete from database", err)
+	}
+
+	return nil
+}
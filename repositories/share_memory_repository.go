@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"pinmarker/entities"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Share Struct
+type shareMemoryRepository struct {
+	mu     sync.RWMutex
+	shares map[uuid.UUID]entities.ShareLink
+}
+
+// Share Constructor
+func NewShareMemoryRepository() ShareRepository {
+	return &shareMemoryRepository{
+		shares: make(map[uuid.UUID]entities.ShareLink),
+	}
+}
+
+func (r *shareMemoryRepository) Create(share *entities.ShareLink) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Default Field
+	share.ID = uuid.New()
+	share.CreatedAt = time.Now()
+
+	// Query
+	r.shares[share.ID] = *share
+
+	return nil
+}
+
+func (r *shareMemoryRepository) FindAll(appsSource string, createdBy uuid.UUID) ([]*entities.ShareLink, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	shares := make([]*entities.ShareLink, 0)
+	for _, share := range r.shares {
+		if share.AppsSource == appsSource && share.CreatedBy == createdBy {
+			share := share
+			shares = append(shares, &share)
+		}
+	}
+	shareSort(shares)
+
+	return shares, nil
+}
+
+func (r *shareMemoryRepository) FindByID(appsSource string, createdBy uuid.UUID, shareID uuid.UUID) (*entities.ShareLink, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	share, ok := r.shares[shareID]
+	if !ok || share.AppsSource != appsSource || share.CreatedBy != createdBy {
+		return nil, ErrShareNotFound
+	}
+
+	return &share, nil
+}
+
+func (r *shareMemoryRepository) Revoke(appsSource string, createdBy uuid.UUID, shareID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	share, ok := r.shares[shareID]
+	if !ok || share.AppsSource != appsSource || share.CreatedBy != createdBy {
+		return ErrShareNotFound
+	}
+
+	// Query : Keep the first revocation time
+	if share.RevokedAt == nil {
+		now := time.Now()
+		share.RevokedAt = &now
+		r.shares[shareID] = share
+	}
+
+	return nil
+}
+
+func (r *shareMemoryRepository) DeleteByUser(appsSource string, createdBy uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for shareID, share := range r.shares {
+		if share.AppsSource == appsSource && share.CreatedBy == createdBy {
+			delete(r.shares, shareID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"time"
+
+	"firebase.google.com/go/v4/db"
+	"github.com/google/uuid"
+)
+
+// Share Interface
+type ShareRepository interface {
+	Create(share *entities.ShareLink) error
+	FindAll(appsSource string, createdBy uuid.UUID) ([]*entities.ShareLink, error)
+	FindByID(appsSource string, createdBy uuid.UUID, shareID uuid.UUID) (*entities.ShareLink, error)
+	Revoke(appsSource string, createdBy uuid.UUID, shareID uuid.UUID) error
+	DeleteByUser(appsSource string, createdBy uuid.UUID) error
+}
+
+// Share Struct
+type shareRepository struct {
+	firebaseClient *db.Client
+	firebaseCtx    context.Context
+}
+
+// Share Constructor
+func NewShareRepository() ShareRepository {
+	client, ctx, err := configs.FirebaseDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize Firebase DB: %v", err))
+	}
+
+	return &shareRepository{
+		firebaseClient: client,
+		firebaseCtx:    ctx,
+	}
+}
+
+// sharePath is the share's node under its app and user
+func sharePath(appsSource string, createdBy uuid.UUID, shareID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/user_%s/%s", configs.ShareDoc, appsSource, createdBy.String(), shareID.String())
+}
+
+func (r *shareRepository) Create(share *entities.ShareLink) error {
+	// Default Field
+	share.ID = uuid.New()
+	share.CreatedAt = time.Now()
+
+	// Query
+	if err := r.firebaseClient.NewRef(sharePath(share.AppsSource, share.CreatedBy, share.ID)).Set(r.firebaseCtx, share); err != nil {
+		return errBackend("failed to save to Firebase", err)
+	}
+
+	return nil
+}
+
+func (r *shareRepository) FindAll(appsSource string, createdBy uuid.UUID) ([]*entities.ShareLink, error) {
+	// Doc Name
+	ref := r.firebaseClient.NewRef(fmt.Sprintf("%s/%s/user_%s", configs.ShareDoc, appsSource, createdBy.String()))
+
+	// Query
+	var result map[string]*entities.ShareLink
+	if err := ref.Get(r.firebaseCtx, &result); err != nil {
+		return nil, errBackend("failed to read from Firebase", err)
+	}
+	shares := make([]*entities.ShareLink, 0, len(result))
+	for _, share := range result {
+		shares = append(shares, share)
+	}
+	shareSort(shares)
+
+	return shares, nil
+}
+
+func (r *shareRepository) FindByID(appsSource string, createdBy uuid.UUID, shareID uuid.UUID) (*entities.ShareLink, error) {
+	// Query
+	var share *entities.ShareLink
+	if err := r.firebaseClient.NewRef(sharePath(appsSource, createdBy, shareID)).Get(r.firebaseCtx, &share); err != nil {
+		return nil, errBackend("failed to read from Firebase", err)
+	}
+	if share == nil {
+		return nil, ErrShareNotFound
+	}
+
+	return share, nil
+}
+
+func (r *shareRepository) Revoke(appsSource string, createdBy uuid.UUID, shareID uuid.UUID) error {
+	// Check Existence
+	share, err := r.FindByID(appsSource, createdBy, shareID)
+	if err != nil {
+		return err
+	}
+	if share.RevokedAt != nil {
+		return nil
+	}
+
+	// Query
+	ref := r.firebaseClient.NewRef(sharePath(appsSource, createdBy, shareID))
+	if err := ref.Update(r.firebaseCtx, map[string]interface{}{"revoked_at": time.Now()}); err != nil {
+		return errBackend("failed to save to Firebase", err)
+	}
+
+	return nil
+}
+
+func (r *shareRepository) DeleteByUser(appsSource string, createdBy uuid.UUID) error {
+	// Query
+	ref := r.firebaseClient.NewRef(fmt.Sprintf("%s/%s/user_%s", configs.ShareDoc, appsSource, createdBy.String()))
+	if err := ref.Delete(r.firebaseCtx); err != nil {
+		return errBackend("failed to delete from Firebase", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"pinmarker/entities"
+	"pinmarker/utils"
+	"sort"
+)
+
+// trackCursorAscending reports whether the cursor walks towards newer tracks
+func trackCursorAscending(cursor utils.Cursor, pagination utils.Pagination) bool {
+	return (cursor.Direction == "next") == (pagination.Sort == "asc")
+}
+
+// trackCursorWalk is the order the cursor walks in, next follows the sort and prev goes against it
+func trackCursorWalk(cursor utils.Cursor, pagination utils.Pagination) string {
+	if trackCursorAscending(cursor, pagination) {
+		return "asc"
+	}
+	return "desc"
+}
+
+// trackCursorEligible returns the candidates strictly past the cursor that pass the
+// filter, nearest to the cursor first
+func trackCursorEligible(candidates []*entities.Track, cursor utils.Cursor, pagination utils.Pagination) ([]*entities.Track, error) {
+	pivot := &entities.Track{CreatedAt: cursor.CreatedAt}
+	if cursor.ID != "" {
+		if err := pivot.ID.UnmarshalText([]byte(cursor.ID)); err != nil {
+			return nil, utils.ErrCursorInvalid
+		}
+	}
+
+	walk := trackCursorWalk(cursor, pagination)
+
+	// Filter : Strictly Past The Cursor
+	tracks := make([]*entities.Track, 0, len(candidates))
+	for _, track := range candidates {
+		if !trackMatchFilter(track, pagination.Filter) {
+			continue
+		}
+		if cursor.ID == "" || trackBefore(pivot, track, walk) {
+			tracks = append(tracks, track)
+		}
+	}
+
+	// Sort : Nearest To The Cursor First
+	sort.Slice(tracks, func(i, j int) bool {
+		return trackBefore(tracks[i], tracks[j], walk)
+	})
+
+	return tracks, nil
+}
+
+// trackCursorPage cuts one cursor page out of the candidate tracks. Candidates must
+// contain every track adjacent to the cursor in the requested direction, up to at
+// least limit + 1 eligible ones, so the presence of a following page can be detected.
+func trackCursorPage(candidates []*entities.Track, pagination utils.Pagination) ([]*entities.Track, string, string, error) {
+	cursor, err := utils.CursorDecode(pagination.Cursor)
+	if err != nil {
+		return nil, "", "", err
+	}
+	tracks, err := trackCursorEligible(candidates, cursor, pagination)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	// Pagination
+	hasMore := len(tracks) > pagination.Limit
+	if hasMore {
+		tracks = tracks[:pagination.Limit]
+	}
+	if cursor.Direction == "prev" {
+		for i, j := 0, len(tracks)-1; i < j; i, j = i+1, j-1 {
+			tracks[i], tracks[j] = tracks[j], tracks[i]
+		}
+	}
+	if len(tracks) == 0 {
+		return tracks, "", "", nil
+	}
+
+	// Cursor : Next & Prev
+	var next, prev string
+	first, last := tracks[0], tracks[len(tracks)-1]
+	if (cursor.Direction == "next" && hasMore) || cursor.Direction == "prev" {
+		next = utils.CursorEncode(utils.Cursor{CreatedAt: last.CreatedAt, ID: last.ID.String(), Direction: "next"})
+	}
+	if (cursor.Direction == "prev" && hasMore) || (cursor.Direction == "next" && cursor.ID != "") {
+		prev = utils.CursorEncode(utils.Cursor{CreatedAt: first.CreatedAt, ID: first.ID.String(), Direction: "prev"})
+	}
+
+	return tracks, next, prev, nil
+}
+
+// trackCursorScanPage cuts the page out of a scan that stopped at its cap before finding
+// limit + 1 eligible tracks. The cursor in the walked direction points at the furthest track
+// read, so the client resumes the scan there instead of the page reading the whole history.
+func trackCursorScanPage(candidates []*entities.Track, pagination utils.Pagination) ([]*entities.Track, string, string, error) {
+	tracks, next, prev, err := trackCursorPage(candidates, pagination)
+	if err != nil || len(candidates) == 0 {
+		return tracks, next, prev, err
+	}
+	cursor, err := utils.CursorDecode(pagination.Cursor)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	// Cursor : Furthest Track Read
+	walk := trackCursorWalk(cursor, pagination)
+	furthest := candidates[0]
+	for _, track := range candidates[1:] {
+		if trackBefore(furthest, track, walk) {
+			furthest = track
+		}
+	}
+	resume := utils.CursorEncode(utils.Cursor{CreatedAt: furthest.CreatedAt, ID: furthest.ID.String(), Direction: cursor.Direction})
+	if cursor.Direction == "prev" {
+		prev = resume
+	} else {
+		next = resume
+	}
+
+	return tracks, next, prev, nil
+}
@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"pinmarker/entities"
+	"pinmarker/utils"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// trackBefore reports whether a comes before b in the (created_at, id) order of the given sort
+func trackBefore(a, b *entities.Track, sortDirection string) bool {
+	if sortDirection == "asc" {
+		a, b = b, a
+	}
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.ID.String() > b.ID.String()
+}
+
+// trackMatchFilter reports whether the track passes every filter that is set
+func trackMatchFilter(track *entities.Track, filter utils.TrackFilter) bool {
+	if filter.From != nil && track.CreatedAt.Before(*filter.From) {
+		return false
+	}
+	if filter.To != nil && track.CreatedAt.After(*filter.To) {
+		return false
+	}
+	if filter.TrackType != "" && track.TrackType != filter.TrackType {
+		return false
+	}
+	if filter.BatteryMin != nil && track.BatteryIndicator < *filter.BatteryMin {
+		return false
+	}
+	if filter.BatteryMax != nil && track.BatteryIndicator > *filter.BatteryMax {
+		return false
+	}
+	return true
+}
+
+// trackOffsetPage filters, sorts and cuts one page/limit page out of the tracks
+func trackOffsetPage(candidates []*entities.Track, pagination utils.Pagination) ([]*entities.Track, int) {
+	tracks := make([]*entities.Track, 0, len(candidates))
+	for _, track := range candidates {
+		if trackMatchFilter(track, pagination.Filter) {
+			tracks = append(tracks, track)
+		}
+	}
+
+	// Total before pagination
+	total := len(tracks)
+
+	// Sort
+	sort.Slice(tracks, func(i, j int) bool {
+		return trackBefore(tracks[i], tracks[j], pagination.Sort)
+	})
+
+	// Pagination
+	start := (pagination.Page - 1) * pagination.Limit
+	end := start + pagination.Limit
+	if start > total {
+		return []*entities.Track{}, total
+	}
+	if end > total {
+		end = total
+	}
+
+	return tracks[start:end], total
+}
+
+// trackLatestByUser keeps the newest of the candidate tracks of each app & user
+func trackLatestByUser(candidates []*entities.Track) []*entities.Track {
+	type owner struct {
+		appsSource string
+		createdBy  uuid.UUID
+	}
+	latest := make(map[owner]*entities.Track)
+	keys := make([]owner, 0)
+	for _, track := range candidates {
+		key := owner{track.AppsSource, track.CreatedBy}
+		current, ok := latest[key]
+		if !ok {
+			keys = append(keys, key)
+		}
+		if !ok || trackBefore(current, track, "asc") {
+			latest[key] = track
+		}
+	}
+
+	tracks := make([]*entities.Track, 0, len(keys))
+	for _, key := range keys {
+		tracks = append(tracks, latest[key])
+	}
+
+	return tracks
+}
+
+// trackTrashPage sorts the trash most recently deleted first and cuts one page/limit page out of it
+func trackTrashPage(trash []*entities.TrackTrash, pagination utils.Pagination) ([]*entities.TrackTrash, int) {
+	total := len(trash)
+
+	// Sort
+	sort.Slice(trash, func(i, j int) bool {
+		if !trash[i].DeletedAt.Equal(trash[j].DeletedAt) {
+			return trash[i].DeletedAt.After(trash[j].DeletedAt)
+		}
+		return trash[i].ID.String() > trash[j].ID.String()
+	})
+
+	// Pagination
+	start := (pagination.Page - 1) * pagination.Limit
+	end := start + pagination.Limit
+	if start > total {
+		return []*entities.TrackTrash{}, total
+	}
+	if end > total {
+		end = total
+	}
+
+	return trash[start:end], total
+}
@@ -0,0 +1,599 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"pinmarker/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Track Struct
+type trackGormRepository struct {
+	db *gorm.DB
+}
+
+// Track Constructor
+func NewTrackGormRepository() TrackRepository {
+	db, err := configs.GormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize database: %v", err))
+	}
+
+	// Migrate Table
+	if err := db.AutoMigrate(&entities.Track{}, &entities.TrackLatest{}); err != nil {
+		panic(fmt.Sprintf("failed to migrate track table: %v", err))
+	}
+
+	// Backfill : Tracks stored before recorded_at & received_at only know their created_at
+	for _, column := range []string{"recorded_at", "received_at"} {
+		if err := db.Model(&entities.Track{}).Where(column+" IS NULL").Update(column, gorm.Expr("created_at")).Error; err != nil {
+			panic(fmt.Sprintf("failed to backfill track %s: %v", column, err))
+		}
+	}
+
+	repo := &trackGormRepository{
+		db: db,
+	}
+
+	// Backfill : Latest records of the tracks stored before they were kept
+	var latestCount int64
+	if err := db.Model(&entities.TrackLatest{}).Count(&latestCount).Error; err != nil {
+		panic(fmt.Sprintf("failed to count latest tracks: %v", err))
+	}
+	if latestCount == 0 {
+		if _, err := repo.MigrateLatest(); err != nil {
+			panic(fmt.Sprintf("failed to backfill latest tracks: %v", err))
+		}
+	}
+
+	return repo
+}
+
+func (r *trackGormRepository) Create(track *entities.Track) error {
+	// Default Field
+	if track.ID == uuid.Nil {
+		track.ID = uuid.New()
+	}
+	trackStamp(track, time.Now())
+	trackGeohash(track)
+
+	// Query : A replayed client id keeps the original track, even a trashed one
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(track)
+	if result.Error != nil {
+		return errGorm("failed to save to database", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		// Replay : Only the app & user's own track, the id is a table wide key so another
+		// user holding it is a conflict
+		err := r.db.Unscoped().
+			Where("apps_source = ? AND created_by = ? AND id = ?", track.AppsSource, track.CreatedBy.String(), track.ID.String()).
+			First(track).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTrackConflict
+		}
+		if err != nil {
+			return errGorm("failed to read from database", err)
+		}
+		track.Replayed = true
+		return nil
+	}
+
+	return r.putLatest([]*entities.Track{track})
+}
+
+// FindStored reads the tracks, trashed ones included, stored under the client ids of each
+// app & user
+func (r *trackGormRepository) FindStored(tracks []*entities.Track) (map[uuid.UUID]entities.Track, error) {
+	type owner struct {
+		appsSource string
+		createdBy  uuid.UUID
+	}
+	groups := make(map[owner][]string)
+	for _, track := range tracks {
+		if track.ID != uuid.Nil {
+			key := owner{track.AppsSource, track.CreatedBy}
+			groups[key] = append(groups[key], track.ID.String())
+		}
+	}
+	existing := make(map[uuid.UUID]entities.Track)
+	if len(groups) == 0 {
+		return existing, nil
+	}
+
+	// Query : IDs Per App & User
+	owners := r.db
+	for key, ids := range groups {
+		owners = owners.Or("apps_source = ? AND created_by = ? AND id IN ?", key.appsSource, key.createdBy.String(), ids)
+	}
+	var stored []entities.Track
+	if err := r.db.Unscoped().Where(owners).Find(&stored).Error; err != nil {
+		return nil, errGorm("failed to read from database", err)
+	}
+	for _, track := range stored {
+		existing[track.ID] = track
+	}
+
+	return existing, nil
+}
+
+func (r *trackGormRepository) CreateBatch(tracks []*entities.Track) error {
+	if len(tracks) == 0 {
+		return nil
+	}
+
+	// Existing : Client IDs already stored for their app & user
+	existing, err := r.FindStored(tracks)
+	if err != nil {
+		return err
+	}
+
+	inserts := make([]*entities.Track, 0, len(tracks))
+	taken := make([]string, 0)
+	receivedAt := time.Now()
+	for _, track := range tracks {
+		// Replay : Return the original track
+		if stored, ok := existing[track.ID]; ok {
+			*track = stored
+			track.Replayed = true
+			continue
+		}
+
+		// Default Field
+		if track.ID == uuid.Nil {
+			track.ID = uuid.New()
+		} else {
+			taken = append(taken, track.ID.String())
+		}
+		trackStamp(track, receivedAt)
+		trackGeohash(track)
+		inserts = append(inserts, track)
+	}
+	if len(inserts) == 0 {
+		return nil
+	}
+
+	// Conflict : The id is a table wide key, a new client id held by another user can't be written
+	if len(taken) > 0 {
+		var count int64
+		if err := r.db.Unscoped().Model(&entities.Track{}).Where("id IN ?", taken).Count(&count).Error; err != nil {
+			return errGorm("failed to read from database", err)
+		}
+		if count > 0 {
+			return ErrTrackConflict
+		}
+	}
+
+	// Query
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(inserts, 100).Error; err != nil {
+		return errGorm("failed to batch insert to database", err)
+	}
+
+	return r.putLatest(inserts)
+}
+
+func (r *trackGormRepository) FindAll(pagination utils.Pagination, appsSource string, createdBy uuid.UUID) ([]*entities.Track, int, error) {
+	// Query : Count
+	var total int64
+	query := r.db.Model(&entities.Track{}).
+		Where("apps_source = ? AND created_by = ?", appsSource, createdBy.String()).
+		Scopes(trackFilterScope(pagination.Filter)).
+		Session(&gorm.Session{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errGorm("failed to count from database", err)
+	}
+
+	// Query : Page
+	tracks := make([]*entities.Track, 0)
+	offset := (pagination.Page - 1) * pagination.Limit
+	if err := query.Order(trackOrder(pagination.Sort == "asc")).
+		Offset(offset).
+		Limit(pagination.Limit).
+		Find(&tracks).Error; err != nil {
+		return nil, 0, errGorm("failed to read from database", err)
+	}
+
+	return tracks, int(total), nil
+}
+
+func (r *trackGormRepository) FindAllByCursor(pagination utils.Pagination, appsSource string, createdBy uuid.UUID) ([]*entities.Track, string, string, error) {
+	cursor, err := utils.CursorDecode(pagination.Cursor)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	// Query
+	ascending := trackCursorAscending(cursor, pagination)
+	query := r.db.Where("apps_source = ? AND created_by = ?", appsSource, createdBy.String()).
+		Scopes(trackFilterScope(pagination.Filter)).
+		Order(trackOrder(ascending))
+	pivot := cursor.CreatedAt.Local()
+	switch {
+	case cursor.ID == "":
+	case ascending:
+		query = query.Where("created_at > ? OR (created_at = ? AND id > ?)", pivot, pivot, cursor.ID)
+	default:
+		query = query.Where("created_at < ? OR (created_at = ? AND id < ?)", pivot, pivot, cursor.ID)
+	}
+
+	// Fetch one extra track to detect the following page
+	tracks := make([]*entities.Track, 0)
+	if err := query.Limit(pagination.Limit + 1).Find(&tracks).Error; err != nil {
+		return nil, "", "", errGorm("failed to read from database", err)
+	}
+
+	return trackCursorPage(tracks, pagination)
+}
+
+func (r *trackGormRepository) FindWithinBox(query utils.SpatialQuery) ([]*entities.Track, error) {
+	candidates, err := r.spatialCandidates(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return trackWithinBox(candidates, query), nil
+}
+
+func (r *trackGormRepository) FindWithinRadius(query utils.SpatialQuery) ([]*entities.Track, error) {
+	candidates, err := r.spatialCandidates(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return trackWithinRadius(candidates, query), nil
+}
+
+// spatialCandidates reads the tracks whose geohash falls in a cell covering the query's box
+func (r *trackGormRepository) spatialCandidates(query utils.SpatialQuery) ([]*entities.Track, error) {
+	// Query : Prefix Per Cell
+	cells := r.db
+	for _, prefix := range utils.GeohashCover(query.MinLat, query.MinLong, query.MaxLat, query.MaxLong) {
+		cells = cells.Or("geohash LIKE ?", prefix+"%")
+	}
+	db := r.db.Where("apps_source = ?", query.AppsSource).
+		Where(cells).
+		Scopes(trackFilterScope(query.Filter))
+	if query.CreatedBy != nil {
+		db = db.Where("created_by = ?", query.CreatedBy.String())
+	}
+
+	tracks := make([]*entities.Track, 0)
+	if err := db.Find(&tracks).Error; err != nil {
+		return nil, errGorm("failed to read from database", err)
+	}
+
+	return tracks, nil
+}
+
+func (r *trackGormRepository) MigrateCoordinates() (int64, error) {
+	var migratedCount int64
+
+	// Coordinate columns are converted by AutoMigrate, only the geohash index is backfilled
+	var tracks []*entities.Track
+	result := r.db.Where("geohash IS NULL OR geohash = ''").FindInBatches(&tracks, 500, func(tx *gorm.DB, batch int) error {
+		for _, track := range tracks {
+			trackGeohash(track)
+			if err := r.db.Model(track).Update("geohash", track.Geohash).Error; err != nil {
+				return err
+			}
+			migratedCount++
+		}
+		return nil
+	})
+	if result.Error != nil {
+		return migratedCount, errGorm("failed to migrate tracks", result.Error)
+	}
+
+	return migratedCount, nil
+}
+
+// trackFilterScope narrows a track query down to the filter. The bounds are moved to the
+// server's zone created_at is stored in, sqlite compares the timestamps as text.
+func trackFilterScope(filter utils.TrackFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.From != nil {
+			db = db.Where("created_at >= ?", filter.From.Local())
+		}
+		if filter.To != nil {
+			db = db.Where("created_at <= ?", filter.To.Local())
+		}
+		if filter.TrackType != "" {
+			db = db.Where("track_type = ?", filter.TrackType)
+		}
+		if filter.BatteryMin != nil {
+			db = db.Where("battery_indicator >= ?", *filter.BatteryMin)
+		}
+		if filter.BatteryMax != nil {
+			db = db.Where("battery_indicator <= ?", *filter.BatteryMax)
+		}
+		return db
+	}
+}
+
+func trackOrder(ascending bool) string {
+	if ascending {
+		return "created_at ASC, id ASC"
+	}
+	return "created_at DESC, id DESC"
+}
+
+func (r *trackGormRepository) DeleteByID(appsSource string, createdBy uuid.UUID, trackID uuid.UUID) error {
+	// Query : Soft delete, the track stays in the trash until it is purged
+	result := r.db.
+		Where("id = ? AND apps_source = ? AND created_by = ?", trackID.String(), appsSource, createdBy.String()).
+		Delete(&entities.Track{})
+	if result.Error != nil {
+		return errGorm("failed to delete from database", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrTrackNotFound
+	}
+
+	// Latest : Only when the record pointed at the deleted track
+	var latest entities.TrackLatest
+	err := r.db.Where("apps_source = ? AND created_by = ? AND track_id = ?", appsSource, createdBy.String(), trackID.String()).
+		Limit(1).Find(&latest).Error
+	if err != nil {
+		return errGorm("failed to read latest track from database", err)
+	}
+	if latest.TrackID == trackID {
+		return r.refreshLatest(appsSource, createdBy)
+	}
+
+	return nil
+}
+
+func (r *trackGormRepository) FindTrash(pagination utils.Pagination, appsSource string, createdBy uuid.UUID) ([]*entities.TrackTrash, int, error) {
+	// Query : Count
+	var total int64
+	query := r.db.Unscoped().Model(&entities.Track{}).
+		Where("apps_source = ? AND created_by = ? AND deleted_at IS NOT NULL", appsSource, createdBy.String()).
+		Session(&gorm.Session{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errGorm("failed to count from database", err)
+	}
+
+	// Query : Page
+	tracks := make([]*entities.Track, 0)
+	offset := (pagination.Page - 1) * pagination.Limit
+	if err := query.Order("deleted_at DESC, id DESC").
+		Offset(offset).
+		Limit(pagination.Limit).
+		Find(&tracks).Error; err != nil {
+		return nil, 0, errGorm("failed to read from database", err)
+	}
+	trash := make([]*entities.TrackTrash, 0, len(tracks))
+	for _, track := range tracks {
+		trash = append(trash, &entities.TrackTrash{Track: *track, DeletedAt: track.DeletedAt.Time})
+	}
+
+	return trash, int(total), nil
+}
+
+func (r *trackGormRepository) RecoverByID(appsSource string, createdBy uuid.UUID, trackID uuid.UUID) (*entities.Track, error) {
+	// Query
+	result := r.db.Unscoped().Model(&entities.Track{}).
+		Where("id = ? AND apps_source = ? AND created_by = ? AND deleted_at IS NOT NULL", trackID.String(), appsSource, createdBy.String()).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return nil, errGorm("failed to recover from database", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrTrackNotFound
+	}
+
+	var track entities.Track
+	if err := r.db.Where("id = ?", trackID.String()).First(&track).Error; err != nil {
+		return nil, errGorm("failed to read from database", err)
+	}
+
+	return &track, r.putLatest([]*entities.Track{&track})
+}
+
+func (r *trackGormRepository) PurgeTrash(before time.Time) (int64, error) {
+	// Query
+	result := r.db.Unscoped().Where("deleted_at < ?", before).Delete(&entities.Track{})
+	if result.Error != nil {
+		return 0, errGorm("failed to purge trash", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *trackGormRepository) FindAppsByUser(createdBy uuid.UUID) ([]string, error) {
+	appsSources := make([]string, 0)
+
+	// Query : Trashed tracks count as well
+	if err := r.db.Unscoped().Model(&entities.Track{}).
+		Where("created_by = ?", createdBy.String()).
+		Distinct().Order("apps_source").
+		Pluck("apps_source", &appsSources).Error; err != nil {
+		return nil, errGorm("failed to read apps from database", err)
+	}
+
+	return appsSources, nil
+}
+
+func (r *trackGormRepository) DeleteByUser(appsSource string, createdBy uuid.UUID) error {
+	// Query : Every track, the trashed ones too, with the latest record
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("apps_source = ? AND created_by = ?", appsSource, createdBy.String()).
+			Delete(&entities.Track{}).Error; err != nil {
+			return errGorm("failed to delete from database", err)
+		}
+		if err := tx.Where("apps_source = ? AND created_by = ?", appsSource, createdBy.String()).
+			Delete(&entities.TrackLatest{}).Error; err != nil {
+			return errGorm("failed to delete latest track from database", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *trackGormRepository) FindAppsUserTotal() ([]*entities.AppCount, error) {
+	appCounts := make([]*entities.AppCount, 0)
+
+	// Query
+	if err := r.db.Model(&entities.Track{}).
+		Select("apps_source AS app_name, COUNT(DISTINCT created_by) AS total").
+		Group("apps_source").
+		Scan(&appCounts).Error; err != nil {
+		return nil, errGorm("failed to read apps from database", err)
+	}
+
+	return appCounts, nil
+}
+
+func (r *trackGormRepository) FindLatest(appsSource string, createdBy []uuid.UUID) ([]*entities.Track, error) {
+	tracks := make([]*entities.Track, 0, len(createdBy))
+	if len(createdBy) == 0 {
+		return tracks, nil
+	}
+	users := make([]string, 0, len(createdBy))
+	for _, user := range createdBy {
+		users = append(users, user.String())
+	}
+
+	// Query : The tracks the latest records point at
+	if err := r.db.Select("tracks.*").
+		Joins("JOIN track_latests ON track_latests.track_id = tracks.id").
+		Where("track_latests.apps_source = ? AND track_latests.created_by IN ?", appsSource, users).
+		Find(&tracks).Error; err != nil {
+		return nil, errGorm("failed to read latest tracks from database", err)
+	}
+
+	return tracks, nil
+}
+
+func (r *trackGormRepository) FindLatestByUser(since time.Time) ([]*entities.Track, error) {
+	tracks := make([]*entities.Track, 0)
+
+	// Query : The tracks the latest records since the lower bound point at
+	if err := r.db.Select("tracks.*").
+		Joins("JOIN track_latests ON track_latests.track_id = tracks.id").
+		Where("track_latests.created_at >= ?", since).
+		Find(&tracks).Error; err != nil {
+		return nil, errGorm("failed to read latest tracks from database", err)
+	}
+
+	return tracks, nil
+}
+
+// putLatest points each user's latest record at their newest stored track, unless the
+// record already points at a newer one
+func (r *trackGormRepository) putLatest(tracks []*entities.Track) error {
+	for _, track := range trackLatestByUser(tracks) {
+		latest := entities.TrackLatest{
+			AppsSource: track.AppsSource,
+			CreatedBy:  track.CreatedBy,
+			TrackID:    track.ID,
+			CreatedAt:  track.CreatedAt,
+		}
+
+		// Query : Upsert, concurrent writes of the same user keep the newest
+		if err := r.db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "apps_source"}, {Name: "created_by"}},
+			DoUpdates: clause.AssignmentColumns([]string{"track_id", "created_at"}),
+			Where: clause.Where{Exprs: []clause.Expression{gorm.Expr(
+				"track_latests.created_at < excluded.created_at OR (track_latests.created_at = excluded.created_at AND track_latests.track_id < excluded.track_id)",
+			)}},
+		}).Create(&latest).Error; err != nil {
+			return errGorm("failed to save latest track to database", err)
+		}
+	}
+
+	return nil
+}
+
+// refreshLatest points the user's latest record at their newest remaining track
+func (r *trackGormRepository) refreshLatest(appsSource string, createdBy uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Query : Newest Remaining Track
+		var tracks []*entities.Track
+		if err := tx.Where("apps_source = ? AND created_by = ?", appsSource, createdBy.String()).
+			Order("created_at DESC, id DESC").Limit(1).Find(&tracks).Error; err != nil {
+			return errGorm("failed to read from database", err)
+		}
+
+		// Query : Replace or drop the record
+		if err := tx.Where("apps_source = ? AND created_by = ?", appsSource, createdBy.String()).
+			Delete(&entities.TrackLatest{}).Error; err != nil {
+			return errGorm("failed to delete latest track from database", err)
+		}
+		if len(tracks) == 0 {
+			return nil
+		}
+		latest := entities.TrackLatest{
+			AppsSource: appsSource,
+			CreatedBy:  createdBy,
+			TrackID:    tracks[0].ID,
+			CreatedAt:  tracks[0].CreatedAt,
+		}
+		if err := tx.Create(&latest).Error; err != nil {
+			return errGorm("failed to save latest track to database", err)
+		}
+
+		return nil
+	})
+}
+
+// refreshOrphanLatest refreshes the latest records whose track was deleted
+func (r *trackGormRepository) refreshOrphanLatest() error {
+	var orphans []entities.TrackLatest
+	if err := r.db.Where("track_id NOT IN (?)", r.db.Model(&entities.Track{}).Select("id")).
+		Find(&orphans).Error; err != nil {
+		return errGorm("failed to read latest tracks from database", err)
+	}
+	for _, orphan := range orphans {
+		if err := r.refreshLatest(orphan.AppsSource, orphan.CreatedBy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *trackGormRepository) DeleteAllTracksByDaysCreated(days int) (int64, error) {
+	// Cutoff Time
+	cutoff := time.Now().AddDate(0, 0, -days)
+
+	// Query : Trashed tracks past the retention go as well
+	result := r.db.Unscoped().Where("received_at < ? OR (received_at IS NULL AND created_at < ?)", cutoff, cutoff).Delete(&entities.Track{})
+	if result.Error != nil {
+		return 0, errGorm("failed to delete tracks", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		if err := r.refreshOrphanLatest(); err != nil {
+			return result.RowsAffected, err
+		}
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *trackGormRepository) MigrateLatest() (int64, error) {
+	var candidates []*entities.Track
+
+	// Query : Every user's tracks at their newest created_at
+	newest := r.db.Model(&entities.Track{}).
+		Select("apps_source, created_by, MAX(created_at) AS created_at").
+		Group("apps_source, created_by")
+	if err := r.db.Select("tracks.*").
+		Joins("JOIN (?) AS newest ON newest.apps_source = tracks.apps_source AND newest.created_by = tracks.created_by AND newest.created_at = tracks.created_at", newest).
+		Find(&candidates).Error; err != nil {
+		return 0, errGorm("failed to read from database", err)
+	}
+
+	latest := trackLatestByUser(candidates)
+	if err := r.putLatest(latest); err != nil {
+		return 0, err
+	}
+
+	return int64(len(latest)), nil
+}
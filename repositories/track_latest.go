@@ -0,0 +1,167 @@
+package repositories
+
+import (
+	"fmt"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"pinmarker/utils"
+	"strings"
+	"time"
+
+	"firebase.google.com/go/v4/db"
+	"github.com/google/uuid"
+)
+
+// trackLatestPath is the copy of the user's newest track
+func trackLatestPath(appsSource string, createdBy uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/user_%s", configs.TrackLatestDoc, appsSource, createdBy.String())
+}
+
+// putLatest copies each user's newest stored track over their latest record, unless the
+// record already holds a newer one
+func (r *trackRepository) putLatest(tracks []*entities.Track) error {
+	for _, track := range trackLatestByUser(tracks) {
+		// Converter : Struct To Map
+		data, err := utils.ConverterStructToMap(track)
+		if err != nil {
+			return errInvalid(fmt.Sprintf("failed to convert track %s", track.ID.String()), err)
+		}
+
+		// Query : Compare & swap, concurrent writes of the same user keep the newest
+		ref := r.firebaseClient.NewRef(trackLatestPath(track.AppsSource, track.CreatedBy))
+		err = ref.Transaction(r.firebaseCtx, func(node db.TransactionNode) (interface{}, error) {
+			var stored map[string]interface{}
+			if err := node.Unmarshal(&stored); err != nil {
+				return nil, err
+			}
+			var current entities.Track
+			if stored != nil && node.Unmarshal(&current) == nil && !trackBefore(&current, track, "asc") {
+				return stored, nil
+			}
+			return data, nil
+		})
+		if err != nil {
+			return errBackend("failed to save latest track to Firebase", err)
+		}
+	}
+
+	return nil
+}
+
+// refreshLatest points the user's latest record at their newest remaining track when the
+// record held one of the deleted tracks
+func (r *trackRepository) refreshLatest(appsSource string, createdBy uuid.UUID, deleted map[uuid.UUID]bool) error {
+	ref := r.firebaseClient.NewRef(trackLatestPath(appsSource, createdBy))
+
+	// Query : Current Record
+	var current *entities.Track
+	if err := ref.Get(r.firebaseCtx, &current); err != nil {
+		return errBackend("failed to read latest track from Firebase", err)
+	}
+	if current == nil || !deleted[current.ID] {
+		return nil
+	}
+
+	// Query : Newest Remaining Track
+	userRef := r.firebaseClient.NewRef(fmt.Sprintf("%s/%s/user_%s", configs.TrackDoc, appsSource, createdBy.String()))
+	nodes, err := trackRangeQuery(userRef, nil, nil).LimitToLast(1).GetOrdered(r.firebaseCtx)
+	if err != nil {
+		return errBackend("failed to read from Firebase", err)
+	}
+	var data map[string]interface{}
+	if remaining := trackFromNodes(nodes); len(remaining) > 0 {
+		data, err = utils.ConverterStructToMap(remaining[0])
+		if err != nil {
+			return errInvalid("failed to convert track", err)
+		}
+	}
+
+	// Query : Only replace the record when no newer track took its place meanwhile
+	err = ref.Transaction(r.firebaseCtx, func(node db.TransactionNode) (interface{}, error) {
+		var stored map[string]interface{}
+		if err := node.Unmarshal(&stored); err != nil {
+			return nil, err
+		}
+		var still entities.Track
+		if stored == nil || node.Unmarshal(&still) != nil || still.ID != current.ID {
+			return stored, nil
+		}
+		if data == nil {
+			return nil, nil
+		}
+		return data, nil
+	})
+	if err != nil {
+		return errBackend("failed to save latest track to Firebase", err)
+	}
+
+	return nil
+}
+
+func (r *trackRepository) FindLatest(appsSource string, createdBy []uuid.UUID) ([]*entities.Track, error) {
+	tracks := make([]*entities.Track, 0, len(createdBy))
+
+	for _, user := range createdBy {
+		// Query : One record per user
+		var track *entities.Track
+		if err := r.firebaseClient.NewRef(trackLatestPath(appsSource, user)).Get(r.firebaseCtx, &track); err != nil {
+			return nil, errBackend("failed to read latest track from Firebase", err)
+		}
+		if track != nil {
+			tracks = append(tracks, track)
+		}
+	}
+
+	return tracks, nil
+}
+
+func (r *trackRepository) FindLatestByUser(since time.Time) ([]*entities.Track, error) {
+	tracks := make([]*entities.Track, 0)
+
+	for _, appsSource := range configs.AppsSources {
+		// Query : Latest records of the app since the lower bound
+		ref := r.firebaseClient.NewRef(fmt.Sprintf("%s/%s", configs.TrackLatestDoc, appsSource))
+		nodes, err := trackRangeQuery(ref, &since, nil).GetOrdered(r.firebaseCtx)
+		if err != nil {
+			return nil, errBackend("failed to read latest tracks from Firebase", err)
+		}
+		tracks = append(tracks, trackFromNodes(nodes)...)
+	}
+
+	return tracks, nil
+}
+
+func (r *trackRepository) MigrateLatest() (int64, error) {
+	var migratedCount int64
+
+	for _, appsSource := range configs.AppsSources {
+		// Query : Shallow App Node
+		var users map[string]bool
+		if err := r.firebaseClient.NewRef(fmt.Sprintf("%s/%s", configs.TrackDoc, appsSource)).GetShallow(r.firebaseCtx, &users); err != nil {
+			return migratedCount, errBackend("failed to read users from Firebase", err)
+		}
+
+		for userKey := range users {
+			if !strings.HasPrefix(userKey, "user_") {
+				continue
+			}
+
+			// Query : The user's newest track
+			ref := r.firebaseClient.NewRef(fmt.Sprintf("%s/%s/%s", configs.TrackDoc, appsSource, userKey))
+			nodes, err := trackRangeQuery(ref, nil, nil).LimitToLast(1).GetOrdered(r.firebaseCtx)
+			if err != nil {
+				return migratedCount, errBackend("failed to read from Firebase", err)
+			}
+			latest := trackFromNodes(nodes)
+			if len(latest) == 0 {
+				continue
+			}
+			if err := r.putLatest(latest); err != nil {
+				return migratedCount, err
+			}
+			migratedCount++
+		}
+	}
+
+	return migratedCount, nil
+}
@@ -0,0 +1,462 @@
+package repositories
+
+import (
+	"pinmarker/entities"
+	"pinmarker/utils"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Track Struct
+type trackMemoryRepository struct {
+	mu     sync.RWMutex
+	tracks map[string]map[uuid.UUID]map[uuid.UUID]entities.Track
+	latest map[string]map[uuid.UUID]uuid.UUID
+	trash  map[string]map[uuid.UUID]map[uuid.UUID]entities.TrackTrash
+}
+
+// Track Constructor
+func NewTrackMemoryRepository() TrackRepository {
+	return &trackMemoryRepository{
+		tracks: make(map[string]map[uuid.UUID]map[uuid.UUID]entities.Track),
+		latest: make(map[string]map[uuid.UUID]uuid.UUID),
+		trash:  make(map[string]map[uuid.UUID]map[uuid.UUID]entities.TrackTrash),
+	}
+}
+
+func (r *trackMemoryRepository) Create(track *entities.Track) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Replay : Return the original track, even a trashed one
+	if stored, ok := r.find(track); ok {
+		*track = stored
+		track.Replayed = true
+		return nil
+	}
+
+	// Default Field
+	if track.ID == uuid.Nil {
+		track.ID = uuid.New()
+	}
+	trackStamp(track, time.Now())
+	trackGeohash(track)
+
+	// Query
+	r.put(*track)
+
+	return nil
+}
+
+func (r *trackMemoryRepository) FindStored(tracks []*entities.Track) (map[uuid.UUID]entities.Track, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored := make(map[uuid.UUID]entities.Track)
+	for _, track := range tracks {
+		if item, ok := r.find(track); ok {
+			stored[track.ID] = item
+		}
+	}
+
+	return stored, nil
+}
+
+func (r *trackMemoryRepository) CreateBatch(tracks []*entities.Track) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	receivedAt := time.Now()
+	for _, track := range tracks {
+		// Replay : Return the original track
+		if stored, ok := r.find(track); ok {
+			*track = stored
+			track.Replayed = true
+			continue
+		}
+
+		// Default Field
+		if track.ID == uuid.Nil {
+			track.ID = uuid.New()
+		}
+		trackStamp(track, receivedAt)
+		trackGeohash(track)
+
+		// Query
+		r.put(*track)
+	}
+
+	return nil
+}
+
+func (r *trackMemoryRepository) FindAll(pagination utils.Pagination, appsSource string, createdBy uuid.UUID) ([]*entities.Track, int, error) {
+	// Filter, Sort & Pagination
+	tracks, total := trackOffsetPage(r.userTracks(appsSource, createdBy), pagination)
+
+	return tracks, total, nil
+}
+
+func (r *trackMemoryRepository) FindAllByCursor(pagination utils.Pagination, appsSource string, createdBy uuid.UUID) ([]*entities.Track, string, string, error) {
+	return trackCursorPage(r.userTracks(appsSource, createdBy), pagination)
+}
+
+func (r *trackMemoryRepository) FindWithinBox(query utils.SpatialQuery) ([]*entities.Track, error) {
+	return trackWithinBox(r.spatialCandidates(query), query), nil
+}
+
+func (r *trackMemoryRepository) FindWithinRadius(query utils.SpatialQuery) ([]*entities.Track, error) {
+	return trackWithinRadius(r.spatialCandidates(query), query), nil
+}
+
+// spatialCandidates returns copies of every track of the app, or of the user when given
+func (r *trackMemoryRepository) spatialCandidates(query utils.SpatialQuery) []*entities.Track {
+	if query.CreatedBy != nil {
+		return r.userTracks(query.AppsSource, *query.CreatedBy)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tracks := make([]*entities.Track, 0)
+	for _, users := range r.tracks[query.AppsSource] {
+		for _, item := range users {
+			track := item
+			tracks = append(tracks, &track)
+		}
+	}
+
+	return tracks
+}
+
+func (r *trackMemoryRepository) DeleteByID(appsSource string, createdBy uuid.UUID, trackID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Check Existence
+	track, ok := r.tracks[appsSource][createdBy][trackID]
+	if !ok {
+		return ErrTrackNotFound
+	}
+
+	// Query : Move the track to the trash
+	users, ok := r.trash[appsSource]
+	if !ok {
+		users = make(map[uuid.UUID]map[uuid.UUID]entities.TrackTrash)
+		r.trash[appsSource] = users
+	}
+	trash, ok := users[createdBy]
+	if !ok {
+		trash = make(map[uuid.UUID]entities.TrackTrash)
+		users[createdBy] = trash
+	}
+	trash[trackID] = entities.TrackTrash{Track: track, DeletedAt: time.Now()}
+	r.remove(appsSource, createdBy, trackID)
+
+	return nil
+}
+
+func (r *trackMemoryRepository) FindTrash(pagination utils.Pagination, appsSource string, createdBy uuid.UUID) ([]*entities.TrackTrash, int, error) {
+	r.mu.RLock()
+	trash := make([]*entities.TrackTrash, 0, len(r.trash[appsSource][createdBy]))
+	for _, item := range r.trash[appsSource][createdBy] {
+		track := item
+		trash = append(trash, &track)
+	}
+	r.mu.RUnlock()
+
+	// Sort & Pagination
+	page, total := trackTrashPage(trash, pagination)
+
+	return page, total, nil
+}
+
+func (r *trackMemoryRepository) RecoverByID(appsSource string, createdBy uuid.UUID, trackID uuid.UUID) (*entities.Track, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Check Existence
+	trashed, ok := r.trash[appsSource][createdBy][trackID]
+	if !ok {
+		return nil, ErrTrackNotFound
+	}
+
+	// Query : Back out of the trash
+	track := trashed.Track
+	r.put(track)
+	r.removeTrash(appsSource, createdBy, trackID)
+
+	return &track, nil
+}
+
+func (r *trackMemoryRepository) PurgeTrash(before time.Time) (int64, error) {
+	var purgedCount int64
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// All Apps
+	for appName, users := range r.trash {
+		for userID, trash := range users {
+			for trackID, track := range trash {
+				if track.DeletedAt.Before(before) {
+					r.removeTrash(appName, userID, trackID)
+					purgedCount++
+				}
+			}
+		}
+	}
+
+	return purgedCount, nil
+}
+
+func (r *trackMemoryRepository) FindAppsByUser(createdBy uuid.UUID) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Trashed tracks count as well
+	appsSources := make([]string, 0)
+	for appName := range r.tracks {
+		if _, ok := r.tracks[appName][createdBy]; ok {
+			appsSources = append(appsSources, appName)
+		}
+	}
+	for appName := range r.trash {
+		if _, ok := r.tracks[appName][createdBy]; ok {
+			continue
+		}
+		if _, ok := r.trash[appName][createdBy]; ok {
+			appsSources = append(appsSources, appName)
+		}
+	}
+	sort.Strings(appsSources)
+
+	return appsSources, nil
+}
+
+func (r *trackMemoryRepository) DeleteByUser(appsSource string, createdBy uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Query : Every track, the trashed ones too, with the latest record
+	delete(r.tracks[appsSource], createdBy)
+	if len(r.tracks[appsSource]) == 0 {
+		delete(r.tracks, appsSource)
+	}
+	delete(r.latest[appsSource], createdBy)
+	delete(r.trash[appsSource], createdBy)
+	if len(r.trash[appsSource]) == 0 {
+		delete(r.trash, appsSource)
+	}
+
+	return nil
+}
+
+func (r *trackMemoryRepository) FindAppsUserTotal() ([]*entities.AppCount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Count each app's track
+	appCounts := make([]*entities.AppCount, 0)
+	for appName, users := range r.tracks {
+		appCounts = append(appCounts, &entities.AppCount{
+			AppName: appName,
+			Total:   len(users),
+		})
+	}
+
+	return appCounts, nil
+}
+
+func (r *trackMemoryRepository) FindLatest(appsSource string, createdBy []uuid.UUID) ([]*entities.Track, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tracks := make([]*entities.Track, 0, len(createdBy))
+	for _, user := range createdBy {
+		if trackID, ok := r.latest[appsSource][user]; ok {
+			track := r.tracks[appsSource][user][trackID]
+			tracks = append(tracks, &track)
+		}
+	}
+
+	return tracks, nil
+}
+
+func (r *trackMemoryRepository) FindLatestByUser(since time.Time) ([]*entities.Track, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tracks := make([]*entities.Track, 0)
+	for appsSource, users := range r.latest {
+		for user, trackID := range users {
+			track := r.tracks[appsSource][user][trackID]
+			if !track.CreatedAt.Before(since) {
+				tracks = append(tracks, &track)
+			}
+		}
+	}
+
+	return tracks, nil
+}
+
+func (r *trackMemoryRepository) DeleteAllTracksByDaysCreated(days int) (int64, error) {
+	var deletedCount int64
+
+	// Cutoff Time
+	cutoff := time.Now().AddDate(0, 0, -days)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// All Apps : Retention follows received_at
+	for appName, users := range r.tracks {
+		for userID, tracks := range users {
+			for trackID, track := range tracks {
+				if trackReceivedAt(track).Before(cutoff) {
+					r.remove(appName, userID, trackID)
+					deletedCount++
+				}
+			}
+		}
+	}
+
+	// Trash : Past the retention as well, the same as the live tracks
+	for appName, users := range r.trash {
+		for userID, tracks := range users {
+			for trackID, track := range tracks {
+				if trackReceivedAt(track.Track).Before(cutoff) {
+					r.removeTrash(appName, userID, trackID)
+					deletedCount++
+				}
+			}
+		}
+	}
+
+	return deletedCount, nil
+}
+
+func (r *trackMemoryRepository) MigrateCoordinates() (int64, error) {
+	var migratedCount int64
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Coordinates are always numbers in memory, only the geohash index is backfilled
+	for _, users := range r.tracks {
+		for _, tracks := range users {
+			for trackID, track := range tracks {
+				if track.Geohash == "" {
+					trackGeohash(&track)
+					tracks[trackID] = track
+					migratedCount++
+				}
+			}
+		}
+	}
+
+	return migratedCount, nil
+}
+
+// userTracks returns copies of every track of the user
+func (r *trackMemoryRepository) userTracks(appsSource string, createdBy uuid.UUID) []*entities.Track {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tracks := make([]*entities.Track, 0, len(r.tracks[appsSource][createdBy]))
+	for _, item := range r.tracks[appsSource][createdBy] {
+		track := item
+		tracks = append(tracks, &track)
+	}
+
+	return tracks
+}
+
+// find returns the stored track with the client supplied id, a trashed one included,
+// the caller must hold the lock
+func (r *trackMemoryRepository) find(track *entities.Track) (entities.Track, bool) {
+	if track.ID == uuid.Nil {
+		return entities.Track{}, false
+	}
+	if stored, ok := r.tracks[track.AppsSource][track.CreatedBy][track.ID]; ok {
+		return stored, true
+	}
+	trashed, ok := r.trash[track.AppsSource][track.CreatedBy][track.ID]
+	return trashed.Track, ok
+}
+
+// put stores a copy of the track, the caller must hold the write lock
+func (r *trackMemoryRepository) put(track entities.Track) {
+	users, ok := r.tracks[track.AppsSource]
+	if !ok {
+		users = make(map[uuid.UUID]map[uuid.UUID]entities.Track)
+		r.tracks[track.AppsSource] = users
+	}
+	tracks, ok := users[track.CreatedBy]
+	if !ok {
+		tracks = make(map[uuid.UUID]entities.Track)
+		users[track.CreatedBy] = tracks
+	}
+	tracks[track.ID] = track
+
+	// Latest : Keep pointing at the newest track
+	latest, ok := r.latest[track.AppsSource]
+	if !ok {
+		latest = make(map[uuid.UUID]uuid.UUID)
+		r.latest[track.AppsSource] = latest
+	}
+	if current, ok := tracks[latest[track.CreatedBy]]; !ok || trackBefore(&current, &track, "asc") {
+		latest[track.CreatedBy] = track.ID
+	}
+}
+
+// remove deletes a track and prunes empty nodes the same way Firebase does,
+// the caller must hold the write lock
+func (r *trackMemoryRepository) remove(appsSource string, createdBy uuid.UUID, trackID uuid.UUID) {
+	delete(r.tracks[appsSource][createdBy], trackID)
+	if r.latest[appsSource][createdBy] == trackID {
+		delete(r.latest[appsSource], createdBy)
+		var newest *entities.Track
+		for _, item := range r.tracks[appsSource][createdBy] {
+			item := item
+			if newest == nil || trackBefore(newest, &item, "asc") {
+				newest = &item
+			}
+		}
+		if newest != nil {
+			r.latest[appsSource][createdBy] = newest.ID
+		}
+	}
+	if len(r.tracks[appsSource][createdBy]) == 0 {
+		delete(r.tracks[appsSource], createdBy)
+	}
+	if len(r.tracks[appsSource]) == 0 {
+		delete(r.tracks, appsSource)
+	}
+}
+
+// removeTrash drops a trashed track and its emptied nodes, the caller must hold the write lock
+func (r *trackMemoryRepository) removeTrash(appsSource string, createdBy uuid.UUID, trackID uuid.UUID) {
+	delete(r.trash[appsSource][createdBy], trackID)
+	if len(r.trash[appsSource][createdBy]) == 0 {
+		delete(r.trash[appsSource], createdBy)
+	}
+	if len(r.trash[appsSource]) == 0 {
+		delete(r.trash, appsSource)
+	}
+}
+
+func (r *trackMemoryRepository) MigrateLatest() (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Latest records are kept on every write in memory, nothing to backfill
+	var total int64
+	for _, users := range r.latest {
+		total += int64(len(users))
+	}
+
+	return total, nil
+}
@@ -3,10 +3,10 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"log"
 	"pinmarker/configs"
 	"pinmarker/entities"
 	"pinmarker/utils"
-	"sort"
 	"strings"
 	"time"
 
@@ -18,10 +18,23 @@ import (
 type TrackRepository interface {
 	Create(track *entities.Track) error
 	CreateBatch(tracks []*entities.Track) error
+	FindStored(tracks []*entities.Track) (map[uuid.UUID]entities.Track, error)
 	FindAll(pagination utils.Pagination, appsSource string, createdBy uuid.UUID) ([]*entities.Track, int, error)
+	FindAllByCursor(pagination utils.Pagination, appsSource string, createdBy uuid.UUID) ([]*entities.Track, string, string, error)
+	FindWithinBox(query utils.SpatialQuery) ([]*entities.Track, error)
+	FindWithinRadius(query utils.SpatialQuery) ([]*entities.Track, error)
 	DeleteByID(appsSource string, createdBy uuid.UUID, trackID uuid.UUID) error
+	FindTrash(pagination utils.Pagination, appsSource string, createdBy uuid.UUID) ([]*entities.TrackTrash, int, error)
+	RecoverByID(appsSource string, createdBy uuid.UUID, trackID uuid.UUID) (*entities.Track, error)
+	PurgeTrash(before time.Time) (int64, error)
+	FindAppsByUser(createdBy uuid.UUID) ([]string, error)
+	DeleteByUser(appsSource string, createdBy uuid.UUID) error
 	FindAppsUserTotal() ([]*entities.AppCount, error)
+	FindLatest(appsSource string, createdBy []uuid.UUID) ([]*entities.Track, error)
+	FindLatestByUser(since time.Time) ([]*entities.Track, error)
 	DeleteAllTracksByDaysCreated(days int) (int64, error)
+	MigrateCoordinates() (int64, error)
+	MigrateLatest() (int64, error)
 }
 
 // Track Struct
@@ -45,119 +58,425 @@ func NewTrackRepository() TrackRepository {
 
 func (r *trackRepository) Create(track *entities.Track) error {
 	// Default Field
-	track.ID = uuid.New()
-	track.CreatedAt = time.Now()
+	clientID := track.ID != uuid.Nil
+	if !clientID {
+		track.ID = uuid.New()
+	}
+	trackStamp(track, time.Now())
+	trackGeohash(track)
 
 	// Converter : Struct To Map
 	data, err := utils.ConverterStructToMap(track)
 	if err != nil {
-		return err
+		return errInvalid("failed to convert track", err)
 	}
 
-	// Doc Name
-	docName := configs.TrackDoc + "/" + track.AppsSource + "/user_" + track.CreatedBy.String()
+	// Query : Client ID, only create when absent so a replay returns the original track
+	if clientID {
+		// Replay : A trashed track keeps its id until it is purged
+		var trashed map[string]interface{}
+		if err := r.firebaseClient.NewRef(trackTrashPath(track.AppsSource, track.CreatedBy, track.ID)).Get(r.firebaseCtx, &trashed); err != nil {
+			return errBackend("failed to read trash from Firebase", err)
+		}
+		if trashed != nil {
+			delete(trashed, "deleted_at")
+			if err := utils.ConverterMapToStruct(trashed, track); err != nil {
+				return errInvalid("failed to convert track", err)
+			}
+			track.Replayed = true
+			return nil
+		}
+
+		var existing map[string]interface{}
+		ref := r.firebaseClient.NewRef(trackPath(track.AppsSource, track.CreatedBy, track.ID))
+		err := ref.Transaction(r.firebaseCtx, func(node db.TransactionNode) (interface{}, error) {
+			existing = nil
+			if err := node.Unmarshal(&existing); err != nil {
+				return nil, err
+			}
+			if existing != nil {
+				return existing, nil
+			}
+			return data, nil
+		})
+		if err != nil {
+			return errBackend("failed to save to Firebase", err)
+		}
+		if existing != nil {
+			if err := utils.ConverterMapToStruct(existing, track); err != nil {
+				return errInvalid("failed to convert track", err)
+			}
+			track.Replayed = true
+			return nil
+		}
+
+		if err := r.firebaseClient.NewRef(trackGeoPath(track.AppsSource, track.ID)).Set(r.firebaseCtx, data); err != nil {
+			return errBackend("failed to save to Firebase", err)
+		}
+		return r.putLatest([]*entities.Track{track})
+	}
+
+	// Doc Name : Track & Geo Index
+	updates := map[string]interface{}{
+		trackPath(track.AppsSource, track.CreatedBy, track.ID): data,
+		trackGeoPath(track.AppsSource, track.ID):               data,
+	}
 
 	// Query
-	ref := r.firebaseClient.NewRef(docName).Child(track.ID.String())
-	if err := ref.Set(r.firebaseCtx, data); err != nil {
-		return fmt.Errorf("failed to save to Firebase: %w", err)
+	ref := r.firebaseClient.NewRef("/")
+	if err := ref.Update(r.firebaseCtx, updates); err != nil {
+		return errBackend("failed to save to Firebase", err)
 	}
 
-	return nil
+	return r.putLatest([]*entities.Track{track})
 }
 
 func (r *trackRepository) CreateBatch(tracks []*entities.Track) error {
+	// Existing : Client IDs already stored
+	existing, err := r.existingTrackIDs(tracks)
+	if err != nil {
+		return err
+	}
+
 	// Prepare multi-path data
 	updates := make(map[string]interface{})
+	created := make([]*entities.Track, 0, len(tracks))
+	receivedAt := time.Now()
 
 	for _, track := range tracks {
 		// Default Field
-		track.ID = uuid.New()
+		if track.ID == uuid.Nil {
+			track.ID = uuid.New()
+		}
+		trackStamp(track, receivedAt)
+		trackGeohash(track)
+
+		// Replay : Return the original track instead of overwriting it, even a trashed one
+		path := trackPath(track.AppsSource, track.CreatedBy, track.ID)
+		if storedPath, ok := existing[path]; ok {
+			if err := r.storedTrack(storedPath, track); err != nil {
+				return err
+			}
+			track.Replayed = true
+			continue
+		}
 
 		// Converter : Struct To Map
 		data, err := utils.ConverterStructToMap(track)
 		if err != nil {
-			continue
+			return errInvalid(fmt.Sprintf("failed to convert track %s", track.ID.String()), err)
 		}
 
-		// Doc Name
-		path := fmt.Sprintf("%s/%s/user_%s/%s", configs.TrackDoc, track.AppsSource, track.CreatedBy.String(), track.ID.String())
+		// Doc Name : Track & Geo Index
 		updates[path] = data
+		updates[trackGeoPath(track.AppsSource, track.ID)] = data
+		created = append(created, track)
+	}
+
+	if len(updates) == 0 {
+		return nil
 	}
 
 	// Query
 	ref := r.firebaseClient.NewRef("/")
 	if err := ref.Update(r.firebaseCtx, updates); err != nil {
-		return fmt.Errorf("failed to batch insert to Firebase: %w", err)
+		return errBackend("failed to batch insert to Firebase", err)
+	}
+
+	return r.putLatest(created)
+}
+
+// existingTrackIDs maps the paths of the client supplied track ids that are already stored
+// to the node holding them, the live track or the trash, reading the keys of each involved
+// user node once
+func (r *trackRepository) existingTrackIDs(tracks []*entities.Track) (map[string]string, error) {
+	existing := make(map[string]string)
+	users := make(map[string]map[string]bool)
+	shallowKeys := func(path string) (map[string]bool, error) {
+		keys, ok := users[path]
+		if !ok {
+			if err := r.firebaseClient.NewRef(path).GetShallow(r.firebaseCtx, &keys); err != nil {
+				return nil, errBackend("failed to read from Firebase", err)
+			}
+			users[path] = keys
+		}
+		return keys, nil
+	}
+
+	for _, track := range tracks {
+		if track.ID == uuid.Nil {
+			continue
+		}
+
+		// Query : Shallow User Node, then its trash
+		keys, err := shallowKeys(fmt.Sprintf("%s/%s/user_%s", configs.TrackDoc, track.AppsSource, track.CreatedBy.String()))
+		if err != nil {
+			return nil, err
+		}
+		path := trackPath(track.AppsSource, track.CreatedBy, track.ID)
+		if keys[track.ID.String()] {
+			existing[path] = path
+			continue
+		}
+		trashKeys, err := shallowKeys(fmt.Sprintf("%s/%s/user_%s", configs.TrackTrashDoc, track.AppsSource, track.CreatedBy.String()))
+		if err != nil {
+			return nil, err
+		}
+		if trashKeys[track.ID.String()] {
+			existing[path] = trackTrashPath(track.AppsSource, track.CreatedBy, track.ID)
+		}
+	}
+
+	return existing, nil
+}
+
+// storedTrack reads the track stored at the path into track, without the trash mark
+func (r *trackRepository) storedTrack(storedPath string, track *entities.Track) error {
+	var stored map[string]interface{}
+	if err := r.firebaseClient.NewRef(storedPath).Get(r.firebaseCtx, &stored); err != nil {
+		return errBackend("failed to read from Firebase", err)
+	}
+	delete(stored, "deleted_at")
+	if err := utils.ConverterMapToStruct(stored, track); err != nil {
+		return errInvalid(fmt.Sprintf("failed to convert track %s", track.ID.String()), err)
 	}
 
 	return nil
 }
 
+func (r *trackRepository) FindStored(tracks []*entities.Track) (map[uuid.UUID]entities.Track, error) {
+	existing, err := r.existingTrackIDs(tracks)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := make(map[uuid.UUID]entities.Track)
+	for _, track := range tracks {
+		storedPath, ok := existing[trackPath(track.AppsSource, track.CreatedBy, track.ID)]
+		if !ok || track.ID == uuid.Nil {
+			continue
+		}
+		if _, ok := stored[track.ID]; ok {
+			continue
+		}
+		var item entities.Track
+		if err := r.storedTrack(storedPath, &item); err != nil {
+			return nil, err
+		}
+		stored[track.ID] = item
+	}
+
+	return stored, nil
+}
+
 func (r *trackRepository) FindAll(pagination utils.Pagination, appsSource string, createdBy uuid.UUID) ([]*entities.Track, int, error) {
 	// Doc Name
 	docName := fmt.Sprintf("%s/%s/user_%s", configs.TrackDoc, appsSource, createdBy.String())
 	ref := r.firebaseClient.NewRef(docName)
 
 	// Query
-	var result map[string]map[string]interface{}
-	if err := ref.Get(r.firebaseCtx, &result); err != nil {
-		return nil, 0, fmt.Errorf("failed to read from Firebase: %w", err)
+	tracks := make([]*entities.Track, 0)
+	filter := pagination.Filter
+	if filter.From != nil || filter.To != nil {
+		// Range Query : created_at
+		nodes, err := trackRangeQuery(ref, filter.From, filter.To).GetOrdered(r.firebaseCtx)
+		if err != nil {
+			return nil, 0, errBackend("failed to read from Firebase", err)
+		}
+		tracks = trackFromNodes(nodes)
+	} else {
+		var result map[string]map[string]interface{}
+		if err := ref.Get(r.firebaseCtx, &result); err != nil {
+			return nil, 0, errBackend("failed to read from Firebase", err)
+		}
+
+		// Converter : Map To Struct
+		for _, item := range result {
+			var track entities.Track
+			if err := utils.ConverterMapToStruct(item, &track); err != nil {
+				continue
+			}
+			tracks = append(tracks, &track)
+		}
+	}
+
+	// Filter, Sort & Pagination
+	page, total := trackOffsetPage(tracks, pagination)
+
+	return page, total, nil
+}
+
+func (r *trackRepository) FindAllByCursor(pagination utils.Pagination, appsSource string, createdBy uuid.UUID) ([]*entities.Track, string, string, error) {
+	cursor, err := utils.CursorDecode(pagination.Cursor)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	// Doc Name
+	docName := fmt.Sprintf("%s/%s/user_%s", configs.TrackDoc, appsSource, createdBy.String())
+	ref := r.firebaseClient.NewRef(docName)
+
+	// Range : Filter narrowed by the cursor
+	ascending := trackCursorAscending(cursor, pagination)
+	lower, upper := pagination.Filter.From, pagination.Filter.To
+	if cursor.ID != "" {
+		if ascending && (lower == nil || cursor.CreatedAt.After(*lower)) {
+			lower = &cursor.CreatedAt
+		}
+		if !ascending && (upper == nil || cursor.CreatedAt.Before(*upper)) {
+			upper = &cursor.CreatedAt
+		}
+	}
+
+	// Fetch one extra track to detect the following page, growing the window when
+	// ties on created_at or the attribute filters crowd it out, up to the scan cap
+	size := pagination.Limit + 2
+	if size > configs.TrackCursorScanMax {
+		size = configs.TrackCursorScanMax
+	}
+	for {
+		// Query
+		query := trackRangeQuery(ref, lower, upper)
+		if ascending {
+			query = query.LimitToFirst(size)
+		} else {
+			query = query.LimitToLast(size)
+		}
+		nodes, err := query.GetOrdered(r.firebaseCtx)
+		if err != nil {
+			return nil, "", "", errBackend("failed to read from Firebase", err)
+		}
+		tracks := trackFromNodes(nodes)
+
+		eligible, err := trackCursorEligible(tracks, cursor, pagination)
+		if err != nil {
+			return nil, "", "", err
+		}
+		if len(nodes) < size || len(eligible) > pagination.Limit {
+			return trackCursorPage(tracks, pagination)
+		}
+		if size >= configs.TrackCursorScanMax {
+			return trackCursorScanPage(tracks, pagination)
+		}
+		size *= 2
+		if size > configs.TrackCursorScanMax {
+			size = configs.TrackCursorScanMax
+		}
 	}
+}
+
+func (r *trackRepository) FindWithinBox(query utils.SpatialQuery) ([]*entities.Track, error) {
+	candidates, err := r.spatialCandidates(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return trackWithinBox(candidates, query), nil
+}
 
-	// Converter : Map To Struct
+func (r *trackRepository) FindWithinRadius(query utils.SpatialQuery) ([]*entities.Track, error) {
+	candidates, err := r.spatialCandidates(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return trackWithinRadius(candidates, query), nil
+}
+
+// spatialCandidates reads the tracks whose geohash falls in a cell covering the query's box,
+// from the user's node when the user is given, or from the app's geo index otherwise
+func (r *trackRepository) spatialCandidates(query utils.SpatialQuery) ([]*entities.Track, error) {
+	// Doc Name
+	docName := fmt.Sprintf("%s/%s", configs.TrackGeoDoc, query.AppsSource)
+	if query.CreatedBy != nil {
+		docName = fmt.Sprintf("%s/%s/user_%s", configs.TrackDoc, query.AppsSource, query.CreatedBy.String())
+	}
+	ref := r.firebaseClient.NewRef(docName)
+
+	// Query : Prefix Per Cell
+	seen := make(map[uuid.UUID]bool)
 	tracks := make([]*entities.Track, 0)
-	for _, item := range result {
-		var track entities.Track
-		if err := utils.ConverterMapToStruct(item, &track); err != nil {
-			continue
+	for _, prefix := range utils.GeohashCover(query.MinLat, query.MinLong, query.MaxLat, query.MaxLong) {
+		nodes, err := ref.OrderByChild("geohash").StartAt(prefix).EndAt(prefix + "\uf8ff").GetOrdered(r.firebaseCtx)
+		if err != nil {
+			return nil, errBackend("failed to read from Firebase", err)
+		}
+		for _, track := range trackFromNodes(nodes) {
+			if !seen[track.ID] {
+				seen[track.ID] = true
+				tracks = append(tracks, track)
+			}
 		}
-		tracks = append(tracks, &track)
 	}
 
-	// Total before pagination
-	total := len(tracks)
+	return tracks, nil
+}
 
-	// Sort Descending
-	sort.Slice(tracks, func(i, j int) bool {
-		return tracks[i].CreatedAt.After(tracks[j].CreatedAt)
-	})
+// trackPath is the track's node under its app and user
+func trackPath(appsSource string, createdBy uuid.UUID, trackID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/user_%s/%s", configs.TrackDoc, appsSource, createdBy.String(), trackID.String())
+}
 
-	// Pagination
-	start := (pagination.Page - 1) * pagination.Limit
-	end := start + pagination.Limit
-	if start > total {
-		return []*entities.Track{}, total, nil
+// trackGeoPath is the track's copy in the app wide geo index
+func trackGeoPath(appsSource string, trackID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/%s", configs.TrackGeoDoc, appsSource, trackID.String())
+}
+
+// trackRangeQuery orders a user's tracks by created_at within the optional bounds. Bounds are
+// formatted in the server's zone, which is the zone created_at is written in
+func trackRangeQuery(ref *db.Ref, lower, upper *time.Time) *db.Query {
+	query := ref.OrderByChild("created_at")
+	if lower != nil {
+		query = query.StartAt(lower.Local().Format(time.RFC3339Nano))
 	}
-	if end > total {
-		end = total
+	if upper != nil {
+		query = query.EndAt(upper.Local().Format(time.RFC3339Nano))
 	}
 
-	return tracks[start:end], total, nil
+	return query
+}
+
+// trackFromNodes converts ordered query nodes, skipping the ones that are not a track
+func trackFromNodes(nodes []db.QueryNode) []*entities.Track {
+	tracks := make([]*entities.Track, 0, len(nodes))
+	for _, node := range nodes {
+		var track entities.Track
+		if err := node.Unmarshal(&track); err != nil {
+			continue
+		}
+		tracks = append(tracks, &track)
+	}
+
+	return tracks
 }
 
 func (r *trackRepository) DeleteByID(appsSource string, createdBy uuid.UUID, trackID uuid.UUID) error {
 	// Doc Name
-	docName := fmt.Sprintf("%s/%s/user_%s/%s", configs.TrackDoc, appsSource, createdBy.String(), trackID.String())
-	ref := r.firebaseClient.NewRef(docName)
+	ref := r.firebaseClient.NewRef(trackPath(appsSource, createdBy, trackID))
 
 	// Check Existence
 	var existing map[string]interface{}
 	err := ref.Get(r.firebaseCtx, &existing)
 	if err != nil {
-		return fmt.Errorf("failed to read before delete: %w", err)
+		return errBackend("failed to read before delete", err)
 	}
 
 	if existing == nil {
-		return fmt.Errorf("Track not found")
+		return ErrTrackNotFound
 	}
 
-	// Query
-	if err := ref.Delete(r.firebaseCtx); err != nil {
-		return fmt.Errorf("failed to delete from Firebase: %w", err)
+	// Query : Move the track to the trash with its deleted_at marker, out of the geo index
+	existing["deleted_at"] = time.Now().Format(time.RFC3339Nano)
+	updates := map[string]interface{}{
+		trackTrashPath(appsSource, createdBy, trackID): existing,
+		trackPath(appsSource, createdBy, trackID):      nil,
+		trackGeoPath(appsSource, trackID):              nil,
+	}
+	if err := r.firebaseClient.NewRef("/").Update(r.firebaseCtx, updates); err != nil {
+		return errBackend("failed to delete from Firebase", err)
 	}
 
-	return nil
+	return r.refreshLatest(appsSource, createdBy, map[uuid.UUID]bool{trackID: true})
 }
 
 func (r *trackRepository) FindAppsUserTotal() ([]*entities.AppCount, error) {
@@ -167,7 +486,7 @@ func (r *trackRepository) FindAppsUserTotal() ([]*entities.AppCount, error) {
 	// Query
 	var raw map[string]map[string]interface{}
 	if err := ref.Get(r.firebaseCtx, &raw); err != nil {
-		return nil, fmt.Errorf("failed to read apps from Firebase: %w", err)
+		return nil, errBackend("failed to read apps from Firebase", err)
 	}
 	appCounts := make([]*entities.AppCount, 0)
 
@@ -192,7 +511,7 @@ func (r *trackRepository) DeleteAllTracksByDaysCreated(days int) (int64, error)
 	var allApps map[string]map[string]map[string]interface{}
 	err := rootRef.Get(r.firebaseCtx, &allApps)
 	if err != nil {
-		return 0, fmt.Errorf("failed to fetch all tracks: %w", err)
+		return 0, errBackend("failed to fetch all tracks", err)
 	}
 
 	// Cutoff Time
@@ -204,6 +523,11 @@ func (r *trackRepository) DeleteAllTracksByDaysCreated(days int) (int64, error)
 			if !strings.HasPrefix(userKey, "user_") {
 				continue
 			}
+			createdBy, err := uuid.Parse(strings.TrimPrefix(userKey, "user_"))
+			if err != nil {
+				continue
+			}
+			deleted := make(map[uuid.UUID]bool)
 
 			for trackID, trackDataRaw := range tracks {
 				trackData, ok := trackDataRaw.(map[string]interface{})
@@ -211,30 +535,127 @@ func (r *trackRepository) DeleteAllTracksByDaysCreated(days int) (int64, error)
 					continue
 				}
 
-				createdAtStr, ok := trackData["created_at"].(string)
-				if !ok {
+				if receivedAt, ok := trackDataReceivedAt(trackData); ok && receivedAt.Before(cutoff) {
+					path := fmt.Sprintf("%s/%s/%s/%s", configs.TrackDoc, appName, userKey, trackID)
+					updates := map[string]interface{}{
+						path: nil,
+						fmt.Sprintf("%s/%s/%s", configs.TrackGeoDoc, appName, trackID): nil,
+					}
+
+					// Query : Track & Geo Index
+					if err := r.firebaseClient.NewRef("/").Update(r.firebaseCtx, updates); err != nil {
+						return deletedCount, errBackend(fmt.Sprintf("failed to delete track %s", path), err)
+					}
+
+					deletedCount++
+					if id, err := uuid.Parse(trackID); err == nil {
+						deleted[id] = true
+					}
+				}
+			}
+
+			// Latest : Point at the newest remaining track
+			if len(deleted) > 0 {
+				if err := r.refreshLatest(appName, createdBy, deleted); err != nil {
+					return deletedCount, err
+				}
+			}
+		}
+	}
+
+	// Trash : Past the retention as well, the same as the live tracks
+	purgedCount, err := r.purgeTrashWhere(func(trackData map[string]interface{}) bool {
+		receivedAt, ok := trackDataReceivedAt(trackData)
+		return ok && receivedAt.Before(cutoff)
+	})
+
+	return deletedCount + purgedCount, err
+}
+
+// trackDataReceivedAt reads when the server stored a track node, retention follows it and
+// tracks stored before received_at existed only know their created_at
+func trackDataReceivedAt(trackData map[string]interface{}) (time.Time, bool) {
+	receivedAtStr, ok := trackData["received_at"].(string)
+	if !ok {
+		receivedAtStr, ok = trackData["created_at"].(string)
+	}
+	if !ok {
+		return time.Time{}, false
+	}
+	receivedAt, err := time.Parse(time.RFC3339Nano, receivedAtStr)
+
+	return receivedAt, err == nil
+}
+
+func (r *trackRepository) MigrateCoordinates() (int64, error) {
+	var migratedCount int64
+
+	// Doc Name
+	rootRef := r.firebaseClient.NewRef(configs.TrackDoc)
+
+	// Fetch All Tracks Data
+	var allApps map[string]map[string]map[string]map[string]interface{}
+	if err := rootRef.Get(r.firebaseCtx, &allApps); err != nil {
+		return 0, errBackend("failed to fetch all tracks", err)
+	}
+
+	// Query : Flush every few hundred tracks
+	updates := make(map[string]interface{})
+	flush := func() error {
+		if len(updates) == 0 {
+			return nil
+		}
+		if err := r.firebaseClient.NewRef("/").Update(r.firebaseCtx, updates); err != nil {
+			return errBackend("failed to migrate tracks", err)
+		}
+		updates = make(map[string]interface{})
+		return nil
+	}
+
+	// All Apps
+	for appName, users := range allApps {
+		for userKey, tracks := range users {
+			if !strings.HasPrefix(userKey, "user_") {
+				continue
+			}
+
+			for trackID, trackData := range tracks {
+				_, latIsString := trackData["track_lat"].(string)
+				_, longIsString := trackData["track_long"].(string)
+				_, hasGeohash := trackData["geohash"].(string)
+				if !latIsString && !longIsString && hasGeohash {
 					continue
 				}
 
-				createdAt, err := time.Parse(time.RFC3339Nano, createdAtStr)
-				if err != nil {
+				// Converter : Legacy String Coordinate
+				var track entities.Track
+				if err := utils.ConverterMapToStruct(trackData, &track); err != nil {
+					log.Printf("Skip migrating track %s/%s/%s: %v", appName, userKey, trackID, err)
 					continue
 				}
+				trackGeohash(&track)
+				data, err := utils.ConverterStructToMap(&track)
+				if err != nil {
+					return migratedCount, errInvalid(fmt.Sprintf("failed to convert track %s", trackID), err)
+				}
 
-				if createdAt.Before(cutoff) {
-					path := fmt.Sprintf("%s/%s/%s/%s", configs.TrackDoc, appName, userKey, trackID)
-					ref := r.firebaseClient.NewRef(path)
+				// Doc Name : Track & Geo Index
+				updates[fmt.Sprintf("%s/%s/%s/%s", configs.TrackDoc, appName, userKey, trackID)] = data
+				updates[fmt.Sprintf("%s/%s/%s", configs.TrackGeoDoc, appName, trackID)] = data
+				migratedCount++
 
-					// Query
-					if err := ref.Delete(r.firebaseCtx); err != nil {
-						return deletedCount, fmt.Errorf("failed to delete track %s: %w", path, err)
+				if len(updates) >= 500 {
+					if err := flush(); err != nil {
+						return migratedCount, err
 					}
-
-					deletedCount++
 				}
 			}
 		}
 	}
 
-	return deletedCount, nil
+	if err := flush(); err != nil {
+		return migratedCount, err
+	}
+
+	return migratedCount, nil
 }
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"pinmarker/entities"
+	"pinmarker/utils"
+	"sort"
+)
+
+// trackGeohash fills the geohash index field from the track's coordinate
+func trackGeohash(track *entities.Track) {
+	track.Geohash = utils.GeohashEncode(float64(track.TrackLat), float64(track.TrackLong), utils.GeohashPrecision)
+}
+
+// trackMatchSpatial reports whether the track is inside the query's box and passes its filters
+func trackMatchSpatial(track *entities.Track, query utils.SpatialQuery) bool {
+	if track.AppsSource != query.AppsSource {
+		return false
+	}
+	if query.CreatedBy != nil && track.CreatedBy != *query.CreatedBy {
+		return false
+	}
+	if !trackMatchFilter(track, query.Filter) {
+		return false
+	}
+
+	lat, long := float64(track.TrackLat), float64(track.TrackLong)
+	if lat < query.MinLat || lat > query.MaxLat {
+		return false
+	}
+	for _, longs := range utils.GeoLongRanges(query.MinLong, query.MaxLong) {
+		if long >= longs[0] && long <= longs[1] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// trackWithinBox keeps the candidates inside the box, newest first, up to the limit
+func trackWithinBox(candidates []*entities.Track, query utils.SpatialQuery) []*entities.Track {
+	tracks := make([]*entities.Track, 0)
+	for _, track := range candidates {
+		if trackMatchSpatial(track, query) {
+			tracks = append(tracks, track)
+		}
+	}
+
+	// Sort Descending
+	sort.Slice(tracks, func(i, j int) bool {
+		return trackBefore(tracks[i], tracks[j], "desc")
+	})
+
+	if len(tracks) > query.Limit {
+		tracks = tracks[:query.Limit]
+	}
+
+	return tracks
+}
+
+// trackWithinRadius keeps the candidates inside the circle, nearest first, up to the limit
+func trackWithinRadius(candidates []*entities.Track, query utils.SpatialQuery) []*entities.Track {
+	tracks := make([]*entities.Track, 0)
+	distances := make(map[*entities.Track]float64)
+	for _, track := range candidates {
+		if !trackMatchSpatial(track, query) {
+			continue
+		}
+		distance := utils.GeoDistance(query.Lat, query.Long, float64(track.TrackLat), float64(track.TrackLong))
+		if distance <= query.Radius {
+			distances[track] = distance
+			tracks = append(tracks, track)
+		}
+	}
+
+	// Sort : Nearest First
+	sort.Slice(tracks, func(i, j int) bool {
+		return distances[tracks[i]] < distances[tracks[j]]
+	})
+
+	if len(tracks) > query.Limit {
+		tracks = tracks[:query.Limit]
+	}
+
+	return tracks
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"pinmarker/entities"
+	"time"
+)
+
+// trackStamp sets the server received time and defaults the device recorded time to it.
+// CreatedAt stays the legacy alias of RecordedAt, listing and cursors keep ordering on it,
+// so it is kept in the server's zone like every created_at written before.
+func trackStamp(track *entities.Track, receivedAt time.Time) {
+	track.ReceivedAt = receivedAt
+	if track.RecordedAt.IsZero() {
+		track.RecordedAt = track.CreatedAt
+	}
+	if track.RecordedAt.IsZero() {
+		track.RecordedAt = receivedAt
+	}
+	track.CreatedAt = track.RecordedAt.Local()
+}
+
+// trackReceivedAt returns when the server stored the track, tracks written before
+// received_at existed only know their created_at
+func trackReceivedAt(track entities.Track) time.Time {
+	if track.ReceivedAt.IsZero() {
+		return track.CreatedAt
+	}
+	return track.ReceivedAt
+}
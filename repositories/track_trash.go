@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"fmt"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"pinmarker/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// trackTrashPath is the deleted track's node, the live track and geo index no longer hold it
+func trackTrashPath(appsSource string, createdBy uuid.UUID, trackID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/user_%s/%s", configs.TrackTrashDoc, appsSource, createdBy.String(), trackID.String())
+}
+
+func (r *trackRepository) FindTrash(pagination utils.Pagination, appsSource string, createdBy uuid.UUID) ([]*entities.TrackTrash, int, error) {
+	// Doc Name
+	ref := r.firebaseClient.NewRef(fmt.Sprintf("%s/%s/user_%s", configs.TrackTrashDoc, appsSource, createdBy.String()))
+
+	// Query : The trash only holds the purge window, read it whole
+	var raw map[string]map[string]interface{}
+	if err := ref.Get(r.firebaseCtx, &raw); err != nil {
+		return nil, 0, errBackend("failed to read trash from Firebase", err)
+	}
+	trash := make([]*entities.TrackTrash, 0, len(raw))
+	for _, data := range raw {
+		var track entities.TrackTrash
+		if err := utils.ConverterMapToStruct(data, &track); err != nil {
+			continue
+		}
+		trash = append(trash, &track)
+	}
+
+	page, total := trackTrashPage(trash, pagination)
+
+	return page, total, nil
+}
+
+func (r *trackRepository) RecoverByID(appsSource string, createdBy uuid.UUID, trackID uuid.UUID) (*entities.Track, error) {
+	// Doc Name
+	ref := r.firebaseClient.NewRef(trackTrashPath(appsSource, createdBy, trackID))
+
+	// Check Existence
+	var existing map[string]interface{}
+	if err := ref.Get(r.firebaseCtx, &existing); err != nil {
+		return nil, errBackend("failed to read trash from Firebase", err)
+	}
+	if existing == nil {
+		return nil, ErrTrackNotFound
+	}
+	delete(existing, "deleted_at")
+	var track entities.Track
+	if err := utils.ConverterMapToStruct(existing, &track); err != nil {
+		return nil, errInvalid(fmt.Sprintf("failed to convert track %s", trackID.String()), err)
+	}
+
+	// Query : Back to the track & geo index, out of the trash
+	updates := map[string]interface{}{
+		trackPath(appsSource, createdBy, trackID):      existing,
+		trackGeoPath(appsSource, trackID):              existing,
+		trackTrashPath(appsSource, createdBy, trackID): nil,
+	}
+	if err := r.firebaseClient.NewRef("/").Update(r.firebaseCtx, updates); err != nil {
+		return nil, errBackend("failed to recover from Firebase", err)
+	}
+
+	return &track, r.putLatest([]*entities.Track{&track})
+}
+
+func (r *trackRepository) PurgeTrash(before time.Time) (int64, error) {
+	return r.purgeTrashWhere(func(trackData map[string]interface{}) bool {
+		deletedAtStr, _ := trackData["deleted_at"].(string)
+		deletedAt, err := time.Parse(time.RFC3339Nano, deletedAtStr)
+		return err == nil && deletedAt.Before(before)
+	})
+}
+
+// purgeTrashWhere drops every trashed track matching the condition
+func (r *trackRepository) purgeTrashWhere(match func(trackData map[string]interface{}) bool) (int64, error) {
+	var purgedCount int64
+
+	// Fetch All Trash
+	var allApps map[string]map[string]map[string]map[string]interface{}
+	if err := r.firebaseClient.NewRef(configs.TrackTrashDoc).Get(r.firebaseCtx, &allApps); err != nil {
+		return 0, errBackend("failed to fetch trash", err)
+	}
+
+	// Query : Flush every few hundred tracks
+	updates := make(map[string]interface{})
+	flush := func() error {
+		if len(updates) == 0 {
+			return nil
+		}
+		if err := r.firebaseClient.NewRef("/").Update(r.firebaseCtx, updates); err != nil {
+			return errBackend("failed to purge trash", err)
+		}
+		updates = make(map[string]interface{})
+		return nil
+	}
+
+	// All Apps
+	for appName, users := range allApps {
+		for userKey, tracks := range users {
+			if !strings.HasPrefix(userKey, "user_") {
+				continue
+			}
+
+			for trackID, trackData := range tracks {
+				if !match(trackData) {
+					continue
+				}
+
+				updates[fmt.Sprintf("%s/%s/%s/%s", configs.TrackTrashDoc, appName, userKey, trackID)] = nil
+				purgedCount++
+
+				if len(updates) >= 500 {
+					if err := flush(); err != nil {
+						return purgedCount - int64(len(updates)), err
+					}
+				}
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return purgedCount - int64(len(updates)), err
+	}
+
+	return purgedCount, nil
+}
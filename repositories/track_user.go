@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"fmt"
+	"pinmarker/configs"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// trackUserPath is the user's node of a track document
+func trackUserPath(doc string, appsSource string, createdBy uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/user_%s", doc, appsSource, createdBy.String())
+}
+
+func (r *trackRepository) FindAppsByUser(createdBy uuid.UUID) ([]string, error) {
+	found := make(map[string]bool)
+
+	// Query : Shallow reads, only the keys of every app & the user's node
+	for _, doc := range []string{configs.TrackDoc, configs.TrackTrashDoc} {
+		var apps map[string]interface{}
+		if err := r.firebaseClient.NewRef(doc).GetShallow(r.firebaseCtx, &apps); err != nil {
+			return nil, errBackend("failed to read apps from Firebase", err)
+		}
+		for appName := range apps {
+			if found[appName] {
+				continue
+			}
+			var user interface{}
+			if err := r.firebaseClient.NewRef(trackUserPath(doc, appName, createdBy)).GetShallow(r.firebaseCtx, &user); err != nil {
+				return nil, errBackend("failed to read apps from Firebase", err)
+			}
+			found[appName] = user != nil
+		}
+	}
+
+	appsSources := make([]string, 0, len(found))
+	for appName, ok := range found {
+		if ok {
+			appsSources = append(appsSources, appName)
+		}
+	}
+	sort.Strings(appsSources)
+
+	return appsSources, nil
+}
+
+func (r *trackRepository) DeleteByUser(appsSource string, createdBy uuid.UUID) error {
+	// Track IDs : Only the keys, for the geo index
+	var tracks map[string]interface{}
+	if err := r.firebaseClient.NewRef(trackUserPath(configs.TrackDoc, appsSource, createdBy)).GetShallow(r.firebaseCtx, &tracks); err != nil {
+		return errBackend("failed to read before delete", err)
+	}
+
+	// Query : Geo index first, flushed every few hundred tracks
+	updates := make(map[string]interface{})
+	for trackID := range tracks {
+		updates[fmt.Sprintf("%s/%s/%s", configs.TrackGeoDoc, appsSource, trackID)] = nil
+		if len(updates) >= 500 {
+			if err := r.firebaseClient.NewRef("/").Update(r.firebaseCtx, updates); err != nil {
+				return errBackend("failed to delete from Firebase", err)
+			}
+			updates = make(map[string]interface{})
+		}
+	}
+
+	// Query : The user's track, trash & latest nodes
+	updates[trackUserPath(configs.TrackDoc, appsSource, createdBy)] = nil
+	updates[trackUserPath(configs.TrackTrashDoc, appsSource, createdBy)] = nil
+	updates[trackLatestPath(appsSource, createdBy)] = nil
+	if err := r.firebaseClient.NewRef("/").Update(r.firebaseCtx, updates); err != nil {
+		return errBackend("failed to delete from Firebase", err)
+	}
+
+	return nil
+}
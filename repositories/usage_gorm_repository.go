@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"pinmarker/configs"
+	"pinmarker/entities"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Usage Struct
+type usageGormRepository struct {
+	db *gorm.DB
+}
+
+// Usage Constructor
+func NewUsageGormRepository() UsageRepository {
+	db, err := configs.GormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize database: %v", err))
+	}
+
+	// Migrate Table
+	if err := db.AutoMigrate(&entities.TrackUsage{}); err != nil {
+		panic(fmt.Sprintf("failed to migrate track usage table: %v", err))
+	}
+
+	return &usageGormRepository{
+		db: db,
+	}
+}
+
+func (r *usageGormRepository) Reserve(appsSource string, createdBy uuid.UUID, day string, points int, limit int) (*entities.TrackUsage, error) {
+	// Query : Make sure the user has a row
+	row := &entities.TrackUsage{AppsSource: appsSource, CreatedBy: createdBy, Day: day}
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(row).Error; err != nil {
+		return nil, errGorm("failed to save usage to database", err)
+	}
+
+	// Query : One statement adds the points unless they go over the limit, the previous day restarts at zero
+	current := gorm.Expr("CASE WHEN day = ? THEN points ELSE 0 END", day)
+	query := r.db.Model(&entities.TrackUsage{}).
+		Where("apps_source = ? AND created_by = ?", appsSource, createdBy.String())
+	if limit > 0 {
+		query = query.Where("? + ? <= ?", current, points, limit)
+	}
+	result := query.Updates(map[string]interface{}{
+		"points": gorm.Expr("? + ?", current, points),
+		"day":    day,
+	})
+	if result.Error != nil {
+		return nil, errGorm("failed to save usage to database", result.Error)
+	}
+
+	usage, err := r.Find(appsSource, createdBy, day)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected == 0 {
+		return usage, ErrQuotaExceeded
+	}
+
+	return usage, nil
+}
+
+func (r *usageGormRepository) Release(appsSource string, createdBy uuid.UUID, day string, points int) error {
+	// Query : Only the same day's points are given back
+	if err := r.db.Model(&entities.TrackUsage{}).
+		Where("apps_source = ? AND created_by = ? AND day = ?", appsSource, createdBy.String(), day).
+		Update("points", gorm.Expr("CASE WHEN points > ? THEN points - ? ELSE 0 END", points, points)).Error; err != nil {
+		return errGorm("failed to save usage to database", err)
+	}
+
+	return nil
+}
+
+func (r *usageGormRepository) Find(appsSource string, createdBy uuid.UUID, day string) (*entities.TrackUsage, error) {
+	var stored entities.TrackUsage
+
+	// Query
+	err := r.db.Where("apps_source = ? AND created_by = ?", appsSource, createdBy.String()).First(&stored).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return usageOfDay(nil, appsSource, createdBy, day), nil
+	}
+	if err != nil {
+		return nil, errGorm("failed to read from database", err)
+	}
+
+	return usageOfDay(&stored, appsSource, createdBy, day), nil
+}
+
+func (r *usageGormRepository) FindByUser(appsSource string, createdBy uuid.UUID) (*entities.TrackUsage, error) {
+	var stored entities.TrackUsage
+
+	// Query : Whatever day was stored last, nil when the user never created a point
+	err := r.db.Where("apps_source = ? AND created_by = ?", appsSource, createdBy.String()).First(&stored).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errGorm("failed to read from database", err)
+	}
+
+	return &stored, nil
+}
+
+func (r *usageGormRepository) DeleteByUser(appsSource string, createdBy uuid.UUID) error {
+	// Query
+	if err := r.db.
+		Where("apps_source = ? AND created_by = ?", appsSource, createdBy.String()).
+		Delete(&entities.TrackUsage{}).Error; err != nil {
+		return errGorm("failed to delete from database", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"pinmarker/entities"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// usageKey is the user a usage is kept under
+type usageKey struct {
+	appsSource string
+	createdBy  uuid.UUID
+}
+
+// Usage Struct
+type usageMemoryRepository struct {
+	mu     sync.Mutex
+	usages map[usageKey]entities.TrackUsage
+}
+
+// Usage Constructor
+func NewUsageMemoryRepository() UsageRepository {
+	return &usageMemoryRepository{
+		usages: make(map[usageKey]entities.TrackUsage),
+	}
+}
+
+func (r *usageMemoryRepository) Reserve(appsSource string, createdBy uuid.UUID, day string, points int, limit int) (*entities.TrackUsage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := usageKey{appsSource, createdBy}
+	stored := r.usages[key]
+	usage := *usageOfDay(&stored, appsSource, createdBy, day)
+	if limit > 0 && usage.Points+points > limit {
+		return &usage, ErrQuotaExceeded
+	}
+	usage.Points += points
+	r.usages[key] = usage
+
+	return &usage, nil
+}
+
+func (r *usageMemoryRepository) Release(appsSource string, createdBy uuid.UUID, day string, points int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := usageKey{appsSource, createdBy}
+	usage, ok := r.usages[key]
+	if !ok || usage.Day != day {
+		return nil
+	}
+	usage.Points -= points
+	if usage.Points < 0 {
+		usage.Points = 0
+	}
+	r.usages[key] = usage
+
+	return nil
+}
+
+func (r *usageMemoryRepository) Find(appsSource string, createdBy uuid.UUID, day string) (*entities.TrackUsage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := r.usages[usageKey{appsSource, createdBy}]
+
+	return usageOfDay(&stored, appsSource, createdBy, day), nil
+}
+
+func (r *usageMemoryRepository) FindByUser(appsSource string, createdBy uuid.UUID) (*entities.TrackUsage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.usages[usageKey{appsSource, createdBy}]
+	if !ok {
+		return nil, nil
+	}
+
+	return &stored, nil
+}
+
+func (r *usageMemoryRepository) DeleteByUser(appsSource string, createdBy uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.usages, usageKey{appsSource, createdBy})
+
+	return nil
+}
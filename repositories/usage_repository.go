@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"pinmarker/configs"
+	"pinmarker/entities"
+
+	"firebase.google.com/go/v4/db"
+	"github.com/google/uuid"
+)
+
+// Usage Interface
+type UsageRepository interface {
+	Reserve(appsSource string, createdBy uuid.UUID, day string, points int, limit int) (*entities.TrackUsage, error)
+	Release(appsSource string, createdBy uuid.UUID, day string, points int) error
+	Find(appsSource string, createdBy uuid.UUID, day string) (*entities.TrackUsage, error)
+	FindByUser(appsSource string, createdBy uuid.UUID) (*entities.TrackUsage, error)
+	DeleteByUser(appsSource string, createdBy uuid.UUID) error
+}
+
+// Usage Struct
+type usageRepository struct {
+	firebaseClient *db.Client
+	firebaseCtx    context.Context
+}
+
+// Usage Constructor
+func NewUsageRepository() UsageRepository {
+	client, ctx, err := configs.FirebaseDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize Firebase DB: %v", err))
+	}
+
+	return &usageRepository{
+		firebaseClient: client,
+		firebaseCtx:    ctx,
+	}
+}
+
+// usagePath is the user's usage record, it only holds the current day
+func usagePath(appsSource string, createdBy uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/user_%s", configs.TrackUsageDoc, appsSource, createdBy.String())
+}
+
+// usageOfDay is the stored usage, or an empty one when it was counted on another day
+func usageOfDay(stored *entities.TrackUsage, appsSource string, createdBy uuid.UUID, day string) *entities.TrackUsage {
+	if stored == nil || stored.Day != day {
+		return &entities.TrackUsage{AppsSource: appsSource, CreatedBy: createdBy, Day: day}
+	}
+
+	return stored
+}
+
+func (r *usageRepository) Reserve(appsSource string, createdBy uuid.UUID, day string, points int, limit int) (*entities.TrackUsage, error) {
+	// Query : Add the points unless they go over the limit, the previous day is dropped
+	var usage *entities.TrackUsage
+	exceeded := false
+	err := r.firebaseClient.NewRef(usagePath(appsSource, createdBy)).Transaction(r.firebaseCtx, func(node db.TransactionNode) (interface{}, error) {
+		var stored *entities.TrackUsage
+		if err := node.Unmarshal(&stored); err != nil {
+			return nil, err
+		}
+		usage = usageOfDay(stored, appsSource, createdBy, day)
+		exceeded = limit > 0 && usage.Points+points > limit
+		if exceeded {
+			return stored, nil
+		}
+		usage.Points += points
+		return usage, nil
+	})
+	if err != nil {
+		return nil, errBackend("failed to save usage to Firebase", err)
+	}
+	if exceeded {
+		return usage, ErrQuotaExceeded
+	}
+
+	return usage, nil
+}
+
+func (r *usageRepository) Release(appsSource string, createdBy uuid.UUID, day string, points int) error {
+	// Query : Only the same day's points are given back
+	err := r.firebaseClient.NewRef(usagePath(appsSource, createdBy)).Transaction(r.firebaseCtx, func(node db.TransactionNode) (interface{}, error) {
+		var stored *entities.TrackUsage
+		if err := node.Unmarshal(&stored); err != nil {
+			return nil, err
+		}
+		if stored == nil || stored.Day != day {
+			return stored, nil
+		}
+		stored.Points -= points
+		if stored.Points < 0 {
+			stored.Points = 0
+		}
+		return stored, nil
+	})
+	if err != nil {
+		return errBackend("failed to save usage to Firebase", err)
+	}
+
+	return nil
+}
+
+func (r *usageRepository) Find(appsSource string, createdBy uuid.UUID, day string) (*entities.TrackUsage, error) {
+	// Query
+	var stored *entities.TrackUsage
+	if err := r.firebaseClient.NewRef(usagePath(appsSource, createdBy)).Get(r.firebaseCtx, &stored); err != nil {
+		return nil, errBackend("failed to read from Firebase", err)
+	}
+
+	return usageOfDay(stored, appsSource, createdBy, day), nil
+}
+
+func (r *usageRepository) FindByUser(appsSource string, createdBy uuid.UUID) (*entities.TrackUsage, error) {
+	// Query : Whatever day was stored last, nil when the user never created a point
+	var stored *entities.TrackUsage
+	if err := r.firebaseClient.NewRef(usagePath(appsSource, createdBy)).Get(r.firebaseCtx, &stored); err != nil {
+		return nil, errBackend("failed to read from Firebase", err)
+	}
+
+	return stored, nil
+}
+
+func (r *usageRepository) DeleteByUser(appsSource string, createdBy uuid.UUID) error {
+	// Query
+	if err := r.firebaseClient.NewRef(usagePath(appsSource, createdBy)).Delete(r.firebaseCtx); err != nil {
+		return errBackend("failed to delete from Firebase", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"fmt"
+	"pinmarker/configs"
+	"pinmarker/entities"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// User Erasure Struct
+type userErasureGormRepository struct {
+	db *gorm.DB
+}
+
+// User Erasure Constructor
+func NewUserErasureGormRepository() UserErasureRepository {
+	db, err := configs.GormDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize database: %v", err))
+	}
+
+	// Migrate Table
+	if err := db.AutoMigrate(&entities.UserErasure{}); err != nil {
+		panic(fmt.Sprintf("failed to migrate user erasure table: %v", err))
+	}
+
+	return &userErasureGormRepository{
+		db: db,
+	}
+}
+
+func (r *userErasureGormRepository) Create(erasure *entities.UserErasure) error {
+	// Query
+	if err := r.db.Create(erasure).Error; err != nil {
+		return errGorm("failed to save to database", err)
+	}
+
+	return nil
+}
+
+func (r *userErasureGormRepository) FindAll(createdBy uuid.UUID) ([]*entities.UserErasure, error) {
+	erasures := make([]*entities.UserErasure, 0)
+
+	// Query
+	if err := r.db.Where("created_by = ?", createdBy.String()).
+		Order("created_at DESC").
+		Find(&erasures).Error; err != nil {
+		return nil, errGorm("failed to read from database", err)
+	}
+
+	return erasures, nil
+}
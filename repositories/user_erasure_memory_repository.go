@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"pinmarker/entities"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// User Erasure Struct
+type userErasureMemoryRepository struct {
+	mu       sync.RWMutex
+	erasures []entities.UserErasure
+}
+
+// User Erasure Constructor
+func NewUserErasureMemoryRepository() UserErasureRepository {
+	return &userErasureMemoryRepository{}
+}
+
+func (r *userErasureMemoryRepository) Create(erasure *entities.UserErasure) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.erasures = append(r.erasures, *erasure)
+
+	return nil
+}
+
+func (r *userErasureMemoryRepository) FindAll(createdBy uuid.UUID) ([]*entities.UserErasure, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	erasures := make([]*entities.UserErasure, 0)
+	for _, erasure := range r.erasures {
+		if erasure.CreatedBy == createdBy {
+			erasure := erasure
+			erasures = append(erasures, &erasure)
+		}
+	}
+	userErasureSort(erasures)
+
+	return erasures, nil
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"sort"
+
+	"firebase.google.com/go/v4/db"
+	"github.com/google/uuid"
+)
+
+// User Erasure Interface
+type UserErasureRepository interface {
+	Create(erasure *entities.UserErasure) error
+	FindAll(createdBy uuid.UUID) ([]*entities.UserErasure, error)
+}
+
+// User Erasure Struct
+type userErasureRepository struct {
+	firebaseClient *db.Client
+	firebaseCtx    context.Context
+}
+
+// User Erasure Constructor
+func NewUserErasureRepository() UserErasureRepository {
+	client, ctx, err := configs.FirebaseDB()
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize Firebase DB: %v", err))
+	}
+
+	return &userErasureRepository{
+		firebaseClient: client,
+		firebaseCtx:    ctx,
+	}
+}
+
+// userErasurePath is the record's node under the erased user, outside every app source
+func userErasurePath(createdBy uuid.UUID, erasureID uuid.UUID) string {
+	return fmt.Sprintf("%s/user_%s/%s", configs.UserErasureDoc, createdBy.String(), erasureID.String())
+}
+
+func (r *userErasureRepository) Create(erasure *entities.UserErasure) error {
+	// Query
+	if err := r.firebaseClient.NewRef(userErasurePath(erasure.CreatedBy, erasure.ID)).Set(r.firebaseCtx, erasure); err != nil {
+		return errBackend("failed to save to Firebase", err)
+	}
+
+	return nil
+}
+
+func (r *userErasureRepository) FindAll(createdBy uuid.UUID) ([]*entities.UserErasure, error) {
+	// Doc Name
+	ref := r.firebaseClient.NewRef(fmt.Sprintf("%s/user_%s", configs.UserErasureDoc, createdBy.String()))
+
+	// Query
+	var result map[string]*entities.UserErasure
+	if err := ref.Get(r.firebaseCtx, &result); err != nil {
+		return nil, errBackend("failed to read from Firebase", err)
+	}
+	erasures := make([]*entities.UserErasure, 0, len(result))
+	for _, erasure := range result {
+		erasures = append(erasures, erasure)
+	}
+	userErasureSort(erasures)
+
+	return erasures, nil
+}
+
+// userErasureSort orders the records newest first
+func userErasureSort(erasures []*entities.UserErasure) {
+	sort.SliceStable(erasures, func(i, j int) bool {
+		return erasures[i].CreatedAt.After(erasures[j].CreatedAt)
+	})
+}
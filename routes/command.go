@@ -0,0 +1,127 @@
+package routes
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"pinmarker/services"
+	"pinmarker/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func SetUpCommand(args []string) {
+	// Setup Service
+	repo := SetUpRepository()
+	trackService := services.NewTrackService(repo.Track, nil)
+	apiKeyService := services.NewApiKeyService(repo.ApiKey)
+
+	switch args[0] {
+	case "migrate-coordinates":
+		// Service : Migrate Track Coordinates
+		total, err := trackService.MigrateTrackCoordinates()
+		if err != nil {
+			log.Println(err.Error())
+			fmt.Fprintf(os.Stderr, "Migration stopped after %d track: %v\n", total, err)
+			os.Exit(1)
+		}
+		log.Printf("Migrated %d track coordinates\n", total)
+		fmt.Printf("Migrated %d track\n", total)
+	case "migrate-latest":
+		// Service : Migrate Track Latest
+		total, err := trackService.MigrateTrackLatest()
+		if err != nil {
+			log.Println(err.Error())
+			fmt.Fprintf(os.Stderr, "Migration stopped after %d user: %v\n", total, err)
+			os.Exit(1)
+		}
+		log.Printf("Migrated %d latest track\n", total)
+		fmt.Printf("Migrated %d user\n", total)
+	case "apikey-issue", "apikey-rotate":
+		if len(args) < 2 || !utils.ValidatorContains(configs.AppsSources, args[1]) {
+			fmt.Fprintf(os.Stderr, "Usage: apikey-issue <app_source> or apikey-rotate <app_source> [overlap], app source is one of %v\n", configs.AppsSources)
+			os.Exit(1)
+		}
+		overlap := configs.ApiKeyRotateOverlap
+		if len(args) > 2 {
+			parsed, err := time.ParseDuration(args[2])
+			if err != nil || parsed < 0 {
+				fmt.Fprintf(os.Stderr, "Overlap %s is not a valid duration\n", args[2])
+				os.Exit(1)
+			}
+			overlap = parsed
+		}
+
+		// Service : Issue Or Rotate API Key
+		var key *entities.ApiKey
+		var raw string
+		var err error
+		expiring := 0
+		if args[0] == "apikey-issue" {
+			key, raw, err = apiKeyService.IssueApiKey(args[1])
+		} else {
+			key, raw, expiring, err = apiKeyService.RotateApiKey(args[1], overlap)
+		}
+		if err != nil {
+			log.Println(err.Error())
+			fmt.Fprintf(os.Stderr, "Failed to issue API key: %v\n", err)
+			os.Exit(1)
+		}
+		log.Printf("Issued API key %s for %s, %d previous key set to expire\n", key.ID, key.AppsSource, expiring)
+		fmt.Printf("Issued API key %s (%s) for %s, it is only shown once:\n%s\n", key.ID, key.Prefix, key.AppsSource, raw)
+		if args[0] == "apikey-rotate" {
+			fmt.Printf("%d previous key will stop working at %s\n", expiring, time.Now().Add(overlap).Format(time.RFC3339))
+		}
+	case "apikey-revoke":
+		if len(args) < 2 {
+			fmt.Fprintf(os.Stderr, "Usage: apikey-revoke <api_key_id>\n")
+			os.Exit(1)
+		}
+		id, err := uuid.Parse(args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "API key id %s is not valid\n", args[1])
+			os.Exit(1)
+		}
+
+		// Service : Revoke API Key
+		if err := apiKeyService.RevokeApiKey(id); err != nil {
+			log.Println(err.Error())
+			fmt.Fprintf(os.Stderr, "Failed to revoke API key: %v\n", err)
+			os.Exit(1)
+		}
+		log.Printf("Revoked API key %s\n", id)
+		fmt.Printf("Revoked API key %s\n", id)
+	case "apikey-list":
+		appsSource := ""
+		if len(args) > 1 {
+			appsSource = args[1]
+		}
+
+		// Service : Get All API Key
+		keys, err := apiKeyService.GetAllApiKey(appsSource)
+		if err != nil {
+			log.Println(err.Error())
+			fmt.Fprintf(os.Stderr, "Failed to list API keys: %v\n", err)
+			os.Exit(1)
+		}
+		now := time.Now()
+		for _, key := range keys {
+			status := "active"
+			switch {
+			case key.RevokedAt != nil:
+				status = "revoked"
+			case !key.Active(now):
+				status = "expired"
+			case key.ExpiresAt != nil:
+				status = "expires " + key.ExpiresAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%s\t%s\t%s\t%s\t%s\n", key.ID, key.AppsSource, key.Prefix, key.CreatedAt.Format(time.RFC3339), status)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %s, available commands: migrate-coordinates, migrate-latest, apikey-issue, apikey-rotate, apikey-revoke, apikey-list\n", args[0])
+		os.Exit(1)
+	}
+}
@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"os"
 	"pinmarker/controllers"
 	"pinmarker/repositories"
 	"pinmarker/services"
@@ -8,19 +9,82 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// Repository holds one repository per document, all on the same driver
+type Repository struct {
+	Track    repositories.TrackRepository
+	Geofence repositories.GeofenceRepository
+	Alert    repositories.AlertRepository
+	Share    repositories.ShareRepository
+	ApiKey   repositories.ApiKeyRepository
+	Usage    repositories.UsageRepository
+	Erasure  repositories.UserErasureRepository
+}
+
 func SetUpDependency(r *gin.Engine) {
 	// Setup Repository
-	trackRepo := repositories.NewTrackRepository()
+	repo := SetUpRepository()
+
+	// Setup Handler
+	trackService := SetUpHandler(r, repo)
 
+	// Task Scheduler
+	alertService := services.NewAlertService(repo.Track, repo.Alert)
+	SetUpScheduler(trackService, alertService)
+}
+
+func SetUpRepository() Repository {
+	switch os.Getenv("REPOSITORY_DRIVER") {
+	case "gorm":
+		return Repository{
+			Track:    repositories.NewTrackGormRepository(),
+			Geofence: repositories.NewGeofenceGormRepository(),
+			Alert:    repositories.NewAlertGormRepository(),
+			Share:    repositories.NewShareGormRepository(),
+			ApiKey:   repositories.NewApiKeyGormRepository(),
+			Usage:    repositories.NewUsageGormRepository(),
+			Erasure:  repositories.NewUserErasureGormRepository(),
+		}
+	case "memory":
+		return Repository{
+			Track:    repositories.NewTrackMemoryRepository(),
+			Geofence: repositories.NewGeofenceMemoryRepository(),
+			Alert:    repositories.NewAlertMemoryRepository(),
+			Share:    repositories.NewShareMemoryRepository(),
+			ApiKey:   repositories.NewApiKeyMemoryRepository(),
+			Usage:    repositories.NewUsageMemoryRepository(),
+			Erasure:  repositories.NewUserErasureMemoryRepository(),
+		}
+	default:
+		return Repository{
+			Track:    repositories.NewTrackRepository(),
+			Geofence: repositories.NewGeofenceRepository(),
+			Alert:    repositories.NewAlertRepository(),
+			Share:    repositories.NewShareRepository(),
+			ApiKey:   repositories.NewApiKeyRepository(),
+			Usage:    repositories.NewUsageRepository(),
+			Erasure:  repositories.NewUserErasureRepository(),
+		}
+	}
+}
+
+func SetUpHandler(r *gin.Engine, repo Repository) services.TrackService {
 	// Setup Service
-	trackService := services.NewTrackService(trackRepo)
+	trackHub := services.NewTrackHub()
+	geofenceService := services.NewGeofenceService(repo.Geofence)
+	quotaService := services.NewQuotaService(repo.Usage)
+	trackService := services.NewTrackService(repo.Track, quotaService, trackHub, geofenceService)
+	shareService := services.NewShareService(repo.Share, repo.Track)
+	apiKeyService := services.NewApiKeyService(repo.ApiKey)
+	userDataService := services.NewUserDataService(trackService, repo.Track, repo.Geofence, repo.Alert, repo.Share, repo.Usage, repo.Erasure)
 
 	// Setup Controller
-	trackController := controllers.NewTrackController(trackService)
+	trackController := controllers.NewTrackController(trackService, trackHub, quotaService)
+	geofenceController := controllers.NewGeofenceController(geofenceService)
+	shareController := controllers.NewShareController(shareService)
+	userController := controllers.NewUserController(userDataService)
 
 	// Setup Routes
-	SetUpRoutes(r, trackController)
+	SetUpRoutes(r, trackController, geofenceController, shareController, userController, apiKeyService)
 
-	// Task Scheduler
-	SetUpScheduler(trackService)
+	return trackService
 }
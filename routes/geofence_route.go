@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"pinmarker/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetUpRouteGeofence(api *gin.RouterGroup, geofenceController *controllers.GeofenceController, middleware ...gin.HandlerFunc) {
+	geofence := api.Group("/geofences", middleware...)
+	{
+		geofence.POST("/", geofenceController.CreateGeofence)
+		geofence.GET("/:app_source/:created_by", geofenceController.GetAllGeofence)
+		geofence.GET("/:app_source/:created_by/events", geofenceController.GetAllGeofenceEvent)
+		geofence.GET("/:app_source/:created_by/:geofence_id", geofenceController.GetGeofenceById)
+		geofence.PUT("/:app_source/:created_by/:geofence_id", geofenceController.UpdateGeofenceById)
+		geofence.DELETE("/:app_source/:created_by/:geofence_id", geofenceController.DeleteGeofenceById)
+	}
+}
@@ -1,17 +1,53 @@
 package routes
 
 import (
+	"log"
+	"pinmarker/configs"
 	"pinmarker/controllers"
+	"pinmarker/middlewares"
+	"pinmarker/services"
+	"pinmarker/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
 func SetUpRoutes(r *gin.Engine,
-	trackController *controllers.TrackController) {
+	trackController *controllers.TrackController,
+	geofenceController *controllers.GeofenceController,
+	shareController *controllers.ShareController,
+	userController *controllers.UserController,
+	apiKeyService services.ApiKeyService) {
 
 	// V1 Endpoint
 	api := r.Group("/api/v1")
 
+	// Auth : App API key, then bearer JWT when a key set is configured
+	var auth []gin.HandlerFunc
+	if configs.ApiKeyRequired {
+		auth = append(auth, middlewares.ApiKeyMiddleware(apiKeyService))
+	}
+	if configs.AuthJWKS != nil {
+		auth = append(auth, middlewares.AuthMiddleware(configs.AuthJWKS.Keyfunc, configs.AuthAdminClaim))
+	}
+
+	// Client IP : Forwarded headers are only believed from the configured proxies
+	if err := r.SetTrustedProxies(configs.TrustedProxies); err != nil {
+		log.Fatalf("Trusted proxies error: %v\n", err)
+	}
+
+	// Rate Limit : Token bucket per route, kept in memory
+	limiter := utils.NewRateLimiter()
+	rateLimit := func(name string) gin.HandlerFunc {
+		return middlewares.RateLimitMiddleware(name, limiter)
+	}
+
 	// Routes Endpoint
-	SetUpRouteTrack(api, trackController)
+	SetUpRouteTrack(api, trackController, rateLimit, auth...)
+	SetUpRouteGeofence(api, geofenceController, auth...)
+	SetUpRouteShare(api, shareController, rateLimit, auth...)
+
+	// Admin : Only served to an admin token, left out without a key set to verify it
+	if configs.AuthJWKS != nil {
+		SetUpRouteUser(api, userController, auth...)
+	}
 }
@@ -8,24 +8,27 @@ import (
 	"github.com/robfig/cron"
 )
 
-func SetUpScheduler(trackService services.TrackService) {
+func SetUpScheduler(trackService services.TrackService, alertService services.AlertService) {
 	// Initialize Scheduler
 	houseKeepingScheduler := schedulers.NewHouseKeepingScheduler()
 	auditScheduler := schedulers.NewAuditScheduler(trackService)
 	cleanScheduler := schedulers.NewCleanScheduler(trackService)
+	alertScheduler := schedulers.NewAlertScheduler(alertService)
 
 	// Init Scheduler
 	c := cron.New()
-	Scheduler(c, houseKeepingScheduler, auditScheduler, cleanScheduler)
+	Scheduler(c, houseKeepingScheduler, auditScheduler, cleanScheduler, alertScheduler)
 	c.Start()
 	defer c.Stop()
 }
 
-func Scheduler(c *cron.Cron, houseKeepingScheduler *schedulers.HouseKeepingScheduler, auditScheduler *schedulers.AuditScheduler, cleanScheduler *schedulers.CleanScheduler) {
+func Scheduler(c *cron.Cron, houseKeepingScheduler *schedulers.HouseKeepingScheduler, auditScheduler *schedulers.AuditScheduler, cleanScheduler *schedulers.CleanScheduler, alertScheduler *schedulers.AlertScheduler) {
 	// For Production
 	c.AddFunc("0 5 2 * *", houseKeepingScheduler.SchedulerMonthlyLog)
 	c.AddFunc("0 0 2 * * *", auditScheduler.SchedulerAuditAppsUserTotal)
 	c.AddFunc("0 0 3 * * *", auditScheduler.SchedulerAuditAppsUserTotal)
+	c.AddFunc("0 */5 * * * *", alertScheduler.SchedulerTrackAlert)
+	c.AddFunc("0 30 3 * * *", cleanScheduler.SchedulerPurgeTrash)
 
 	// For Development
 	go func() {
@@ -36,6 +39,10 @@ func Scheduler(c *cron.Cron, houseKeepingScheduler *schedulers.HouseKeepingSched
 
 		// Clean Scheduler
 		cleanScheduler.SchedulerCleanAllTracksCreatedByDays()
+		cleanScheduler.SchedulerPurgeTrash()
+
+		// Alert Scheduler
+		alertScheduler.SchedulerTrackAlert()
 
 		// House Keeping Scheduler
 		houseKeepingScheduler.SchedulerMonthlyLog()
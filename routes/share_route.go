@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"pinmarker/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetUpRouteShare(api *gin.RouterGroup, shareController *controllers.ShareController, rateLimit func(name string) gin.HandlerFunc, middleware ...gin.HandlerFunc) {
+	share := api.Group("/shares", middleware...)
+	{
+		share.POST("/", shareController.CreateShare)
+		share.GET("/:app_source/:created_by", shareController.GetAllShare)
+		share.DELETE("/:app_source/:created_by/:share_id", shareController.RevokeShareById)
+	}
+
+	// Public : The token is the only credential
+	api.GET("/shared/:token", rateLimit("read_shared"), shareController.GetSharedTrack)
+}
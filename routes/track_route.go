@@ -6,13 +6,27 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func SetUpRouteTrack(api *gin.RouterGroup, trackController *controllers.TrackController) {
-	track := api.Group("/tracks")
+func SetUpRouteTrack(api *gin.RouterGroup, trackController *controllers.TrackController, rateLimit func(name string) gin.HandlerFunc, middleware ...gin.HandlerFunc) {
+	read := rateLimit("read_track")
+
+	track := api.Group("/tracks", middleware...)
 	{
-		track.POST("/", trackController.CreateTrack)
-		track.POST("/multi", trackController.CreateTrackMulti)
-		track.GET("/:app_source/:created_by", trackController.GetAllTrack)
-		track.GET("/summary", trackController.GetAppsUserTotal)
+		track.POST("/", rateLimit("create_track"), trackController.CreateTrack)
+		track.POST("/multi", rateLimit("create_track_multi"), trackController.CreateTrackMulti)
+		track.POST("/:app_source/:created_by/import", rateLimit("import_track"), trackController.ImportTrack)
+		track.GET("/:app_source/:created_by", read, trackController.GetAllTrack)
+		track.GET("/:app_source/:created_by/export", read, trackController.ExportTrack)
+		track.GET("/:app_source/:created_by/trips", read, trackController.GetTrackTrips)
+		track.GET("/:app_source/:created_by/stats", read, trackController.GetTrackStats)
+		track.GET("/:app_source/:created_by/latest", read, trackController.GetLatestTrack)
+		track.GET("/:app_source/:created_by/stream", read, trackController.StreamTrack)
+		track.GET("/:app_source/:created_by/usage", read, trackController.GetTrackQuota)
+		track.GET("/:app_source/:created_by/trash", read, trackController.GetTrashTrack)
+		track.GET("/:app_source/latest", read, trackController.GetLatestTrackBulk)
+		track.GET("/:app_source/area", read, trackController.GetTrackWithinBox)
+		track.GET("/:app_source/nearby", read, trackController.GetTrackWithinRadius)
+		track.GET("/summary", read, trackController.GetAppsUserTotal)
+		track.PUT("/:app_source/:created_by/:track_id/recover", trackController.RecoverTrackById)
 		track.DELETE("/:app_source/:created_by/:track_id", trackController.DeleteTrackById)
 	}
 }
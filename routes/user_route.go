@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"pinmarker/controllers"
+	"pinmarker/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetUpRouteUser(api *gin.RouterGroup, userController *controllers.UserController, middleware ...gin.HandlerFunc) {
+	// Admin : Every app source of one user at once
+	middleware = append(middleware, middlewares.AdminMiddleware())
+
+	user := api.Group("/users", middleware...)
+	{
+		user.GET("/:created_by/apps", userController.GetUserApps)
+		user.GET("/:created_by/export", userController.ExportUserData)
+		user.GET("/:created_by/erasures", userController.GetAllUserErasure)
+		user.DELETE("/:created_by", userController.EraseUserData)
+	}
+}
@@ -0,0 +1,91 @@
+package schedulers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"pinmarker/entities"
+	"pinmarker/services"
+	"strconv"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+type AlertScheduler struct {
+	AlertService services.AlertService
+}
+
+func NewAlertScheduler(
+	alertService services.AlertService,
+) *AlertScheduler {
+	return &AlertScheduler{
+		AlertService: alertService,
+	}
+}
+
+func (s *AlertScheduler) SchedulerTrackAlert() {
+	// Open the JSON
+	file, err := os.Open("configs/admin_telegram.json")
+	if err != nil {
+		log.Fatalf("failed to open file: %v", err)
+	}
+	defer file.Close()
+
+	// Decode JSON
+	var admins []Admin
+	if err := json.NewDecoder(file).Decode(&admins); err != nil {
+		log.Fatalf("failed to decode json: %v", err)
+	}
+	if len(admins) == 0 {
+		return
+	}
+
+	bot, err := tgbotapi.NewBotAPI(os.Getenv("TELEGRAM_BOT_TOKEN"))
+	if err != nil {
+		log.Println("Failed to connect to Telegram bot")
+		return
+	}
+
+	// Recipients : Admins with a valid Telegram ID
+	recipients := make([]string, 0, len(admins))
+	byTelegramID := make(map[string]Admin)
+	for _, dt := range admins {
+		if _, err := strconv.ParseInt(dt.TelegramUserID, 10, 64); err != nil {
+			log.Println("Invalid Telegram User Id")
+			continue
+		}
+		recipients = append(recipients, dt.TelegramUserID)
+		byTelegramID[dt.TelegramUserID] = dt
+	}
+
+	// Service : Check Track Alerts, the admins a message failed for get it on the next check
+	total, err := s.AlertService.CheckTrackAlerts(recipients, func(alert *entities.TrackAlert, recipient string) error {
+		telegramID, _ := strconv.ParseInt(recipient, 10, 64)
+		msg := tgbotapi.NewMessage(telegramID, alertMessage(byTelegramID[recipient], alert))
+		if _, err := bot.Send(msg); err != nil {
+			return fmt.Errorf("failed to send message to Telegram: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	if total > 0 {
+		log.Printf("Sent %d track alert\n", total)
+	}
+}
+
+func alertMessage(admin Admin, alert *entities.TrackAlert) string {
+	recordedAt := alert.RecordedAt.Format(time.RFC1123)
+	switch alert.AlertType {
+	case "low_battery":
+		return fmt.Sprintf("[ALERT] Hello %s, user %s on %s is running low on battery, %d%% at their latest point recorded %s",
+			admin.Username, alert.CreatedBy, alert.AppsSource, alert.BatteryIndicator, recordedAt)
+	default:
+		return fmt.Sprintf("[ALERT] Hello %s, user %s on %s stopped sending live track, their latest point was recorded %s",
+			admin.Username, alert.CreatedBy, alert.AppsSource, recordedAt)
+	}
+}
@@ -38,7 +38,7 @@ func (s *AuditScheduler) SchedulerAuditAppsUserTotal() {
 	}
 
 	// Service : Get All Error Audit
-	res, err := s.TrackService.GetAppsUserTotal()
+	res, err := s.TrackService.GetAppsUserTotal("")
 	if err != nil {
 		log.Println(err.Error())
 		return
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"pinmarker/configs"
 	"pinmarker/services"
 	"strconv"
 
@@ -72,3 +73,53 @@ func (s *CleanScheduler) SchedulerCleanAllTracksCreatedByDays() {
 		}
 	}
 }
+
+func (s *CleanScheduler) SchedulerPurgeTrash() {
+	days := configs.TrackTrashRetentionDays
+
+	// Open the JSON
+	file, err := os.Open("configs/admin_telegram.json")
+	if err != nil {
+		log.Fatalf("failed to open file: %v", err)
+	}
+	defer file.Close()
+
+	// Decode JSON
+	var admins []Admin
+	if err := json.NewDecoder(file).Decode(&admins); err != nil {
+		log.Fatalf("failed to decode json: %v", err)
+	}
+
+	// Service : Purge Trash Track
+	purgedRow, err := s.TrackService.PurgeTrashTrack(days)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	if purgedRow == 0 {
+		return
+	}
+
+	// Send to Telegram
+	for _, dt := range admins {
+		bot, err := tgbotapi.NewBotAPI(os.Getenv("TELEGRAM_BOT_TOKEN"))
+		if err != nil {
+			log.Println("Failed to connect to Telegram bot")
+			return
+		}
+
+		telegramID, err := strconv.ParseInt(dt.TelegramUserID, 10, 64)
+		if err != nil {
+			log.Println("Invalid Telegram User Id")
+			return
+		}
+
+		msgText := fmt.Sprintf("[ADMIN] Hello %s, the system just purged deleted track that have been in the trash for %d days with total %d item purged", dt.Username, days, purgedRow)
+		msg := tgbotapi.NewMessage(telegramID, msgText)
+
+		if _, err := bot.Send(msg); err != nil {
+			log.Println("Failed to send message to Telegram")
+			return
+		}
+	}
+}
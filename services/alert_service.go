@@ -0,0 +1,133 @@
+package services
+
+import (
+	"log"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"pinmarker/repositories"
+	"pinmarker/utils"
+	"time"
+)
+
+// Alert Interface
+type AlertService interface {
+	CheckTrackAlerts(recipients []string, send func(alert *entities.TrackAlert, recipient string) error) (int, error)
+}
+
+// Alert Struct
+type alertService struct {
+	trackRepo repositories.TrackRepository
+	alertRepo repositories.AlertRepository
+}
+
+// Alert Constructor
+func NewAlertService(trackRepo repositories.TrackRepository, alertRepo repositories.AlertRepository) AlertService {
+	return &alertService{
+		trackRepo: trackRepo,
+		alertRepo: alertRepo,
+	}
+}
+
+// CheckTrackAlerts looks at every user's latest point and sends a low_battery alert when its
+// battery is under the threshold, and a silence alert when it is a live point older than the
+// silence window. An alert is sent once to each recipient and kept until its condition clears,
+// the recipients a send failed for are tried again on the next check. Returns the number of
+// alerts that reached a recipient.
+func (s *alertService) CheckTrackAlerts(recipients []string, send func(alert *entities.TrackAlert, recipient string) error) (int, error) {
+	now := time.Now()
+
+	// Repo : Latest point of every watched user
+	tracks, err := s.trackRepo.FindLatestByUser(now.Add(-configs.AlertLookback))
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, track := range tracks {
+		// Repo : Alerts already sent to the user
+		alerts, err := s.alertRepo.FindByUser(track.AppsSource, track.CreatedBy)
+		if err != nil {
+			log.Printf("alert: failed to load alerts of %s/%s: %v", track.AppsSource, track.CreatedBy, err)
+			continue
+		}
+		previous := make(map[string]*entities.TrackAlert)
+		for _, alert := range alerts {
+			previous[alert.AlertType] = alert
+		}
+
+		// Condition : Low battery lasts until a point is charged again, silence until a newer point
+		lowBattery := configs.AlertBatteryThreshold > 0 && track.BatteryIndicator < configs.AlertBatteryThreshold
+		silent := configs.AlertSilenceAfter > 0 && track.TrackType == "live" && now.Sub(track.CreatedAt) >= configs.AlertSilenceAfter
+		conditions := map[string]bool{
+			"low_battery": lowBattery && previous["low_battery"] == nil,
+			"silence":     silent && (previous["silence"] == nil || previous["silence"].TrackID != track.ID),
+		}
+		cleared := map[string]bool{
+			"low_battery": !lowBattery,
+			"silence":     !silent,
+		}
+
+		for _, alertType := range configs.AlertTypes {
+			if cleared[alertType] && previous[alertType] != nil {
+				if err := s.alertRepo.Delete(track.AppsSource, track.CreatedBy, alertType); err != nil {
+					log.Printf("alert: failed to clear %s of %s/%s: %v", alertType, track.AppsSource, track.CreatedBy, err)
+				}
+				continue
+			}
+
+			// Alert : A new one for every recipient, or the one still pending for some
+			var alert *entities.TrackAlert
+			targets := recipients
+			switch {
+			case conditions[alertType]:
+				alert = &entities.TrackAlert{
+					AppsSource:       track.AppsSource,
+					CreatedBy:        track.CreatedBy,
+					AlertType:        alertType,
+					TrackID:          track.ID,
+					BatteryIndicator: track.BatteryIndicator,
+					RecordedAt:       track.CreatedAt,
+				}
+			case previous[alertType] != nil && len(previous[alertType].Pending) > 0:
+				alert = previous[alertType]
+				targets = alertPending(alert.Pending, recipients)
+			default:
+				continue
+			}
+
+			delivered, pending := 0, make([]string, 0)
+			for _, recipient := range targets {
+				if err := send(alert, recipient); err != nil {
+					log.Printf("alert: failed to send %s of %s/%s to %s: %v", alertType, track.AppsSource, track.CreatedBy, recipient, err)
+					pending = append(pending, recipient)
+					continue
+				}
+				delivered++
+			}
+
+			// Nobody got it : A new alert is sent as new on the next check, a pending one stays
+			if delivered == 0 {
+				continue
+			}
+			sent++
+			alert.SentAt, alert.Pending = now, pending
+			if err := s.alertRepo.Save(alert); err != nil {
+				log.Printf("alert: failed to save %s of %s/%s: %v", alertType, track.AppsSource, track.CreatedBy, err)
+			}
+		}
+	}
+
+	return sent, nil
+}
+
+// alertPending keeps the pending recipients that are still recipients
+func alertPending(pending []string, recipients []string) []string {
+	targets := make([]string, 0, len(pending))
+	for _, recipient := range pending {
+		if utils.ValidatorContains(recipients, recipient) {
+			targets = append(targets, recipient)
+		}
+	}
+
+	return targets
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"errors"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"pinmarker/repositories"
+	"pinmarker/utils"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// API Key Interface
+type ApiKeyService interface {
+	IssueApiKey(appsSource string) (*entities.ApiKey, string, error)
+	RotateApiKey(appsSource string, overlap time.Duration) (*entities.ApiKey, string, int, error)
+	RevokeApiKey(id uuid.UUID) error
+	GetAllApiKey(appsSource string) ([]*entities.ApiKey, error)
+	AuthenticateApiKey(raw string) (string, error)
+}
+
+// apiKeyCached is a repository key and when it was read
+type apiKeyCached struct {
+	key    *entities.ApiKey
+	readAt time.Time
+}
+
+// API Key Struct
+type apiKeyService struct {
+	apiKeyRepo repositories.ApiKeyRepository
+	mu         sync.Mutex
+	cache      map[string]apiKeyCached
+}
+
+// API Key Constructor
+func NewApiKeyService(apiKeyRepo repositories.ApiKeyRepository) ApiKeyService {
+	return &apiKeyService{
+		apiKeyRepo: apiKeyRepo,
+		cache:      make(map[string]apiKeyCached),
+	}
+}
+
+// IssueApiKey stores a new key of the app, the raw key is only returned here
+func (s *apiKeyService) IssueApiKey(appsSource string) (*entities.ApiKey, string, error) {
+	raw, hash, prefix, err := utils.ApiKeyGenerate()
+	if err != nil {
+		return nil, "", err
+	}
+	key := &entities.ApiKey{
+		ID:         uuid.New(),
+		AppsSource: appsSource,
+		KeyHash:    hash,
+		Prefix:     prefix,
+		CreatedAt:  time.Now(),
+	}
+
+	// Repo : Save
+	if err := s.apiKeyRepo.Save(key); err != nil {
+		return nil, "", err
+	}
+
+	return key, raw, nil
+}
+
+// RotateApiKey issues a new key and lets the app's other active keys expire after overlap,
+// it returns how many keys were set to expire
+func (s *apiKeyService) RotateApiKey(appsSource string, overlap time.Duration) (*entities.ApiKey, string, int, error) {
+	// Repo : Find All
+	keys, err := s.apiKeyRepo.FindAll(appsSource)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	key, raw, err := s.IssueApiKey(appsSource)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	// Overlap : Never extend a key that already expires sooner
+	now := time.Now()
+	expiresAt := now.Add(overlap)
+	total := 0
+	for _, old := range keys {
+		if !old.Active(now) || (old.ExpiresAt != nil && old.ExpiresAt.Before(expiresAt)) {
+			continue
+		}
+		old.ExpiresAt = &expiresAt
+		if err := s.apiKeyRepo.Save(old); err != nil {
+			return key, raw, total, err
+		}
+		total++
+	}
+
+	return key, raw, total, nil
+}
+
+func (s *apiKeyService) RevokeApiKey(id uuid.UUID) error {
+	// Repo : Find All
+	keys, err := s.apiKeyRepo.FindAll("")
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if key.ID != id {
+			continue
+		}
+		if key.RevokedAt != nil {
+			return nil
+		}
+		now := time.Now()
+		key.RevokedAt = &now
+
+		// Repo : Save
+		return s.apiKeyRepo.Save(key)
+	}
+
+	return repositories.ErrApiKeyNotFound
+}
+
+func (s *apiKeyService) GetAllApiKey(appsSource string) ([]*entities.ApiKey, error) {
+	// Repo : Find All
+	return s.apiKeyRepo.FindAll(appsSource)
+}
+
+// AuthenticateApiKey returns the app source of an active key, the env keys are checked
+// first and a repository key is cached for a short while
+func (s *apiKeyService) AuthenticateApiKey(raw string) (string, error) {
+	if raw == "" {
+		return "", utils.ErrApiKeyInvalid
+	}
+	hash := utils.ApiKeyHash(raw)
+
+	// Env Keys
+	if appsSource, ok := configs.ApiKeyHashes[hash]; ok {
+		return appsSource, nil
+	}
+
+	// Cache
+	now := time.Now()
+	s.mu.Lock()
+	cached, ok := s.cache[hash]
+	s.mu.Unlock()
+	if !ok || now.Sub(cached.readAt) > configs.ApiKeyCacheTTL {
+		// Repo : Find By Hash
+		key, err := s.apiKeyRepo.FindByHash(hash)
+		if errors.Is(err, repositories.ErrApiKeyNotFound) {
+			return "", utils.ErrApiKeyInvalid
+		}
+		if err != nil {
+			return "", err
+		}
+		cached = apiKeyCached{key: key, readAt: now}
+		s.mu.Lock()
+		s.cache[hash] = cached
+		s.mu.Unlock()
+	}
+
+	if !cached.key.Active(now) {
+		return "", utils.ErrApiKeyInvalid
+	}
+
+	return cached.key.AppsSource, nil
+}
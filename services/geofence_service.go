@@ -0,0 +1,214 @@
+package services
+
+import (
+	"log"
+	"pinmarker/entities"
+	"pinmarker/repositories"
+	"pinmarker/utils"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Geofence Interface
+type GeofenceService interface {
+	TrackListener
+	CreateGeofence(fence *entities.Geofence) error
+	GetAllGeofence(appsSource string, createdBy uuid.UUID) ([]*entities.Geofence, error)
+	GetGeofenceByID(appsSource string, createdBy uuid.UUID, geofenceID uuid.UUID) (*entities.Geofence, error)
+	UpdateGeofence(req entities.RequestUpdateGeofence, appsSource string, createdBy uuid.UUID, geofenceID uuid.UUID) (*entities.Geofence, error)
+	DeleteGeofenceByID(appsSource string, createdBy uuid.UUID, geofenceID uuid.UUID) error
+	GetAllGeofenceEvent(query utils.GeofenceEventQuery, appsSource string, createdBy uuid.UUID) ([]*entities.GeofenceEvent, error)
+}
+
+// Geofence Struct
+type geofenceService struct {
+	geofenceRepo repositories.GeofenceRepository
+	locks        *fenceLocks
+}
+
+// Geofence Constructor
+func NewGeofenceService(geofenceRepo repositories.GeofenceRepository) GeofenceService {
+	return &geofenceService{
+		geofenceRepo: geofenceRepo,
+		locks:        &fenceLocks{users: make(map[fenceOwner]*fenceLock)},
+	}
+}
+
+// fenceOwner is the app & user a fence belongs to
+type fenceOwner struct {
+	appsSource string
+	createdBy  uuid.UUID
+}
+
+// fenceLocks serializes the read, walk & write of a user's fence states within the instance,
+// so two batches of the same user can't both walk from the same state. A lock is dropped
+// once nobody holds or waits for it.
+type fenceLocks struct {
+	mu    sync.Mutex
+	users map[fenceOwner]*fenceLock
+}
+
+type fenceLock struct {
+	mu      sync.Mutex
+	waiters int
+}
+
+func (l *fenceLocks) lock(key fenceOwner) {
+	l.mu.Lock()
+	user, ok := l.users[key]
+	if !ok {
+		user = &fenceLock{}
+		l.users[key] = user
+	}
+	user.waiters++
+	l.mu.Unlock()
+
+	user.mu.Lock()
+}
+
+func (l *fenceLocks) unlock(key fenceOwner) {
+	l.mu.Lock()
+	user := l.users[key]
+	user.waiters--
+	if user.waiters == 0 {
+		delete(l.users, key)
+	}
+	l.mu.Unlock()
+
+	user.mu.Unlock()
+}
+
+func (s *geofenceService) CreateGeofence(fence *entities.Geofence) error {
+	// Repo : Create
+	return s.geofenceRepo.Create(fence)
+}
+
+func (s *geofenceService) GetAllGeofence(appsSource string, createdBy uuid.UUID) ([]*entities.Geofence, error) {
+	// Repo : Find All
+	return s.geofenceRepo.FindAll(appsSource, createdBy)
+}
+
+func (s *geofenceService) GetGeofenceByID(appsSource string, createdBy uuid.UUID, geofenceID uuid.UUID) (*entities.Geofence, error) {
+	// Repo : Find By ID
+	return s.geofenceRepo.FindByID(appsSource, createdBy, geofenceID)
+}
+
+func (s *geofenceService) UpdateGeofence(req entities.RequestUpdateGeofence, appsSource string, createdBy uuid.UUID, geofenceID uuid.UUID) (*entities.Geofence, error) {
+	// Lock : The state reset must not interleave with a walk
+	key := fenceOwner{appsSource, createdBy}
+	s.locks.lock(key)
+	defer s.locks.unlock(key)
+
+	// Repo : Find By ID
+	fence, err := s.geofenceRepo.FindByID(appsSource, createdBy, geofenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Shape : The next track decides again where the user stands
+	utils.ConverterRequestToGeofence(req, fence)
+	fence.Inside, fence.StateAt = nil, nil
+
+	// Repo : Update
+	if err := s.geofenceRepo.Update(fence); err != nil {
+		return nil, err
+	}
+
+	return fence, nil
+}
+
+func (s *geofenceService) DeleteGeofenceByID(appsSource string, createdBy uuid.UUID, geofenceID uuid.UUID) error {
+	// Repo : Delete By ID
+	return s.geofenceRepo.DeleteByID(appsSource, createdBy, geofenceID)
+}
+
+func (s *geofenceService) GetAllGeofenceEvent(query utils.GeofenceEventQuery, appsSource string, createdBy uuid.UUID) ([]*entities.GeofenceEvent, error) {
+	// Repo : Find Events
+	return s.geofenceRepo.FindEvents(query, appsSource, createdBy)
+}
+
+// TrackCreated moves every fence of the tracks' users through the new points, oldest first.
+// The first point a fence sees only sets its state, each later crossing is an enter or exit
+// event. Points older than the fence's state are late arrivals and are skipped. Failures are
+// logged, the tracks are stored either way.
+func (s *geofenceService) TrackCreated(tracks []*entities.Track) {
+	// Group : Per app & user
+	groups := make(map[fenceOwner][]*entities.Track)
+	for _, track := range tracks {
+		key := fenceOwner{track.AppsSource, track.CreatedBy}
+		groups[key] = append(groups[key], track)
+	}
+
+	for key, group := range groups {
+		s.walkUserFences(key, group)
+	}
+}
+
+// walkUserFences moves the user's fences through the group while holding the user's lock
+func (s *geofenceService) walkUserFences(key fenceOwner, group []*entities.Track) {
+	s.locks.lock(key)
+	defer s.locks.unlock(key)
+
+	fences, err := s.geofenceRepo.FindAll(key.appsSource, key.createdBy)
+	if err != nil {
+		log.Printf("geofence: failed to load fences of %s/%s: %v", key.appsSource, key.createdBy, err)
+		return
+	}
+	if len(fences) == 0 {
+		return
+	}
+
+	sort.SliceStable(group, func(i, j int) bool {
+		return group[i].CreatedAt.Before(group[j].CreatedAt)
+	})
+	for _, fence := range fences {
+		events, changed := geofenceWalk(fence, group)
+		if !changed {
+			continue
+		}
+		if err := s.geofenceRepo.Update(fence); err != nil {
+			log.Printf("geofence: failed to save state of %s: %v", fence.ID, err)
+			continue
+		}
+		if err := s.geofenceRepo.CreateEvents(events); err != nil {
+			log.Printf("geofence: failed to save events of %s: %v", fence.ID, err)
+		}
+	}
+}
+
+// geofenceWalk moves the fence's state through the tracks and returns the crossings
+func geofenceWalk(fence *entities.Geofence, tracks []*entities.Track) ([]*entities.GeofenceEvent, bool) {
+	events := make([]*entities.GeofenceEvent, 0)
+	changed := false
+	for _, track := range tracks {
+		if fence.StateAt != nil && !track.CreatedAt.After(*fence.StateAt) {
+			continue
+		}
+
+		inside := utils.GeofenceContains(fence, float64(track.TrackLat), float64(track.TrackLong))
+		if fence.Inside != nil && *fence.Inside != inside {
+			eventType := "exit"
+			if inside {
+				eventType = "enter"
+			}
+			events = append(events, &entities.GeofenceEvent{
+				GeofenceID: fence.ID,
+				EventType:  eventType,
+				TrackID:    track.ID,
+				TrackLat:   track.TrackLat,
+				TrackLong:  track.TrackLong,
+				AppsSource: fence.AppsSource,
+				RecordedAt: track.CreatedAt,
+				CreatedBy:  fence.CreatedBy,
+			})
+		}
+
+		stateAt := track.CreatedAt
+		fence.Inside, fence.StateAt = &inside, &stateAt
+		changed = true
+	}
+
+	return events, changed
+}
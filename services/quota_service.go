@@ -0,0 +1,120 @@
+package services
+
+import (
+	"errors"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"pinmarker/repositories"
+	"pinmarker/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuotaUser is the app source & user the points are counted for
+type QuotaUser struct {
+	AppsSource string
+	CreatedBy  uuid.UUID
+}
+
+// QuotaReservation is the points taken from each user's quota on a day
+type QuotaReservation struct {
+	Day    string
+	Points map[QuotaUser]int
+}
+
+// Quota Interface
+type QuotaService interface {
+	ReserveQuota(points map[QuotaUser]int) (*QuotaReservation, error)
+	ReleaseQuota(reservation *QuotaReservation, points map[QuotaUser]int) error
+	GetQuota(appsSource string, createdBy uuid.UUID) (*entities.TrackQuota, error)
+}
+
+// Quota Struct
+type quotaService struct {
+	usageRepo repositories.UsageRepository
+}
+
+// Quota Constructor
+func NewQuotaService(usageRepo repositories.UsageRepository) QuotaService {
+	return &quotaService{
+		usageRepo: usageRepo,
+	}
+}
+
+// ReserveQuota takes the points from every user's daily quota, or none of them when one
+// user would go over it
+func (s *quotaService) ReserveQuota(points map[QuotaUser]int) (*QuotaReservation, error) {
+	reservation := &QuotaReservation{Day: utils.QuotaDay(time.Now()), Points: make(map[QuotaUser]int)}
+
+	for user, total := range points {
+		if total <= 0 {
+			continue
+		}
+
+		// Repo : Reserve
+		if _, err := s.usageRepo.Reserve(user.AppsSource, user.CreatedBy, reservation.Day, total, configs.QuotaDailyPoints); err != nil {
+			s.ReleaseQuota(reservation, reservation.Points)
+			return nil, err
+		}
+		reservation.Points[user] = total
+	}
+
+	return reservation, nil
+}
+
+// ReleaseQuota gives back the points of a reservation that were not written
+func (s *quotaService) ReleaseQuota(reservation *QuotaReservation, points map[QuotaUser]int) error {
+	var errs []error
+	for user, total := range points {
+		if total > reservation.Points[user] {
+			total = reservation.Points[user]
+		}
+		if total <= 0 {
+			continue
+		}
+
+		// Repo : Release
+		if err := s.usageRepo.Release(user.AppsSource, user.CreatedBy, reservation.Day, total); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		reservation.Points[user] -= total
+	}
+
+	return errors.Join(errs...)
+}
+
+// quotaPoints counts the tracks of each user
+func quotaPoints(tracks []*entities.Track) map[QuotaUser]int {
+	points := make(map[QuotaUser]int)
+	for _, track := range tracks {
+		points[QuotaUser{AppsSource: track.AppsSource, CreatedBy: track.CreatedBy}]++
+	}
+
+	return points
+}
+
+func (s *quotaService) GetQuota(appsSource string, createdBy uuid.UUID) (*entities.TrackQuota, error) {
+	now := time.Now()
+
+	// Repo : Find
+	usage, err := s.usageRepo.Find(appsSource, createdBy, utils.QuotaDay(now))
+	if err != nil {
+		return nil, err
+	}
+
+	quota := &entities.TrackQuota{
+		AppsSource: appsSource,
+		CreatedBy:  createdBy,
+		Day:        usage.Day,
+		Used:       usage.Points,
+		Limit:      configs.QuotaDailyPoints,
+		ResetsAt:   utils.QuotaResetsAt(now),
+	}
+	if quota.Limit > 0 && quota.Used < quota.Limit {
+		quota.Remaining = quota.Limit - quota.Used
+	}
+
+	return quota, nil
+}
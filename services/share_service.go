@@ -0,0 +1,104 @@
+package services
+
+import (
+	"errors"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"pinmarker/repositories"
+	"pinmarker/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Share Interface
+type ShareService interface {
+	CreateShare(share *entities.ShareLink) (string, error)
+	GetAllShare(appsSource string, createdBy uuid.UUID) ([]*entities.ShareLink, error)
+	RevokeShare(appsSource string, createdBy uuid.UUID, shareID uuid.UUID) error
+	GetSharedTrack(token string) (*entities.ShareLink, []*entities.Track, error)
+}
+
+// Share Struct
+type shareService struct {
+	shareRepo repositories.ShareRepository
+	trackRepo repositories.TrackRepository
+}
+
+// Share Constructor
+func NewShareService(shareRepo repositories.ShareRepository, trackRepo repositories.TrackRepository) ShareService {
+	return &shareService{
+		shareRepo: shareRepo,
+		trackRepo: trackRepo,
+	}
+}
+
+func (s *shareService) CreateShare(share *entities.ShareLink) (string, error) {
+	// Repo : Create
+	if err := s.shareRepo.Create(share); err != nil {
+		return "", err
+	}
+
+	// Token : Signed locator of the share, valid until it expires
+	return utils.ShareTokenSign(utils.ShareToken{
+		ID:         share.ID,
+		AppsSource: share.AppsSource,
+		CreatedBy:  share.CreatedBy,
+		ExpiresAt:  share.ExpiresAt.Unix(),
+	})
+}
+
+func (s *shareService) GetAllShare(appsSource string, createdBy uuid.UUID) ([]*entities.ShareLink, error) {
+	// Repo : Find All
+	return s.shareRepo.FindAll(appsSource, createdBy)
+}
+
+func (s *shareService) RevokeShare(appsSource string, createdBy uuid.UUID, shareID uuid.UUID) error {
+	// Repo : Revoke
+	return s.shareRepo.Revoke(appsSource, createdBy, shareID)
+}
+
+// GetSharedTrack resolves a share token to the newest share-loc points of its window. A forged or
+// unknown token reads as not found, an expired or revoked one as expired.
+func (s *shareService) GetSharedTrack(token string) (*entities.ShareLink, []*entities.Track, error) {
+	now := time.Now()
+
+	// Token
+	claims, err := utils.ShareTokenParse(token, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Repo : Find By ID
+	share, err := s.shareRepo.FindByID(claims.AppsSource, claims.CreatedBy, claims.ID)
+	if errors.Is(err, repositories.ErrShareNotFound) {
+		return nil, nil, utils.ErrShareTokenInvalid
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if share.RevokedAt != nil || !now.Before(share.ExpiresAt) {
+		return nil, nil, utils.ErrShareExpired
+	}
+
+	// Window : Recent shared points when the share has no from
+	filter := utils.TrackFilter{From: share.From, To: share.To, TrackType: configs.ShareTrackType}
+	if filter.From == nil {
+		from := share.CreatedAt.Add(-configs.ShareRecentWindow)
+		filter.From = &from
+	}
+	pagination := utils.Pagination{
+		Limit:     configs.ShareMaxPoints,
+		UseCursor: true,
+		Sort:      "desc",
+		Filter:    filter,
+	}
+
+	// Repo : Find All By Cursor
+	tracks, _, _, err := s.trackRepo.FindAllByCursor(pagination, share.AppsSource, share.CreatedBy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return share, tracks, nil
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Hub Interface
+type TrackHub interface {
+	TrackListener
+	Subscribe(appsSource string, createdBy uuid.UUID) *TrackSubscription
+	Unsubscribe(subscription *TrackSubscription)
+}
+
+// TrackSubscription receives the user's new tracks oldest first. Tracks is closed when the
+// subscriber is dropped for falling a whole buffer behind, or after Unsubscribe.
+type TrackSubscription struct {
+	Tracks <-chan *entities.Track
+	tracks chan *entities.Track
+	owner  trackHubOwner
+}
+
+type trackHubOwner struct {
+	appsSource string
+	createdBy  uuid.UUID
+}
+
+// Hub Struct
+type trackHub struct {
+	mu          sync.Mutex
+	subscribers map[trackHubOwner]map[*TrackSubscription]bool
+}
+
+// Hub Constructor
+func NewTrackHub() TrackHub {
+	return &trackHub{
+		subscribers: make(map[trackHubOwner]map[*TrackSubscription]bool),
+	}
+}
+
+func (h *trackHub) Subscribe(appsSource string, createdBy uuid.UUID) *TrackSubscription {
+	tracks := make(chan *entities.Track, configs.TrackStreamBuffer)
+	subscription := &TrackSubscription{
+		Tracks: tracks,
+		tracks: tracks,
+		owner:  trackHubOwner{appsSource, createdBy},
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subscriptions, ok := h.subscribers[subscription.owner]
+	if !ok {
+		subscriptions = make(map[*TrackSubscription]bool)
+		h.subscribers[subscription.owner] = subscriptions
+	}
+	subscriptions[subscription] = true
+
+	return subscription
+}
+
+func (h *trackHub) Unsubscribe(subscription *TrackSubscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.drop(subscription)
+}
+
+// drop removes and closes the subscription once, the caller must hold the lock
+func (h *trackHub) drop(subscription *TrackSubscription) {
+	subscriptions := h.subscribers[subscription.owner]
+	if !subscriptions[subscription] {
+		return
+	}
+	delete(subscriptions, subscription)
+	if len(subscriptions) == 0 {
+		delete(h.subscribers, subscription.owner)
+	}
+	close(subscription.tracks)
+}
+
+// TrackCreated hands each track to its user's subscribers without waiting on them, a
+// subscriber whose buffer is full is dropped rather than silently missing points
+func (h *trackHub) TrackCreated(tracks []*entities.Track) {
+	ordered := make([]*entities.Track, len(tracks))
+	copy(ordered, tracks)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].CreatedAt.Before(ordered[j].CreatedAt)
+	})
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, track := range ordered {
+		for subscription := range h.subscribers[trackHubOwner{track.AppsSource, track.CreatedBy}] {
+			published := *track
+			select {
+			case subscription.tracks <- &published:
+			default:
+				h.drop(subscription)
+			}
+		}
+	}
+}
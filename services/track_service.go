@@ -1,42 +1,337 @@
 package services
 
 import (
-	"errors"
+	"fmt"
+	"math"
 	"pinmarker/entities"
 	"pinmarker/repositories"
 	"pinmarker/utils"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 // Track Interface
 type TrackService interface {
-	GetAppsUserTotal() ([]*entities.AppCount, error)
+	GetAppsUserTotal(appsSource string) ([]*entities.AppCount, error)
 	CreateTrack(track *entities.Track) error
 	CreateTrackMulti(track []*entities.Track) error
+	CreateTrackMultiPartial(items entities.RequestCreateTrackMulti) ([]*entities.TrackBatchResult, entities.TrackBatchSummary, error)
+	ImportTrack(items entities.RequestCreateTrackMulti) ([]*entities.TrackBatchResult, entities.TrackImportSummary, error)
 	GetAllTrack(pagination utils.Pagination, appsSource string, createdBy uuid.UUID) ([]*entities.Track, int, error)
+	GetAllTrackByCursor(pagination utils.Pagination, appsSource string, createdBy uuid.UUID) ([]*entities.Track, string, string, error)
+	GetTrackWithinBox(query utils.SpatialQuery) ([]*entities.Track, error)
+	GetTrackWithinRadius(query utils.SpatialQuery) ([]*entities.TrackNearby, error)
+	ExportTrack(filter utils.TrackFilter, simplify utils.SimplifyQuery, appsSource string, createdBy uuid.UUID, write func(tracks []*entities.Track) error) error
+	GetTrackTrips(query utils.TripQuery, appsSource string, createdBy uuid.UUID) ([]*entities.Trip, error)
+	GetTrackStats(query utils.StatsQuery, appsSource string, createdBy uuid.UUID) ([]*entities.TrackDayStats, error)
+	GetLatestTrack(appsSource string, createdBy uuid.UUID) (*entities.Track, error)
+	GetLatestTrackBulk(appsSource string, createdBy []uuid.UUID) ([]*entities.Track, error)
 	DeleteTrackByID(appsSource string, createdBy uuid.UUID, trackID uuid.UUID) error
+	GetTrashTrack(pagination utils.Pagination, appsSource string, createdBy uuid.UUID) ([]*entities.TrackTrash, int, error)
+	RecoverTrackByID(appsSource string, createdBy uuid.UUID, trackID uuid.UUID) (*entities.Track, error)
 	DeleteAllTracksByDaysCreated(days int) (int64, error)
+	PurgeTrashTrack(days int) (int64, error)
+	MigrateTrackCoordinates() (int64, error)
+	MigrateTrackLatest() (int64, error)
+}
+
+// Page size of the oldest first walk & chunk size of the import writes
+const walkPageSize = 500
+const importChunkSize = 500
+
+// TrackListener is told about the tracks a client sent live, once they are stored
+type TrackListener interface {
+	TrackCreated(tracks []*entities.Track)
 }
 
 // Track Struct
 type trackService struct {
 	trackRepo repositories.TrackRepository
+	quota     QuotaService
+	listeners []TrackListener
 }
 
-// Track Constructor
-func NewTrackService(trackRepo repositories.TrackRepository) TrackService {
+// Track Constructor, a nil quota counts no points
+func NewTrackService(trackRepo repositories.TrackRepository, quota QuotaService, listeners ...TrackListener) TrackService {
 	return &trackService{
 		trackRepo: trackRepo,
+		quota:     quota,
+		listeners: listeners,
+	}
+}
+
+// notify hands the tracks the call inserted to every listener, a replayed track was
+// handed over when it was first stored
+func (s *trackService) notify(tracks []*entities.Track) {
+	inserted := make([]*entities.Track, 0, len(tracks))
+	for _, track := range tracks {
+		if !track.Replayed {
+			inserted = append(inserted, track)
+		}
+	}
+	if len(inserted) == 0 {
+		return
+	}
+	for _, listener := range s.listeners {
+		listener.TrackCreated(inserted)
 	}
 }
 
 func (s *trackService) CreateTrack(track *entities.Track) error {
-	return s.trackRepo.Create(track)
+	requested := *track
+
+	// Quota : One point of the user's day, none for a replay
+	stored, err := s.trackRepo.FindStored([]*entities.Track{track})
+	if err != nil {
+		return err
+	}
+	reservation, err := s.reserveQuota([]*entities.Track{track}, stored)
+	if err != nil {
+		return err
+	}
+
+	// Repo : Create, a concurrent replay gives its point back
+	if err := s.trackRepo.Create(track); err != nil {
+		s.releaseQuota(reservation, []*entities.Track{track})
+		return err
+	}
+	if track.Replayed {
+		s.releaseQuota(reservation, []*entities.Track{track})
+	}
+
+	// Replay : The stored track must be the one the client id was minted for
+	if requested.ID != uuid.Nil && !trackSamePoint(requested, *track) {
+		return repositories.ErrTrackConflict
+	}
+	s.notify([]*entities.Track{track})
+
+	return nil
+}
+
+// reserveQuota takes a point of each track whose client id is not stored yet from its
+// user's day, a replay is not counted again
+func (s *trackService) reserveQuota(tracks []*entities.Track, stored map[uuid.UUID]entities.Track) (*QuotaReservation, error) {
+	if s.quota == nil {
+		return nil, nil
+	}
+
+	counted := make([]*entities.Track, 0, len(tracks))
+	for _, track := range tracks {
+		if _, ok := stored[track.ID]; !ok {
+			counted = append(counted, track)
+		}
+	}
+
+	return s.quota.ReserveQuota(quotaPoints(counted))
+}
+
+// releaseQuota gives back the points of the tracks that were not written
+func (s *trackService) releaseQuota(reservation *QuotaReservation, tracks []*entities.Track) {
+	if s.quota == nil || len(tracks) == 0 {
+		return
+	}
+	s.quota.ReleaseQuota(reservation, quotaPoints(tracks))
+}
+
+// replayConflicts reports the indexes of the tracks whose client id is already stored, or
+// sent earlier in the batch, for a different point
+func replayConflicts(tracks []*entities.Track, stored map[uuid.UUID]entities.Track) map[int]bool {
+	conflicts := make(map[int]bool)
+	sent := make(map[uuid.UUID]*entities.Track)
+	for i, track := range tracks {
+		if track.ID == uuid.Nil {
+			continue
+		}
+		if item, ok := stored[track.ID]; ok && !trackSamePoint(*track, item) {
+			conflicts[i] = true
+			continue
+		}
+		if first, ok := sent[track.ID]; ok && !trackSamePoint(*track, *first) {
+			conflicts[i] = true
+			continue
+		}
+		sent[track.ID] = track
+	}
+
+	return conflicts
+}
+
+// replayedNew returns the tracks a concurrent write stored first, their points were reserved
+func replayedNew(tracks []*entities.Track, stored map[uuid.UUID]entities.Track) []*entities.Track {
+	replayed := make([]*entities.Track, 0)
+	for _, track := range tracks {
+		if _, ok := stored[track.ID]; track.Replayed && !ok {
+			replayed = append(replayed, track)
+		}
+	}
+
+	return replayed
 }
 
 func (s *trackService) CreateTrackMulti(track []*entities.Track) error {
-	return s.trackRepo.CreateBatch(track)
+	requested := make([]entities.Track, len(track))
+	for i, item := range track {
+		requested[i] = *item
+	}
+
+	// Replay : A client id minted for another point rejects the whole batch before any write
+	stored, err := s.trackRepo.FindStored(track)
+	if err != nil {
+		return err
+	}
+	conflicts := replayConflicts(track, stored)
+	for i := range track {
+		if conflicts[i] {
+			return fmt.Errorf("%w at index %d", repositories.ErrTrackConflict, i)
+		}
+	}
+
+	// Quota : One point per track of each user, none for a replay
+	reservation, err := s.reserveQuota(track, stored)
+	if err != nil {
+		return err
+	}
+
+	// Repo : Create Batch, the concurrent replays give their points back
+	if err := s.trackRepo.CreateBatch(track); err != nil {
+		s.releaseQuota(reservation, track)
+		return err
+	}
+	s.releaseQuota(reservation, replayedNew(track, stored))
+
+	// Replay : A concurrent write may still have taken a client id
+	for i, item := range track {
+		if requested[i].ID != uuid.Nil && !trackSamePoint(requested[i], *item) {
+			return fmt.Errorf("%w at index %d", repositories.ErrTrackConflict, i)
+		}
+	}
+	s.notify(track)
+
+	return nil
+}
+
+// trackSamePoint reports whether both tracks describe the same recorded point
+func trackSamePoint(a, b entities.Track) bool {
+	return a.AppsSource == b.AppsSource &&
+		a.CreatedBy == b.CreatedBy &&
+		a.TrackLat == b.TrackLat &&
+		a.TrackLong == b.TrackLong &&
+		a.TrackType == b.TrackType &&
+		a.BatteryIndicator == b.BatteryIndicator
+}
+
+func (s *trackService) CreateTrackMultiPartial(items entities.RequestCreateTrackMulti) ([]*entities.TrackBatchResult, entities.TrackBatchSummary, error) {
+	results := make([]*entities.TrackBatchResult, len(items))
+	summary := entities.TrackBatchSummary{Total: len(items)}
+
+	// Validate each item
+	tracks := make([]*entities.Track, 0, len(items))
+	indexes := make([]int, 0, len(items))
+	for i, item := range items {
+		if err := utils.ValidatorTrackMultiItem(item); err != nil {
+			results[i] = &entities.TrackBatchResult{Index: i, Status: "failed", Error: err.Error()}
+			continue
+		}
+
+		track := utils.ConverterRequestToTrackMulti(item)
+		tracks = append(tracks, track)
+		indexes = append(indexes, i)
+	}
+
+	// Replay : The items whose client id was minted for another point are left out
+	stored, err := s.trackRepo.FindStored(tracks)
+	if err != nil {
+		return nil, summary, err
+	}
+	conflicts := replayConflicts(tracks, stored)
+	if len(conflicts) > 0 {
+		writes := make([]*entities.Track, 0, len(tracks))
+		writeIndexes := make([]int, 0, len(tracks))
+		for j, track := range tracks {
+			if conflicts[j] {
+				results[indexes[j]] = &entities.TrackBatchResult{Index: indexes[j], Status: "failed", Error: repositories.ErrTrackConflict.Error()}
+				continue
+			}
+			writes = append(writes, track)
+			writeIndexes = append(writeIndexes, indexes[j])
+		}
+		tracks, indexes = writes, writeIndexes
+	}
+
+	// Quota : The items not stored yet, the whole batch is refused over it
+	reservation, err := s.reserveQuota(tracks, stored)
+	if err != nil {
+		return nil, summary, err
+	}
+
+	// Repo : Create Batch of the valid items, a failed write gives every point back
+	requested := make([]entities.Track, len(tracks))
+	for j, track := range tracks {
+		requested[j] = *track
+	}
+	if len(tracks) > 0 {
+		if err := s.trackRepo.CreateBatch(tracks); err != nil {
+			s.releaseQuota(reservation, tracks)
+			return nil, summary, err
+		}
+	}
+	created := make([]*entities.Track, 0, len(tracks))
+	failed := replayedNew(tracks, stored)
+	for j, track := range tracks {
+		i := indexes[j]
+		if requested[j].ID != uuid.Nil && !trackSamePoint(requested[j], *track) {
+			results[i] = &entities.TrackBatchResult{Index: i, Status: "failed", Error: repositories.ErrTrackConflict.Error()}
+			continue
+		}
+		id := track.ID
+		results[i] = &entities.TrackBatchResult{Index: i, Status: "created", ID: &id, Replayed: track.Replayed}
+		created = append(created, track)
+	}
+	s.releaseQuota(reservation, failed)
+	s.notify(created)
+
+	// Summary
+	for _, result := range results {
+		if result.Status == "created" {
+			summary.Created++
+		} else {
+			summary.Failed++
+		}
+	}
+
+	return results, summary, nil
+}
+
+// ImportTrack writes the valid imported points in chunks and reports the rejected ones
+func (s *trackService) ImportTrack(items entities.RequestCreateTrackMulti) ([]*entities.TrackBatchResult, entities.TrackImportSummary, error) {
+	rejected := make([]*entities.TrackBatchResult, 0)
+	summary := entities.TrackImportSummary{Total: len(items)}
+
+	// Validate each point
+	tracks := make([]*entities.Track, 0, len(items))
+	for i, item := range items {
+		if err := utils.ValidatorTrackImportItem(item); err != nil {
+			rejected = append(rejected, &entities.TrackBatchResult{Index: i, Status: "failed", Error: err.Error()})
+			continue
+		}
+		tracks = append(tracks, utils.ConverterRequestToTrackMulti(item))
+	}
+	summary.Rejected = len(rejected)
+
+	// Repo : Create Batch per chunk
+	for start := 0; start < len(tracks); start += importChunkSize {
+		end := start + importChunkSize
+		if end > len(tracks) {
+			end = len(tracks)
+		}
+		if err := s.trackRepo.CreateBatch(tracks[start:end]); err != nil {
+			return rejected, summary, err
+		}
+		summary.Imported = end
+	}
+
+	return rejected, summary, nil
 }
 
 func (s *trackService) GetAllTrack(pagination utils.Pagination, appsSource string, createdBy uuid.UUID) ([]*entities.Track, int, error) {
@@ -46,20 +341,240 @@ func (s *trackService) GetAllTrack(pagination utils.Pagination, appsSource strin
 		return nil, 0, err
 	}
 	if track == nil {
-		return nil, 0, errors.New("tracks not found")
+		return nil, 0, repositories.ErrTrackNotFound
+	}
+
+	// Simplify : The page keeps its ends, total still counts every track
+	if pagination.Simplify.Enabled() {
+		track = utils.SimplifyTrack(track, pagination.Simplify)
 	}
 
 	return track, total, nil
 }
 
+func (s *trackService) GetAllTrackByCursor(pagination utils.Pagination, appsSource string, createdBy uuid.UUID) ([]*entities.Track, string, string, error) {
+	// Repo : Get All Track By Cursor
+	track, next, prev, err := s.trackRepo.FindAllByCursor(pagination, appsSource, createdBy)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if track == nil {
+		return nil, "", "", repositories.ErrTrackNotFound
+	}
+
+	// Simplify : The page keeps its ends, so the cursors still point at them
+	if pagination.Simplify.Enabled() {
+		track = utils.SimplifyTrack(track, pagination.Simplify)
+	}
+
+	return track, next, prev, nil
+}
+
+func (s *trackService) GetTrackWithinBox(query utils.SpatialQuery) ([]*entities.Track, error) {
+	return s.trackRepo.FindWithinBox(query)
+}
+
+func (s *trackService) GetTrackWithinRadius(query utils.SpatialQuery) ([]*entities.TrackNearby, error) {
+	// Repo : Find Within Radius
+	tracks, err := s.trackRepo.FindWithinRadius(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Distance From Center
+	nearby := make([]*entities.TrackNearby, 0, len(tracks))
+	for _, track := range tracks {
+		distance := utils.GeoDistance(query.Lat, query.Long, float64(track.TrackLat), float64(track.TrackLong))
+		nearby = append(nearby, &entities.TrackNearby{
+			Track:    *track,
+			Distance: math.Round(distance*100) / 100,
+		})
+	}
+
+	return nearby, nil
+}
+
+// ExportTrack hands the user's tracks to write oldest first, one page at a time. A simplified
+// export is simplified page by page, max points are shared out by the share of each page.
+func (s *trackService) ExportTrack(filter utils.TrackFilter, simplify utils.SimplifyQuery, appsSource string, createdBy uuid.UUID, write func(tracks []*entities.Track) error) error {
+	if !simplify.Enabled() {
+		return s.walkTrack(filter, appsSource, createdBy, write)
+	}
+
+	// Count : Max points need the size of the route first
+	total := 0
+	if simplify.MaxPoints > 0 {
+		err := s.walkTrack(filter, appsSource, createdBy, func(tracks []*entities.Track) error {
+			total += len(tracks)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Simplify : The route's ends are pinned, the other max points - 2 are the pages' quota
+	seen, interior := 0, 0
+	return s.walkTrack(filter, appsSource, createdBy, func(tracks []*entities.Track) error {
+		pinFirst := seen == 0
+		seen += len(tracks)
+		pinLast := total > 0 && seen >= total
+
+		quota := -1
+		if simplify.MaxPoints > 0 {
+			pinned := 0
+			if pinFirst {
+				pinned++
+			}
+			if pinLast {
+				pinned++
+			}
+			share := simplify.MaxPoints - 2
+			if seen < total {
+				share = share * seen / total
+			}
+			quota = share - interior
+			if quota < 0 {
+				quota = 0
+			}
+			interior += quota
+			quota += pinned
+		}
+
+		page := utils.SimplifyTrackPage(tracks, simplify.Tolerance, quota, pinFirst, pinLast)
+		if len(page) == 0 {
+			return nil
+		}
+		return write(page)
+	})
+}
+
+// GetTrackTrips splits the user's tracks into trips on long pauses or jumps
+func (s *trackService) GetTrackTrips(query utils.TripQuery, appsSource string, createdBy uuid.UUID) ([]*entities.Trip, error) {
+	builder := newTripBuilder(query)
+	err := s.walkTrack(query.Filter, appsSource, createdBy, func(tracks []*entities.Track) error {
+		for _, track := range tracks {
+			builder.add(track)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.result(), nil
+}
+
+// GetTrackStats sums the user's tracks into distance, speed and battery totals per day
+func (s *trackService) GetTrackStats(query utils.StatsQuery, appsSource string, createdBy uuid.UUID) ([]*entities.TrackDayStats, error) {
+	builder := newStatsBuilder(query.Location)
+	err := s.walkTrack(query.Filter, appsSource, createdBy, func(tracks []*entities.Track) error {
+		for _, track := range tracks {
+			builder.add(track)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.result(), nil
+}
+
+// walkTrack reads the user's tracks oldest first one cursor page at a time,
+// so a long history is never held whole. Oldest is by created_at, the device
+// recorded time, which the trip & stats builders measure gaps with.
+func (s *trackService) walkTrack(filter utils.TrackFilter, appsSource string, createdBy uuid.UUID, fn func(tracks []*entities.Track) error) error {
+	pagination := utils.Pagination{
+		Limit:     walkPageSize,
+		UseCursor: true,
+		Sort:      "asc",
+		Filter:    filter,
+	}
+
+	for {
+		// Repo : Find All By Cursor
+		tracks, next, _, err := s.trackRepo.FindAllByCursor(pagination, appsSource, createdBy)
+		if err != nil {
+			return err
+		}
+		if len(tracks) > 0 {
+			if err := fn(tracks); err != nil {
+				return err
+			}
+		}
+		if next == "" {
+			return nil
+		}
+		pagination.Cursor = next
+	}
+}
+
+func (s *trackService) GetLatestTrack(appsSource string, createdBy uuid.UUID) (*entities.Track, error) {
+	// Repo : Find Latest
+	tracks, err := s.trackRepo.FindLatest(appsSource, []uuid.UUID{createdBy})
+	if err != nil {
+		return nil, err
+	}
+	if len(tracks) == 0 {
+		return nil, repositories.ErrTrackNotFound
+	}
+
+	return tracks[0], nil
+}
+
+func (s *trackService) GetLatestTrackBulk(appsSource string, createdBy []uuid.UUID) ([]*entities.Track, error) {
+	// Repo : Find Latest
+	return s.trackRepo.FindLatest(appsSource, createdBy)
+}
+
 func (s *trackService) DeleteTrackByID(appsSource string, createdBy uuid.UUID, trackID uuid.UUID) error {
 	return s.trackRepo.DeleteByID(appsSource, createdBy, trackID)
 }
 
-func (s *trackService) GetAppsUserTotal() ([]*entities.AppCount, error) {
-	return s.trackRepo.FindAppsUserTotal()
+func (s *trackService) GetTrashTrack(pagination utils.Pagination, appsSource string, createdBy uuid.UUID) ([]*entities.TrackTrash, int, error) {
+	return s.trackRepo.FindTrash(pagination, appsSource, createdBy)
+}
+
+func (s *trackService) RecoverTrackByID(appsSource string, createdBy uuid.UUID, trackID uuid.UUID) (*entities.Track, error) {
+	return s.trackRepo.RecoverByID(appsSource, createdBy, trackID)
+}
+
+// GetAppsUserTotal counts the users of each app, of appsSource only when it is given
+func (s *trackService) GetAppsUserTotal(appsSource string) ([]*entities.AppCount, error) {
+	// Repo : Find Apps User Total
+	appCounts, err := s.trackRepo.FindAppsUserTotal()
+	if err != nil {
+		return nil, err
+	}
+	if appsSource != "" {
+		own := make([]*entities.AppCount, 0, 1)
+		for _, appCount := range appCounts {
+			if appCount.AppName == appsSource {
+				own = append(own, appCount)
+			}
+		}
+		appCounts = own
+	}
+	if len(appCounts) == 0 {
+		return nil, repositories.ErrTrackNotFound
+	}
+
+	return appCounts, nil
 }
 
 func (s *trackService) DeleteAllTracksByDaysCreated(days int) (int64, error) {
 	return s.trackRepo.DeleteAllTracksByDaysCreated(days)
 }
+
+func (s *trackService) PurgeTrashTrack(days int) (int64, error) {
+	return s.trackRepo.PurgeTrash(time.Now().AddDate(0, 0, -days))
+}
+
+func (s *trackService) MigrateTrackCoordinates() (int64, error) {
+	return s.trackRepo.MigrateCoordinates()
+}
+
+func (s *trackService) MigrateTrackLatest() (int64, error) {
+	return s.trackRepo.MigrateLatest()
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"math"
+	"pinmarker/entities"
+	"pinmarker/utils"
+	"time"
+)
+
+// statsBuilder sums the tracks walkTrack feeds it into one total per day
+type statsBuilder struct {
+	location *time.Location
+	days     []*entities.TrackDayStats
+	previous *entities.Track
+}
+
+func newStatsBuilder(location *time.Location) *statsBuilder {
+	return &statsBuilder{
+		location: location,
+		days:     make([]*entities.TrackDayStats, 0),
+	}
+}
+
+// add counts the track in its day, the move from the previous track belongs to the
+// day it ends in and pauses longer than a trip gap are not movement
+func (b *statsBuilder) add(track *entities.Track) {
+	date := track.CreatedAt.In(b.location).Format("2006-01-02")
+	day := b.day(date)
+	if day.PointCount == 0 || track.BatteryIndicator < day.BatteryMin {
+		day.BatteryMin = track.BatteryIndicator
+	}
+	if day.PointCount == 0 || track.BatteryIndicator > day.BatteryMax {
+		day.BatteryMax = track.BatteryIndicator
+	}
+	day.PointCount++
+
+	// Move : From The Previous Track
+	previous := b.previous
+	b.previous = track
+	if previous == nil {
+		return
+	}
+	duration := track.CreatedAt.Sub(previous.CreatedAt)
+	if duration <= 0 || duration > utils.TripDefaultGap {
+		return
+	}
+	distance := utils.GeoDistance(float64(previous.TrackLat), float64(previous.TrackLong), float64(track.TrackLat), float64(track.TrackLong))
+	speed := utils.GeoSpeed(distance, duration)
+	if speed < utils.StatsMovingSpeed {
+		return
+	}
+	day.Distance += distance
+	day.MovingTime += duration.Seconds()
+	day.MaxSpeed = math.Max(day.MaxSpeed, speed)
+}
+
+// day returns the totals of the date, tracks come in order so it is the last or a new one
+func (b *statsBuilder) day(date string) *entities.TrackDayStats {
+	if len(b.days) > 0 && b.days[len(b.days)-1].Date == date {
+		return b.days[len(b.days)-1]
+	}
+	day := &entities.TrackDayStats{Date: date}
+	b.days = append(b.days, day)
+	return day
+}
+
+func (b *statsBuilder) result() []*entities.TrackDayStats {
+	for _, day := range b.days {
+		day.AvgSpeed = math.Round(utils.GeoSpeed(day.Distance, time.Duration(day.MovingTime*float64(time.Second)))*100) / 100
+		day.MaxSpeed = math.Round(day.MaxSpeed*100) / 100
+		day.Distance = math.Round(day.Distance*100) / 100
+		day.MovingTime = math.Round(day.MovingTime*100) / 100
+	}
+	return b.days
+}
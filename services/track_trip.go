@@ -0,0 +1,67 @@
+package services
+
+import (
+	"math"
+	"pinmarker/entities"
+	"pinmarker/utils"
+)
+
+// tripBuilder cuts trips out of the tracks walkTrack feeds it, only the open trip is held
+type tripBuilder struct {
+	query   utils.TripQuery
+	trips   []*entities.Trip
+	current *entities.Trip
+}
+
+func newTripBuilder(query utils.TripQuery) *tripBuilder {
+	return &tripBuilder{
+		query: query,
+		trips: make([]*entities.Trip, 0),
+	}
+}
+
+// add extends the open trip with the track, or starts a new one when the pause
+// or the jump since the previous point is over the thresholds
+func (b *tripBuilder) add(track *entities.Track) {
+	if trip := b.current; trip != nil {
+		gap := track.CreatedAt.Sub(trip.EndAt)
+		jump := utils.GeoDistance(float64(trip.EndLat), float64(trip.EndLong), float64(track.TrackLat), float64(track.TrackLong))
+		if gap <= b.query.Gap && jump <= b.query.Distance {
+			trip.EndAt = track.CreatedAt
+			trip.EndLat, trip.EndLong = track.TrackLat, track.TrackLong
+			trip.PointCount++
+			trip.Distance += jump
+			return
+		}
+		b.close()
+	}
+
+	b.current = &entities.Trip{
+		StartAt:    track.CreatedAt,
+		EndAt:      track.CreatedAt,
+		StartLat:   track.TrackLat,
+		StartLong:  track.TrackLong,
+		EndLat:     track.TrackLat,
+		EndLong:    track.TrackLong,
+		PointCount: 1,
+	}
+}
+
+// close keeps the open trip when it holds more than a single point
+func (b *tripBuilder) close() {
+	trip := b.current
+	b.current = nil
+	if trip == nil || trip.PointCount < 2 {
+		return
+	}
+
+	trip.Distance = math.Round(trip.Distance*100) / 100
+	trip.Duration = math.Round(trip.EndAt.Sub(trip.StartAt).Seconds()*100) / 100
+	trip.Bearing = math.Round(utils.GeoBearing(float64(trip.StartLat), float64(trip.StartLong), float64(trip.EndLat), float64(trip.EndLong))*100) / 100
+	b.trips = append(b.trips, trip)
+}
+
+func (b *tripBuilder) result() []*entities.Trip {
+	b.close()
+	return b.trips
+}
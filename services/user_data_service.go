@@ -0,0 +1,370 @@
+package services
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"pinmarker/repositories"
+	"pinmarker/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// User Data Interface
+type UserDataService interface {
+	GetUserApps(createdBy uuid.UUID) ([]string, error)
+	ExportUserData(createdBy uuid.UUID, w io.Writer) error
+	EraseUserData(createdBy uuid.UUID, requestedBy *uuid.UUID) (*entities.UserErasure, error)
+	GetAllUserErasure(createdBy uuid.UUID) ([]*entities.UserErasure, error)
+}
+
+// ErrUserErasureUnverified is returned with the audit record when a record of the user was still found after the erasure
+var ErrUserErasureUnverified = errors.New("user data erasure could not be verified")
+
+// User Data Struct
+type userDataService struct {
+	trackService TrackService
+	trackRepo    repositories.TrackRepository
+	geofenceRepo repositories.GeofenceRepository
+	alertRepo    repositories.AlertRepository
+	shareRepo    repositories.ShareRepository
+	usageRepo    repositories.UsageRepository
+	erasureRepo  repositories.UserErasureRepository
+}
+
+// User Data Constructor
+func NewUserDataService(
+	trackService TrackService,
+	trackRepo repositories.TrackRepository,
+	geofenceRepo repositories.GeofenceRepository,
+	alertRepo repositories.AlertRepository,
+	shareRepo repositories.ShareRepository,
+	usageRepo repositories.UsageRepository,
+	erasureRepo repositories.UserErasureRepository,
+) UserDataService {
+	return &userDataService{
+		trackService: trackService,
+		trackRepo:    trackRepo,
+		geofenceRepo: geofenceRepo,
+		alertRepo:    alertRepo,
+		shareRepo:    shareRepo,
+		usageRepo:    usageRepo,
+		erasureRepo:  erasureRepo,
+	}
+}
+
+func (s *userDataService) GetUserApps(createdBy uuid.UUID) ([]string, error) {
+	// Repo : Find Apps By User
+	return s.trackRepo.FindAppsByUser(createdBy)
+}
+
+// userApps is every known app source with the ones the user's tracks are stored under,
+// the other documents are only kept for the known ones
+func (s *userDataService) userApps(createdBy uuid.UUID) ([]string, error) {
+	stored, err := s.trackRepo.FindAppsByUser(createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	appsSources := append([]string{}, configs.AppsSources...)
+	for _, appsSource := range stored {
+		if !utils.ValidatorContains(appsSources, appsSource) {
+			appsSources = append(appsSources, appsSource)
+		}
+	}
+
+	return appsSources, nil
+}
+
+// walkUserData hands every record the user has in the app to fn with its document name,
+// one document after the other
+func (s *userDataService) walkUserData(appsSource string, createdBy uuid.UUID, fn func(doc string, record interface{}) error) error {
+	// Track : Oldest first
+	err := s.trackService.ExportTrack(utils.TrackFilter{}, utils.SimplifyQuery{}, appsSource, createdBy, func(tracks []*entities.Track) error {
+		for _, track := range tracks {
+			if err := fn(configs.TrackDoc, track); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Trash
+	pagination := utils.Pagination{Page: 1, Limit: walkPageSize}
+	for {
+		trash, total, err := s.trackService.GetTrashTrack(pagination, appsSource, createdBy)
+		if err != nil {
+			return err
+		}
+		for _, track := range trash {
+			if err := fn(configs.TrackTrashDoc, track); err != nil {
+				return err
+			}
+		}
+		if len(trash) == 0 || pagination.Page*pagination.Limit >= total {
+			break
+		}
+		pagination.Page++
+	}
+
+	// Latest
+	latest, err := s.trackRepo.FindLatest(appsSource, []uuid.UUID{createdBy})
+	if err != nil {
+		return err
+	}
+	for _, track := range latest {
+		if err := fn(configs.TrackLatestDoc, track); err != nil {
+			return err
+		}
+	}
+
+	// Geofence & Event
+	fences, err := s.geofenceRepo.FindAll(appsSource, createdBy)
+	if err != nil {
+		return err
+	}
+	for _, fence := range fences {
+		if err := fn(configs.GeofenceDoc, fence); err != nil {
+			return err
+		}
+	}
+	events, err := s.geofenceRepo.FindEventsByUser(appsSource, createdBy)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if err := fn(configs.GeofenceEventDoc, event); err != nil {
+			return err
+		}
+	}
+
+	// Alert
+	alerts, err := s.alertRepo.FindByUser(appsSource, createdBy)
+	if err != nil {
+		return err
+	}
+	for _, alert := range alerts {
+		if err := fn(configs.TrackAlertDoc, alert); err != nil {
+			return err
+		}
+	}
+
+	// Share
+	shares, err := s.shareRepo.FindAll(appsSource, createdBy)
+	if err != nil {
+		return err
+	}
+	for _, share := range shares {
+		if err := fn(configs.ShareDoc, share); err != nil {
+			return err
+		}
+	}
+
+	// Usage
+	usage, err := s.usageRepo.FindByUser(appsSource, createdBy)
+	if err != nil {
+		return err
+	}
+	if usage != nil {
+		if err := fn(configs.TrackUsageDoc, usage); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// countUserData counts the user's records by app & document, apps without any are left out
+func (s *userDataService) countUserData(createdBy uuid.UUID) ([]entities.UserDataApp, error) {
+	appsSources, err := s.userApps(createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	apps := make([]entities.UserDataApp, 0)
+	for _, appsSource := range appsSources {
+		records := make(map[string]int)
+		err := s.walkUserData(appsSource, createdBy, func(doc string, record interface{}) error {
+			records[doc]++
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(records) > 0 {
+			apps = append(apps, entities.UserDataApp{AppsSource: appsSource, Records: records})
+		}
+	}
+
+	return apps, nil
+}
+
+func (s *userDataService) ExportUserData(createdBy uuid.UUID, w io.Writer) error {
+	appsSources, err := s.userApps(createdBy)
+	if err != nil {
+		return err
+	}
+
+	// Archive : One JSON array per app & document, the manifest last once everything is counted
+	archive := &userDataArchive{zip: zip.NewWriter(w)}
+	manifest := entities.UserDataManifest{
+		CreatedBy:  createdBy,
+		ExportedAt: time.Now(),
+		Apps:       make([]entities.UserDataApp, 0),
+	}
+	for _, appsSource := range appsSources {
+		records := make(map[string]int)
+		err := s.walkUserData(appsSource, createdBy, func(doc string, record interface{}) error {
+			records[doc]++
+			return archive.write(fmt.Sprintf("%s/%s.json", appsSource, doc), record)
+		})
+		if err != nil {
+			return err
+		}
+		if len(records) > 0 {
+			manifest.Apps = append(manifest.Apps, entities.UserDataApp{AppsSource: appsSource, Records: records})
+		}
+	}
+	if err := archive.end(); err != nil {
+		return err
+	}
+
+	entry, err := archive.zip.Create("manifest.json")
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(entry)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return err
+	}
+
+	return archive.zip.Close()
+}
+
+func (s *userDataService) EraseUserData(createdBy uuid.UUID, requestedBy *uuid.UUID) (*entities.UserErasure, error) {
+	// Count : What is about to go
+	deleted, err := s.countUserData(createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Repo : Delete By User, every document of every app
+	var eraseErr error
+	for _, app := range deleted {
+		if eraseErr = s.deleteUserData(app.AppsSource, createdBy); eraseErr != nil {
+			break
+		}
+	}
+
+	// Verify : Read everything again, nothing may be left
+	remaining, err := s.countUserData(createdBy)
+	if err != nil && eraseErr == nil {
+		eraseErr = err
+	}
+
+	// Repo : Create the audit record, kept whether or not the erasure was verified
+	erasure := &entities.UserErasure{
+		ID:          uuid.New(),
+		CreatedBy:   createdBy,
+		RequestedBy: requestedBy,
+		Deleted:     deleted,
+		Remaining:   remaining,
+		Verified:    eraseErr == nil && len(remaining) == 0,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.erasureRepo.Create(erasure); err != nil {
+		return nil, err
+	}
+
+	if eraseErr != nil {
+		return erasure, eraseErr
+	}
+	if !erasure.Verified {
+		return erasure, fmt.Errorf("%w, see audit record %s", ErrUserErasureUnverified, erasure.ID.String())
+	}
+
+	return erasure, nil
+}
+
+// deleteUserData deletes every document of the user in the app
+func (s *userDataService) deleteUserData(appsSource string, createdBy uuid.UUID) error {
+	if err := s.trackRepo.DeleteByUser(appsSource, createdBy); err != nil {
+		return err
+	}
+	if err := s.geofenceRepo.DeleteByUser(appsSource, createdBy); err != nil {
+		return err
+	}
+	if err := s.alertRepo.DeleteByUser(appsSource, createdBy); err != nil {
+		return err
+	}
+	if err := s.shareRepo.DeleteByUser(appsSource, createdBy); err != nil {
+		return err
+	}
+
+	return s.usageRepo.DeleteByUser(appsSource, createdBy)
+}
+
+func (s *userDataService) GetAllUserErasure(createdBy uuid.UUID) ([]*entities.UserErasure, error) {
+	// Repo : Find All
+	return s.erasureRepo.FindAll(createdBy)
+}
+
+// userDataArchive writes the records of one document after the other as JSON array entries of a zip
+type userDataArchive struct {
+	zip   *zip.Writer
+	entry io.Writer
+	name  string
+	count int
+}
+
+func (a *userDataArchive) write(name string, record interface{}) error {
+	// Entry : A new document starts a new array
+	if name != a.name {
+		if err := a.end(); err != nil {
+			return err
+		}
+		entry, err := a.zip.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(entry, "[\n"); err != nil {
+			return err
+		}
+		a.entry, a.name, a.count = entry, name, 0
+	}
+
+	// Record
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if a.count > 0 {
+		if _, err := io.WriteString(a.entry, ",\n"); err != nil {
+			return err
+		}
+	}
+	a.count++
+	_, err = a.entry.Write(raw)
+
+	return err
+}
+
+// end closes the array of the current entry
+func (a *userDataArchive) end() error {
+	if a.entry == nil {
+		return nil
+	}
+	_, err := io.WriteString(a.entry, "\n]\n")
+	a.entry, a.name = nil, ""
+
+	return err
+}
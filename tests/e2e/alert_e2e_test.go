@@ -0,0 +1,150 @@
+package e2e
+
+import (
+	"errors"
+	"pinmarker/entities"
+	"pinmarker/repositories"
+	"pinmarker/services"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Positive - Test Case
+func TestSuccessCheckTrackAlertsOncePerCondition(t *testing.T) {
+	trackRepo := repositories.NewTrackMemoryRepository()
+	alertService := services.NewAlertService(trackRepo, repositories.NewAlertMemoryRepository())
+
+	// Test Data : A live user gone quiet on a low battery, a charged one still sending
+	quiet := uuid.MustParse("fcd3f23e-e5aa-11ee-892a-3216422910e9")
+	active := uuid.New()
+	point := func(createdBy uuid.UUID, battery int, age time.Duration) *entities.Track {
+		return &entities.Track{
+			BatteryIndicator: battery,
+			TrackLat:         -6.228755,
+			TrackLong:        106.820035,
+			TrackType:        "live",
+			AppsSource:       "pinmarker",
+			CreatedBy:        createdBy,
+			CreatedAt:        time.Now().Add(-age),
+		}
+	}
+	assert.NoError(t, trackRepo.CreateBatch([]*entities.Track{
+		point(quiet, 60, 2*time.Hour),
+		point(quiet, 9, time.Hour),
+		point(active, 80, time.Minute),
+	}))
+
+	// Exec : Twice, the second check finds nothing new
+	admins := []string{"1001"}
+	var sent []*entities.TrackAlert
+	send := func(alert *entities.TrackAlert, recipient string) error {
+		sent = append(sent, alert)
+		return nil
+	}
+	total, err := alertService.CheckTrackAlerts(admins, send)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+	total, err = alertService.CheckTrackAlerts(admins, send)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+
+	// Check Alerts
+	types := make([]string, 0)
+	for _, alert := range sent {
+		assert.Equal(t, quiet, alert.CreatedBy)
+		assert.Equal(t, 9, alert.BatteryIndicator)
+		types = append(types, alert.AlertType)
+	}
+	assert.ElementsMatch(t, []string{"low_battery", "silence"}, types)
+
+	// Exec : A charged point clears both, a low battery after it is alerted again
+	assert.NoError(t, trackRepo.Create(point(quiet, 70, time.Minute)))
+	total, err = alertService.CheckTrackAlerts(admins, send)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.NoError(t, trackRepo.Create(point(quiet, 12, 0)))
+	total, err = alertService.CheckTrackAlerts(admins, send)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "low_battery", sent[len(sent)-1].AlertType)
+}
+
+// Negative - Test Case
+func TestFailedCheckTrackAlertsRetriedAfterSendError(t *testing.T) {
+	trackRepo := repositories.NewTrackMemoryRepository()
+	alertService := services.NewAlertService(trackRepo, repositories.NewAlertMemoryRepository())
+
+	// Test Data
+	assert.NoError(t, trackRepo.Create(&entities.Track{
+		BatteryIndicator: 5,
+		TrackLat:         -6.228755,
+		TrackLong:        106.820035,
+		TrackType:        "share-loc",
+		AppsSource:       "pinmarker",
+		CreatedBy:        uuid.MustParse("fcd3f23e-e5aa-11ee-892a-3216422910e9"),
+	}))
+
+	// Exec : The failed send is not remembered
+	total, err := alertService.CheckTrackAlerts([]string{"1001"}, func(alert *entities.TrackAlert, recipient string) error {
+		return errors.New("telegram is down")
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+	total, err = alertService.CheckTrackAlerts([]string{"1001"}, func(alert *entities.TrackAlert, recipient string) error {
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+}
+
+func TestFailedCheckTrackAlertsRetriedForFailedAdmin(t *testing.T) {
+	trackRepo := repositories.NewTrackMemoryRepository()
+	alertRepo := repositories.NewAlertMemoryRepository()
+	alertService := services.NewAlertService(trackRepo, alertRepo)
+
+	// Test Data
+	createdBy := uuid.MustParse("fcd3f23e-e5aa-11ee-892a-3216422910e9")
+	assert.NoError(t, trackRepo.Create(&entities.Track{
+		BatteryIndicator: 5,
+		TrackLat:         -6.228755,
+		TrackLong:        106.820035,
+		TrackType:        "share-loc",
+		AppsSource:       "pinmarker",
+		CreatedBy:        createdBy,
+	}))
+	admins := []string{"1001", "1002", "1003"}
+	received := make(map[string]int)
+	down := "1002"
+	send := func(alert *entities.TrackAlert, recipient string) error {
+		if recipient == down {
+			return errors.New("telegram is down")
+		}
+		received[recipient]++
+		return nil
+	}
+
+	// Exec : The second admin fails, the ones after it still get the alert
+	total, err := alertService.CheckTrackAlerts(admins, send)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, map[string]int{"1001": 1, "1003": 1}, received)
+	alerts, err := alertRepo.FindByUser("pinmarker", createdBy)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1002"}, alerts[0].Pending)
+
+	// Exec : Only the failed admin is sent to again
+	down = ""
+	total, err = alertService.CheckTrackAlerts(admins, send)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, map[string]int{"1001": 1, "1002": 1, "1003": 1}, received)
+
+	// Exec : Everybody got it
+	total, err = alertService.CheckTrackAlerts(admins, send)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.Equal(t, map[string]int{"1001": 1, "1002": 1, "1003": 1}, received)
+}
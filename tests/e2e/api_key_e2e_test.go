@@ -0,0 +1,155 @@
+package e2e
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"pinmarker/configs"
+	"pinmarker/repositories"
+	"pinmarker/services"
+	"pinmarker/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// setUpApiKeyServer requires API keys, the returned service shares the server's key repository
+func setUpApiKeyServer(t *testing.T) (*httptest.Server, repositories.TrackRepository, services.ApiKeyService) {
+	t.Helper()
+
+	configs.ApiKeyRequired = true
+	cacheTTL := configs.ApiKeyCacheTTL
+	configs.ApiKeyCacheTTL = 0
+	t.Cleanup(func() {
+		configs.ApiKeyRequired = false
+		configs.ApiKeyCacheTTL = cacheTTL
+	})
+
+	repo := newTestRepository(repositories.NewTrackMemoryRepository())
+
+	return setUpServerWithRepositories(t, repo), repo.Track, services.NewApiKeyService(repo.ApiKey)
+}
+
+func trackPayload(appSource, userID string) map[string]interface{} {
+	return map[string]interface{}{
+		"battery_indicator": 80,
+		"track_lat":         -6.2,
+		"track_long":        106.8,
+		"track_type":        "live",
+		"app_source":        appSource,
+		"created_by":        userID,
+	}
+}
+
+// Positive - Test Case
+func TestSuccessApiKeyRotateWithOverlap(t *testing.T) {
+	server, _, apiKeyService := setUpApiKeyServer(t)
+
+	// Test Data
+	appSource := "myride"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	url := server.URL + "/api/v1/tracks/" + appSource + "/" + userID
+	_, first, err := apiKeyService.IssueApiKey(appSource)
+	assert.NoError(t, err)
+
+	// Exec : Create & Get With The Key
+	status, _ := sendJSONWithHeader(t, http.MethodPost, server.URL+"/api/v1/tracks", map[string]string{"X-API-Key": first}, trackPayload(appSource, userID))
+	assert.Equal(t, http.StatusCreated, status)
+	status, result := sendJSONWithHeader(t, http.MethodGet, url, map[string]string{"X-API-Key": first}, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, result["data"], 1)
+
+	// Exec : Rotate, both keys work during the overlap
+	_, second, expiring, err := apiKeyService.RotateApiKey(appSource, time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expiring)
+	for _, key := range []string{first, second} {
+		status, _ = sendJSONWithHeader(t, http.MethodGet, url, map[string]string{"X-API-Key": key}, nil)
+		assert.Equal(t, http.StatusOK, status)
+	}
+
+	// Exec : Rotate without overlap, only the newest key works
+	_, third, expiring, err := apiKeyService.RotateApiKey(appSource, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, expiring)
+	for key, expected := range map[string]int{first: http.StatusUnauthorized, second: http.StatusUnauthorized, third: http.StatusOK} {
+		status, _ = sendJSONWithHeader(t, http.MethodGet, url, map[string]string{"X-API-Key": key}, nil)
+		assert.Equal(t, expected, status)
+	}
+}
+
+func TestSuccessApiKeyFromEnv(t *testing.T) {
+	server, _, _ := setUpApiKeyServer(t)
+
+	// Test Data
+	key := "pmk_env-key-for-the-kumande-app"
+	configs.ApiKeyHashes[utils.ApiKeyHash(key)] = "kumande"
+	t.Cleanup(func() { delete(configs.ApiKeyHashes, utils.ApiKeyHash(key)) })
+
+	// Exec
+	status, _ := sendJSONWithHeader(t, http.MethodGet, server.URL+"/api/v1/tracks/kumande/fcd3f23e-e5aa-11ee-892a-3216422910e9", map[string]string{"X-API-Key": key}, nil)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestSuccessApiKeySummaryOfOwnApp(t *testing.T) {
+	server, trackRepo, apiKeyService := setUpApiKeyServer(t)
+
+	// Test Data
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	seedTrack(t, trackRepo, "myride", userID)
+	seedTrack(t, trackRepo, "kumande", userID)
+	_, key, err := apiKeyService.IssueApiKey("myride")
+	assert.NoError(t, err)
+
+	// Exec
+	status, result := sendJSONWithHeader(t, http.MethodGet, server.URL+"/api/v1/tracks/summary", map[string]string{"X-API-Key": key}, nil)
+
+	// Template Response : Only the key's app
+	assert.Equal(t, http.StatusOK, status)
+	data := result["data"].([]interface{})
+	assert.Len(t, data, 1)
+	assert.Equal(t, "myride", data[0].(map[string]interface{})["app_name"])
+}
+
+// Negative - Test Case
+func TestFailedApiKeyForAnotherApp(t *testing.T) {
+	server, _, apiKeyService := setUpApiKeyServer(t)
+
+	// Test Data
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	_, key, err := apiKeyService.IssueApiKey("myride")
+	assert.NoError(t, err)
+	header := map[string]string{"X-API-Key": key}
+
+	// Exec : Read, create & create multi into another app
+	status, result := sendJSONWithHeader(t, http.MethodGet, server.URL+"/api/v1/tracks/kumande/"+userID, header, nil)
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "api key is not valid for the app source", result["message"])
+	status, _ = sendJSONWithHeader(t, http.MethodPost, server.URL+"/api/v1/tracks", header, trackPayload("kumande", userID))
+	assert.Equal(t, http.StatusForbidden, status)
+	status, _ = sendJSONWithHeader(t, http.MethodPost, server.URL+"/api/v1/tracks/multi", header, []map[string]interface{}{
+		trackPayload("myride", userID),
+		trackPayload("kumande", userID),
+	})
+	assert.Equal(t, http.StatusForbidden, status)
+}
+
+func TestFailedApiKeyMissingOrRevoked(t *testing.T) {
+	server, _, apiKeyService := setUpApiKeyServer(t)
+
+	// Test Data
+	url := server.URL + "/api/v1/tracks/myride/fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	issued, key, err := apiKeyService.IssueApiKey("myride")
+	assert.NoError(t, err)
+	assert.NoError(t, apiKeyService.RevokeApiKey(issued.ID))
+
+	for _, header := range []map[string]string{{}, {"X-API-Key": "pmk_unknown"}, {"X-API-Key": key}} {
+		// Exec
+		status, result := sendJSONWithHeader(t, http.MethodGet, url, header, nil)
+
+		// Template Response
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, "failed", result["status"])
+		assert.Equal(t, "api key is not valid", result["message"])
+	}
+}
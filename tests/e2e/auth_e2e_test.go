@@ -0,0 +1,252 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"pinmarker/configs"
+	"pinmarker/repositories"
+	"pinmarker/utils"
+	"testing"
+	"time"
+
+	"github.com/MicahParks/keyfunc"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var authTestSecret = []byte("pinmarker-e2e-secret-pinmarker-e2e-secret")
+
+// setUpAuthServer serves the routes behind the JWT middleware with a single HMAC key
+func setUpAuthServer(t *testing.T) (*httptest.Server, repositories.TrackRepository) {
+	t.Helper()
+
+	setUpAuthKey(t)
+
+	return setUpServer(t)
+}
+
+// setUpAuthKey verifies the bearer tokens with a single HMAC key until the test ends
+func setUpAuthKey(t *testing.T) {
+	t.Helper()
+
+	configs.AuthJWKS = keyfunc.NewGiven(map[string]keyfunc.GivenKey{
+		"e2e": keyfunc.NewGivenHMAC(authTestSecret),
+	})
+	t.Cleanup(func() { configs.AuthJWKS = nil })
+}
+
+func signToken(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = "e2e"
+	raw, err := token.SignedString(authTestSecret)
+	assert.NoError(t, err)
+
+	return raw
+}
+
+func sendJSONWithToken(t *testing.T, method, url, token string, payload interface{}) (int, map[string]interface{}) {
+	t.Helper()
+
+	header := map[string]string{}
+	if token != "" {
+		header["Authorization"] = "Bearer " + token
+	}
+
+	return sendJSONWithHeader(t, method, url, header, payload)
+}
+
+func sendJSONWithHeader(t *testing.T, method, url string, header map[string]string, payload interface{}) (int, map[string]interface{}) {
+	t.Helper()
+
+	jsonPayload, _ := json.Marshal(payload)
+	req, err := http.NewRequest(method, url, bytes.NewBuffer(jsonPayload))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var result map[string]interface{}
+	err = json.Unmarshal(body, &result)
+	assert.NoError(t, err)
+
+	return resp.StatusCode, result
+}
+
+// Positive - Test Case
+func TestSuccessAuthOwnerAndAdmin(t *testing.T) {
+	server, trackRepo := setUpAuthServer(t)
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	otherID := "0b4b8d0e-5c3a-4d8e-9a3e-2f3a1c6b7d10"
+	seedTrack(t, trackRepo, appSource, otherID)
+	expiresAt := time.Now().Add(time.Hour).Unix()
+	owner := signToken(t, jwt.MapClaims{"sub": userID, "exp": expiresAt})
+	admin := signToken(t, jwt.MapClaims{"sub": userID, "exp": expiresAt, "admin": true})
+
+	// Exec : Create Own Track
+	status, _ := sendJSONWithToken(t, http.MethodPost, server.URL+"/api/v1/tracks", owner, map[string]interface{}{
+		"battery_indicator": 80,
+		"track_lat":         -6.2,
+		"track_long":        106.8,
+		"track_type":        "live",
+		"app_source":        appSource,
+		"created_by":        userID,
+	})
+	assert.Equal(t, http.StatusCreated, status)
+
+	// Exec : Get Own Track
+	status, result := sendJSONWithToken(t, http.MethodGet, server.URL+"/api/v1/tracks/"+appSource+"/"+userID, owner, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, result["data"], 1)
+
+	// Exec : Admin Gets Another User's Track
+	status, result = sendJSONWithToken(t, http.MethodGet, server.URL+"/api/v1/tracks/"+appSource+"/"+otherID, admin, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, result["data"], 1)
+}
+
+// Negative - Test Case
+func TestFailedAuthWithoutValidToken(t *testing.T) {
+	server, _ := setUpAuthServer(t)
+
+	// Test Data
+	url := server.URL + "/api/v1/tracks/pinmarker/fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	cases := map[string]string{
+		"authorization bearer token is required": "",
+		"token is not valid":                     signToken(t, jwt.MapClaims{"sub": "fcd3f23e-e5aa-11ee-892a-3216422910e9", "exp": time.Now().Add(-time.Minute).Unix()}),
+		"token subject is not valid":             signToken(t, jwt.MapClaims{"sub": "someone", "exp": time.Now().Add(time.Hour).Unix()}),
+	}
+
+	for message, token := range cases {
+		// Exec
+		status, result := sendJSONWithToken(t, http.MethodGet, url, token, nil)
+
+		// Template Response
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, "failed", result["status"])
+		assert.Equal(t, message, result["message"])
+	}
+}
+
+func TestFailedAuthSummaryWithoutAdmin(t *testing.T) {
+	server, trackRepo := setUpAuthServer(t)
+
+	// Test Data
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	seedTrack(t, trackRepo, "pinmarker", userID)
+	owner := signToken(t, jwt.MapClaims{"sub": userID, "exp": time.Now().Add(time.Hour).Unix()})
+
+	// Exec : Every app's totals are only for an admin
+	status, result := sendJSONWithToken(t, http.MethodGet, server.URL+"/api/v1/tracks/summary", owner, nil)
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "admin token is required", result["message"])
+	status, result = sendJSONWithToken(t, http.MethodGet, server.URL+"/api/v1/tracks/summary", adminToken(t), nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, result["data"], 1)
+}
+
+func TestFailedAuthWithAnotherUserData(t *testing.T) {
+	server, trackRepo := setUpAuthServer(t)
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	otherID := "0b4b8d0e-5c3a-4d8e-9a3e-2f3a1c6b7d10"
+	track := seedTrack(t, trackRepo, appSource, otherID)
+	owner := signToken(t, jwt.MapClaims{"sub": userID, "exp": time.Now().Add(time.Hour).Unix()})
+	url := server.URL + "/api/v1/tracks/" + appSource + "/" + otherID
+
+	// Exec : Get, Delete & Create On Behalf Of Another User
+	status, result := sendJSONWithToken(t, http.MethodGet, url, owner, nil)
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "created by does not match the token", result["message"])
+	status, _ = sendJSONWithToken(t, http.MethodDelete, url+"/"+track.ID.String(), owner, nil)
+	assert.Equal(t, http.StatusForbidden, status)
+	status, _ = sendJSONWithToken(t, http.MethodPost, server.URL+"/api/v1/tracks", owner, map[string]interface{}{
+		"battery_indicator": 80,
+		"track_lat":         -6.2,
+		"track_long":        106.8,
+		"track_type":        "live",
+		"app_source":        appSource,
+		"created_by":        otherID,
+	})
+	assert.Equal(t, http.StatusForbidden, status)
+
+	// Check Data : The track is still there
+	status, result = sendJSONWithToken(t, http.MethodGet, url, signToken(t, jwt.MapClaims{"sub": otherID, "exp": time.Now().Add(time.Hour).Unix()}), nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, result["data"], 1)
+}
+
+func TestFailedAuthWithAnotherUserTrackRoutes(t *testing.T) {
+	server, trackRepo := setUpAuthServer(t)
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	otherID := "0b4b8d0e-5c3a-4d8e-9a3e-2f3a1c6b7d10"
+	seedTrack(t, trackRepo, appSource, userID)
+	seedTrack(t, trackRepo, appSource, otherID)
+	owner := signToken(t, jwt.MapClaims{"sub": userID, "exp": time.Now().Add(time.Hour).Unix()})
+	url := server.URL + "/api/v1/tracks/" + appSource
+
+	// Exec : Every route of another user's data
+	routes := map[string]string{
+		http.MethodPost + " import": url + "/" + otherID + "/import",
+		http.MethodGet + " export":  url + "/" + otherID + "/export?format=csv",
+		http.MethodGet + " trips":   url + "/" + otherID + "/trips",
+		http.MethodGet + " stats":   url + "/" + otherID + "/stats",
+		http.MethodGet + " latest":  url + "/" + otherID + "/latest",
+		http.MethodGet + " stream":  url + "/" + otherID + "/stream",
+		http.MethodGet + " bulk":    url + "/latest?created_by=" + userID + "," + otherID,
+		http.MethodGet + " area":    url + "/area?min_lat=-6.3&min_long=106.7&max_lat=-6.1&max_long=106.9&created_by=" + otherID,
+		http.MethodGet + " nearby":  url + "/nearby?lat=-6.228755&long=106.820035&radius=1000&created_by=" + otherID,
+	}
+	for name, route := range routes {
+		method := http.MethodGet
+		if name == http.MethodPost+" import" {
+			method = http.MethodPost
+		}
+		status, result := sendJSONWithToken(t, method, route, owner, nil)
+		assert.Equal(t, http.StatusForbidden, status, name)
+		assert.Equal(t, "created by does not match the token", result["message"], name)
+	}
+
+	// Exec : Multi with one item of another user
+	status, _ := sendJSONWithToken(t, http.MethodPost, server.URL+"/api/v1/tracks/multi", owner, []interface{}{
+		trackMultiPayload(appSource, userID),
+		trackMultiPayload(appSource, otherID),
+	})
+	assert.Equal(t, http.StatusForbidden, status)
+
+	// Exec : Area & nearby without created_by only hold the token's own track
+	for _, route := range []string{
+		url + "/area?min_lat=-6.3&min_long=106.7&max_lat=-6.1&max_long=106.9",
+		url + "/nearby?lat=-6.228755&long=106.820035&radius=1000",
+	} {
+		status, result := sendJSONWithToken(t, http.MethodGet, route, owner, nil)
+		assert.Equal(t, http.StatusOK, status)
+		data := result["data"].([]interface{})
+		assert.Len(t, data, 1)
+		assert.Equal(t, userID, data[0].(map[string]interface{})["created_by"])
+	}
+
+	// Check Data : The multi wrote nothing
+	tracks, _, err := trackRepo.FindAll(utils.Pagination{Page: 1, Limit: 10}, appSource, uuid.MustParse(userID))
+	assert.NoError(t, err)
+	assert.Len(t, tracks, 1)
+}
@@ -0,0 +1,90 @@
+package e2e
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Positive - Test Case
+func TestSuccessExportTrackWithEachFormat(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	tracks := seedTrackBatch(t, trackRepo, appSource, userID, 3)
+
+	// Exec : CSV, oldest first
+	resp, err := http.Get(server.URL + "/api/v1/tracks/" + appSource + "/" + userID + "/export?format=csv")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "tracks_pinmarker_"+userID+".csv")
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, rows, 4)
+	assert.Equal(t, "id", rows[0][0])
+	assert.Equal(t, tracks[2].ID.String(), rows[1][0])
+	assert.Equal(t, tracks[0].ID.String(), rows[3][0])
+
+	// Exec : GeoJSON
+	resp, err = http.Get(server.URL + "/api/v1/tracks/" + appSource + "/" + userID + "/export?format=geojson")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var collection map[string]interface{}
+	err = json.Unmarshal(body, &collection)
+	assert.NoError(t, err)
+	assert.Equal(t, "FeatureCollection", collection["type"])
+	features, ok := collection["features"].([]interface{})
+	assert.True(t, ok, "features should be a JSON array")
+	assert.Len(t, features, 3)
+	geometry := features[0].(map[string]interface{})["geometry"].(map[string]interface{})
+	assert.Equal(t, []interface{}{float64(tracks[2].TrackLong), float64(tracks[2].TrackLat)}, geometry["coordinates"])
+
+	// Exec : GPX & KML are well formed XML
+	for _, format := range []string{"gpx", "kml"} {
+		resp, err := http.Get(server.URL + "/api/v1/tracks/" + appSource + "/" + userID + "/export?format=" + format)
+		assert.NoError(t, err)
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		var document interface{}
+		assert.NoError(t, xml.Unmarshal(body, &document), format)
+	}
+}
+
+// Negative - Test Case
+func TestFailedExportTrackWithInvalidFormat(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Exec
+	url := server.URL + "/api/v1/tracks/pinmarker/fcd3f23e-e5aa-11ee-892a-3216422910e9/export?format=shp"
+	resp, err := http.Get(url)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Prepare Response Test
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var result map[string]interface{}
+	err = json.Unmarshal(body, &result)
+	assert.NoError(t, err)
+
+	// Template Response
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "failed", result["status"])
+	assert.Equal(t, "format must be one of gpx, kml, geojson, csv", result["message"])
+}
@@ -0,0 +1,297 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"pinmarker/repositories"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// sendJSON sends the payload and decodes the JSON response
+func sendJSON(t *testing.T, method, url string, payload interface{}) (int, map[string]interface{}) {
+	t.Helper()
+
+	jsonPayload, _ := json.Marshal(payload)
+	req, err := http.NewRequest(method, url, bytes.NewBuffer(jsonPayload))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var result map[string]interface{}
+	err = json.Unmarshal(body, &result)
+	assert.NoError(t, err)
+
+	return resp.StatusCode, result
+}
+
+// Positive - Test Case
+func TestSuccessGeofenceCrud(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	url := server.URL + "/api/v1/geofences/" + appSource + "/" + userID
+
+	// Exec : Create
+	status, result := sendJSON(t, http.MethodPost, server.URL+"/api/v1/geofences", map[string]interface{}{
+		"name":        "Home",
+		"fence_type":  "circle",
+		"center_lat":  -6.2,
+		"center_long": 106.8,
+		"radius":      500,
+		"app_source":  appSource,
+		"created_by":  userID,
+	})
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "Geofence created", result["message"])
+	geofenceID := result["data"].(map[string]interface{})["id"].(string)
+
+	// Exec : Update To A Polygon
+	status, result = sendJSON(t, http.MethodPut, url+"/"+geofenceID, map[string]interface{}{
+		"name":       "Office",
+		"fence_type": "polygon",
+		"polygon": []map[string]float64{
+			{"lat": -6.21, "long": 106.81},
+			{"lat": -6.21, "long": 106.83},
+			{"lat": -6.23, "long": 106.83},
+		},
+	})
+	assert.Equal(t, http.StatusOK, status)
+	data := result["data"].(map[string]interface{})
+	assert.Equal(t, "Office", data["name"])
+	assert.Nil(t, data["center_lat"])
+	assert.Len(t, data["polygon"], 3)
+
+	// Exec : Get All
+	status, result = sendJSON(t, http.MethodGet, url, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, result["data"], 1)
+
+	// Exec : Delete, Then Get
+	status, _ = sendJSON(t, http.MethodDelete, url+"/"+geofenceID, nil)
+	assert.Equal(t, http.StatusOK, status)
+	status, result = sendJSON(t, http.MethodGet, url+"/"+geofenceID, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "Geofence not found", result["message"])
+}
+
+func TestSuccessGeofenceEventOnCreateTrack(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	status, result := sendJSON(t, http.MethodPost, server.URL+"/api/v1/geofences", map[string]interface{}{
+		"name":        "Home",
+		"fence_type":  "circle",
+		"center_lat":  -6.2,
+		"center_long": 106.8,
+		"radius":      500,
+		"app_source":  appSource,
+		"created_by":  userID,
+	})
+	assert.Equal(t, http.StatusCreated, status)
+	geofenceID := result["data"].(map[string]interface{})["id"].(string)
+
+	// Exec : Outside, inside, a late point inside, then outside again
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	points := []struct {
+		minute int
+		lat    float64
+	}{
+		{0, -6.3},
+		{10, -6.2},
+		{5, -6.2},
+		{20, -6.3},
+	}
+	for _, point := range points {
+		status, _ := sendJSON(t, http.MethodPost, server.URL+"/api/v1/tracks", map[string]interface{}{
+			"battery_indicator": 80,
+			"track_lat":         point.lat,
+			"track_long":        106.8,
+			"track_type":        "live",
+			"app_source":        appSource,
+			"recorded_at":       start.Add(time.Duration(point.minute) * time.Minute).Format(time.RFC3339Nano),
+			"created_by":        userID,
+		})
+		assert.Equal(t, http.StatusCreated, status)
+	}
+
+	// Check Events : Newest first, the first point and the late one emit nothing
+	status, result = sendJSON(t, http.MethodGet, server.URL+"/api/v1/geofences/"+appSource+"/"+userID+"/events?geofence_id="+geofenceID, nil)
+	assert.Equal(t, http.StatusOK, status)
+	data, ok := result["data"].([]interface{})
+	assert.True(t, ok, "data should be a JSON array")
+	assert.Len(t, data, 2)
+	assert.Equal(t, "exit", data[0].(map[string]interface{})["event_type"])
+	assert.Equal(t, "enter", data[1].(map[string]interface{})["event_type"])
+	recordedAt, err := time.Parse(time.RFC3339Nano, data[1].(map[string]interface{})["recorded_at"].(string))
+	assert.NoError(t, err)
+	assert.True(t, start.Add(10*time.Minute).Equal(recordedAt))
+
+	// Check State
+	status, result = sendJSON(t, http.MethodGet, server.URL+"/api/v1/geofences/"+appSource+"/"+userID+"/"+geofenceID, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, false, result["data"].(map[string]interface{})["inside"])
+}
+
+// slowGeofenceRepository widens the window between reading the fences and saving their state
+type slowGeofenceRepository struct {
+	repositories.GeofenceRepository
+}
+
+func (r *slowGeofenceRepository) FindAll(appsSource string, createdBy uuid.UUID) ([]*entities.Geofence, error) {
+	fences, err := r.GeofenceRepository.FindAll(appsSource, createdBy)
+	time.Sleep(10 * time.Millisecond)
+
+	return fences, err
+}
+
+func TestSuccessGeofenceEventOnConcurrentTracks(t *testing.T) {
+	repo := newTestRepository(repositories.NewTrackMemoryRepository())
+	repo.Geofence = &slowGeofenceRepository{repo.Geofence}
+	server := setUpServerWithRepositories(t, repo)
+	setRateLimit(t, "create_track", configs.RateLimit{})
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	status, result := sendJSON(t, http.MethodPost, server.URL+"/api/v1/geofences", map[string]interface{}{
+		"name":        "Home",
+		"fence_type":  "circle",
+		"center_lat":  -6.2,
+		"center_long": 106.8,
+		"radius":      500,
+		"app_source":  appSource,
+		"created_by":  userID,
+	})
+	assert.Equal(t, http.StatusCreated, status)
+	geofenceID := result["data"].(map[string]interface{})["id"].(string)
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	point := func(minute int, lat float64) map[string]interface{} {
+		return map[string]interface{}{
+			"battery_indicator": 80,
+			"track_lat":         lat,
+			"track_long":        106.8,
+			"track_type":        "live",
+			"app_source":        appSource,
+			"recorded_at":       start.Add(time.Duration(minute) * time.Minute).Format(time.RFC3339Nano),
+			"created_by":        userID,
+		}
+	}
+	status, _ = sendJSON(t, http.MethodPost, server.URL+"/api/v1/tracks", point(0, -6.3))
+	assert.Equal(t, http.StatusCreated, status)
+
+	// Exec : Inside points of the same user at once
+	var wg sync.WaitGroup
+	for i := 1; i <= 20; i++ {
+		wg.Add(1)
+		go func(minute int) {
+			defer wg.Done()
+			status, _ := sendJSON(t, http.MethodPost, server.URL+"/api/v1/tracks", point(minute, -6.2))
+			assert.Equal(t, http.StatusCreated, status)
+		}(i)
+	}
+	wg.Wait()
+
+	// Check Events : A single enter, no batch walked from a stale state
+	status, result = sendJSON(t, http.MethodGet, server.URL+"/api/v1/geofences/"+appSource+"/"+userID+"/events?geofence_id="+geofenceID, nil)
+	assert.Equal(t, http.StatusOK, status)
+	data := result["data"].([]interface{})
+	assert.Len(t, data, 1)
+	assert.Equal(t, "enter", data[0].(map[string]interface{})["event_type"])
+}
+
+// Negative - Test Case
+func TestFailedPostCreateGeofenceWithInvalidShape(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Test Data
+	cases := map[string]map[string]interface{}{
+		"radius must be a number between 1 and 50000": {
+			"fence_type": "circle", "center_lat": -6.2, "center_long": 106.8, "radius": 0,
+		},
+		"polygon must have between 3 and 100 points": {
+			"fence_type": "polygon", "polygon": []map[string]float64{{"lat": -6.2, "long": 106.8}},
+		},
+		"fence type must be circle or polygon": {
+			"fence_type": "square",
+		},
+	}
+
+	for message, payload := range cases {
+		payload["name"] = "Home"
+		payload["app_source"] = "pinmarker"
+		payload["created_by"] = "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+
+		// Exec
+		status, result := sendJSON(t, http.MethodPost, server.URL+"/api/v1/geofences", payload)
+
+		// Template Response
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, "failed", result["status"])
+		assert.Equal(t, message, result["message"])
+	}
+}
+
+func TestFailedGeofenceWithAnotherUserToken(t *testing.T) {
+	server, _ := setUpAuthServer(t)
+
+	// Test Data : A geofence of another user
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	otherID := "0b4b8d0e-5c3a-4d8e-9a3e-2f3a1c6b7d10"
+	expiresAt := time.Now().Add(time.Hour).Unix()
+	owner := signToken(t, jwt.MapClaims{"sub": userID, "exp": expiresAt})
+	other := signToken(t, jwt.MapClaims{"sub": otherID, "exp": expiresAt})
+	fence := map[string]interface{}{
+		"name":        "Home",
+		"fence_type":  "circle",
+		"center_lat":  -6.2,
+		"center_long": 106.8,
+		"radius":      500,
+		"app_source":  appSource,
+		"created_by":  otherID,
+	}
+	status, result := sendJSONWithToken(t, http.MethodPost, server.URL+"/api/v1/geofences", other, fence)
+	assert.Equal(t, http.StatusCreated, status)
+	geofenceID := result["data"].(map[string]interface{})["id"].(string)
+	url := server.URL + "/api/v1/geofences/" + appSource + "/" + otherID
+
+	// Exec : Without a token
+	status, _ = sendJSON(t, http.MethodGet, url, nil)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	// Exec : Every route with another user's token
+	status, result = sendJSONWithToken(t, http.MethodPost, server.URL+"/api/v1/geofences", owner, fence)
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "created by does not match the token", result["message"])
+	for _, route := range []string{url, url + "/events", url + "/" + geofenceID} {
+		status, _ = sendJSONWithToken(t, http.MethodGet, route, owner, nil)
+		assert.Equal(t, http.StatusForbidden, status, route)
+	}
+	status, _ = sendJSONWithToken(t, http.MethodPut, url+"/"+geofenceID, owner, fence)
+	assert.Equal(t, http.StatusForbidden, status)
+	status, _ = sendJSONWithToken(t, http.MethodDelete, url+"/"+geofenceID, owner, nil)
+	assert.Equal(t, http.StatusForbidden, status)
+
+	// Check Data : Untouched
+	status, result = sendJSONWithToken(t, http.MethodGet, url+"/"+geofenceID, other, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "Home", result["data"].(map[string]interface{})["name"])
+}
@@ -0,0 +1,163 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"pinmarker/configs"
+	"pinmarker/utils"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func postImportFile(t *testing.T, url string, filename string, content string) (*http.Response, map[string]interface{}) {
+	t.Helper()
+
+	// Multipart Body
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	assert.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	// Exec
+	resp, err := http.Post(url, writer.FormDataContentType(), &body)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Prepare Response Test
+	raw, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var result map[string]interface{}
+	err = json.Unmarshal(raw, &result)
+	assert.NoError(t, err)
+
+	return resp, result
+}
+
+// Positive - Test Case
+func TestSuccessImportTrackWithGPX(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data : History older than the past tolerance, one point out of range
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	recordedAt := time.Now().AddDate(0, -2, 0).UTC()
+	gpx := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="test" xmlns="http://www.topografix.com/GPX/1/1">
+<trk><trkseg>
+<trkpt lat="-6.228755" lon="106.820035"><time>%s</time></trkpt>
+<trkpt lat="-6.228756" lon="106.820036"><time>%s</time><type>share-loc</type></trkpt>
+<trkpt lat="-96.1" lon="106.820037"><time>%s</time></trkpt>
+</trkseg></trk>
+</gpx>`, recordedAt.Format(time.RFC3339), recordedAt.Add(time.Minute).Format(time.RFC3339), recordedAt.Add(2*time.Minute).Format(time.RFC3339))
+
+	// Exec
+	url := server.URL + "/api/v1/tracks/" + appSource + "/" + userID + "/import"
+	resp, result := postImportFile(t, url, "history.gpx", gpx)
+
+	// Template Response
+	assert.Equal(t, http.StatusMultiStatus, resp.StatusCode)
+	assert.Equal(t, "success", result["status"])
+
+	// Check Summary & Rejected Point
+	metadata, ok := result["metadata"].(map[string]interface{})
+	assert.True(t, ok, "metadata should be a JSON object")
+	assert.Equal(t, float64(3), metadata["total"])
+	assert.Equal(t, float64(2), metadata["imported"])
+	assert.Equal(t, float64(1), metadata["rejected"])
+	data, ok := result["data"].([]interface{})
+	assert.True(t, ok, "data should be a JSON array")
+	assert.Len(t, data, 1)
+	assert.Equal(t, float64(2), data[0].(map[string]interface{})["index"])
+	assert.Equal(t, "track latitude must be between -90 and 90", data[0].(map[string]interface{})["error"])
+
+	// Check Stored Track : Device time kept
+	tracks, total, err := trackRepo.FindAll(utils.Pagination{Page: 1, Limit: 10, Sort: "asc"}, appSource, uuid.MustParse(userID))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.True(t, recordedAt.Truncate(time.Second).Equal(tracks[0].RecordedAt))
+	assert.Equal(t, "live", tracks[0].TrackType)
+	assert.Equal(t, "share-loc", tracks[1].TrackType)
+}
+
+func TestSuccessImportTrackWithGeoJSON(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Test Data
+	recordedAt := time.Now().Add(-time.Hour).UTC()
+	geojson := fmt.Sprintf(`{"type":"FeatureCollection","features":[
+{"type":"Feature","geometry":{"type":"Point","coordinates":[106.820035,-6.228755]},"properties":{"recorded_at":"%s","battery_indicator":70}},
+{"type":"Feature","geometry":{"type":"LineString","coordinates":[[106.82,-6.22],[106.83,-6.23]]},"properties":{"coordTimes":["%s","%s"]}}
+]}`, recordedAt.Format(time.RFC3339), recordedAt.Add(time.Minute).Format(time.RFC3339), recordedAt.Add(2*time.Minute).Format(time.RFC3339))
+
+	// Exec
+	url := server.URL + "/api/v1/tracks/pinmarker/fcd3f23e-e5aa-11ee-892a-3216422910e9/import"
+	resp, result := postImportFile(t, url, "history.geojson", geojson)
+
+	// Template Response
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "success", result["status"])
+	metadata, ok := result["metadata"].(map[string]interface{})
+	assert.True(t, ok, "metadata should be a JSON object")
+	assert.Equal(t, float64(3), metadata["imported"])
+	assert.Equal(t, float64(0), metadata["rejected"])
+}
+
+func TestSuccessImportTrackExemptFromQuota(t *testing.T) {
+	server, _ := setUpServer(t)
+	quota := configs.QuotaDailyPoints
+	configs.QuotaDailyPoints = 1
+	t.Cleanup(func() { configs.QuotaDailyPoints = quota })
+
+	// Test Data : More points than the quota, one of them rejected
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	recordedAt := time.Now().AddDate(0, 0, -3).UTC()
+	geojson := fmt.Sprintf(`{"type":"FeatureCollection","features":[
+{"type":"Feature","geometry":{"type":"LineString","coordinates":[[106.82,-6.22],[106.83,-6.23],[106.84,-96.1]]},"properties":{"coordTimes":["%s","%s","%s"]}}
+]}`, recordedAt.Format(time.RFC3339), recordedAt.Add(time.Minute).Format(time.RFC3339), recordedAt.Add(2*time.Minute).Format(time.RFC3339))
+
+	// Exec
+	resp, result := postImportFile(t, server.URL+"/api/v1/tracks/"+appSource+"/"+userID+"/import", "history.geojson", geojson)
+	assert.Equal(t, http.StatusMultiStatus, resp.StatusCode)
+	assert.Equal(t, float64(2), result["metadata"].(map[string]interface{})["imported"])
+
+	// Check Data : Nothing taken from the day, a live point still fits
+	status, result := sendJSON(t, http.MethodGet, server.URL+"/api/v1/tracks/"+appSource+"/"+userID+"/usage", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, float64(0), result["data"].(map[string]interface{})["used"])
+	status, _ = sendJSON(t, http.MethodPost, server.URL+"/api/v1/tracks", trackPayload(appSource, userID))
+	assert.Equal(t, http.StatusCreated, status)
+}
+
+// Negative - Test Case
+func TestFailedImportTrackWithInvalidFile(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Test Data
+	url := server.URL + "/api/v1/tracks/pinmarker/fcd3f23e-e5aa-11ee-892a-3216422910e9/import"
+	cases := map[string][2]string{
+		"format must be one of gpx, geojson":   {"history.shp", "binary"},
+		"file is not a valid GPX document":     {"history.gpx", "not xml"},
+		"file is not a valid GeoJSON document": {"history.geojson", "{"},
+	}
+
+	for message, file := range cases {
+		// Exec
+		resp, result := postImportFile(t, url, file[0], file[1])
+
+		// Template Response
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, "failed", result["status"])
+		assert.Equal(t, message, result["message"])
+	}
+}
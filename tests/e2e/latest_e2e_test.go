@@ -0,0 +1,91 @@
+package e2e
+
+import (
+	"net/http"
+	"pinmarker/entities"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Positive - Test Case
+func TestSuccessGetLatestTrackAfterDelete(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data : Seeded newest first, a late arriving old point must not become the latest
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	tracks := seedTrackBatch(t, trackRepo, appSource, userID, 3)
+	late := &entities.Track{
+		BatteryIndicator: 50,
+		TrackLat:         -6.2,
+		TrackLong:        106.8,
+		TrackType:        "live",
+		AppsSource:       appSource,
+		CreatedBy:        uuid.MustParse(userID),
+		CreatedAt:        tracks[2].CreatedAt.Add(-time.Hour),
+	}
+	assert.NoError(t, trackRepo.Create(late))
+	url := server.URL + "/api/v1/tracks/" + appSource + "/" + userID
+
+	// Exec
+	status, result := sendJSON(t, http.MethodGet, url+"/latest", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, tracks[0].ID.String(), result["data"].(map[string]interface{})["id"])
+
+	// Exec : Deleting the latest falls back to the next newest
+	status, _ = sendJSON(t, http.MethodDelete, url+"/"+tracks[0].ID.String(), nil)
+	assert.Equal(t, http.StatusOK, status)
+	status, result = sendJSON(t, http.MethodGet, url+"/latest", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, tracks[1].ID.String(), result["data"].(map[string]interface{})["id"])
+}
+
+func TestSuccessGetLatestTrackBulk(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data
+	appSource := "pinmarker"
+	first := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	second := "0b4b8d0e-5c3a-4d8e-9a3e-2f3a1c6b7d10"
+	missing := "6a1f0c2e-8d3b-4b7a-9f2e-1c3d5e7f9a0b"
+	seedTrack(t, trackRepo, appSource, first)
+	seedTrack(t, trackRepo, appSource, second)
+
+	// Exec
+	url := server.URL + "/api/v1/tracks/" + appSource + "/latest?created_by=" + first + "," + missing + "&created_by=" + second
+	status, result := sendJSON(t, http.MethodGet, url, nil)
+
+	// Template Response
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "success", result["status"])
+
+	// Check Data
+	data, ok := result["data"].([]interface{})
+	assert.True(t, ok, "data should be a JSON array")
+	users := make([]string, 0)
+	for _, item := range data {
+		users = append(users, item.(map[string]interface{})["created_by"].(string))
+	}
+	assert.ElementsMatch(t, []string{first, second}, users)
+	metadata := result["metadata"].(map[string]interface{})
+	assert.Equal(t, float64(2), metadata["total"])
+	assert.Equal(t, []interface{}{missing}, metadata["not_found"])
+}
+
+// Negative - Test Case
+func TestFailedGetLatestTrack(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Exec : A user without track
+	status, result := sendJSON(t, http.MethodGet, server.URL+"/api/v1/tracks/pinmarker/fcd3f23e-e5aa-11ee-892a-3216422910e9/latest", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "Track not found", result["message"])
+
+	// Exec : A bulk request without users
+	status, result = sendJSON(t, http.MethodGet, server.URL+"/api/v1/tracks/pinmarker/latest", nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "created by must list between 1 and 100 users", result["message"])
+}
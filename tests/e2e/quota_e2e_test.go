@@ -0,0 +1,191 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"pinmarker/configs"
+	"pinmarker/repositories"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// setRateLimit replaces a route's limit for the test
+func setRateLimit(t *testing.T, name string, limit configs.RateLimit) {
+	t.Helper()
+
+	previous := configs.RateLimits[name]
+	configs.RateLimits[name] = limit
+	t.Cleanup(func() { configs.RateLimits[name] = previous })
+}
+
+// trackMultiPayload is a track payload with the recorded_at multi items require
+func trackMultiPayload(appSource, userID string) map[string]interface{} {
+	payload := trackPayload(appSource, userID)
+	payload["recorded_at"] = time.Now().Add(-time.Minute).Format(time.RFC3339)
+
+	return payload
+}
+
+// Positive - Test Case
+func TestSuccessGetTrackQuotaAfterCreate(t *testing.T) {
+	server, _ := setUpServer(t)
+	quota := configs.QuotaDailyPoints
+	configs.QuotaDailyPoints = 3
+	t.Cleanup(func() { configs.QuotaDailyPoints = quota })
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	url := server.URL + "/api/v1/tracks/" + appSource + "/" + userID + "/usage"
+
+	// Exec : Two points, then the usage
+	status, _ := sendJSON(t, http.MethodPost, server.URL+"/api/v1/tracks/multi", []map[string]interface{}{
+		trackMultiPayload(appSource, userID),
+		trackMultiPayload(appSource, userID),
+	})
+	assert.Equal(t, http.StatusCreated, status)
+	status, result := sendJSON(t, http.MethodGet, url, nil)
+	assert.Equal(t, http.StatusOK, status)
+	data := result["data"].(map[string]interface{})
+	assert.Equal(t, float64(2), data["used"])
+	assert.Equal(t, float64(3), data["limit"])
+	assert.Equal(t, float64(1), data["remaining"])
+
+	// Exec : A batch over the quota is refused whole, a single point still fits
+	status, _ = sendJSON(t, http.MethodPost, server.URL+"/api/v1/tracks/multi", []map[string]interface{}{
+		trackMultiPayload(appSource, userID),
+		trackMultiPayload(appSource, userID),
+	})
+	assert.Equal(t, http.StatusTooManyRequests, status)
+	status, _ = sendJSON(t, http.MethodPost, server.URL+"/api/v1/tracks", trackPayload(appSource, userID))
+	assert.Equal(t, http.StatusCreated, status)
+	status, result = sendJSON(t, http.MethodGet, url, nil)
+	assert.Equal(t, http.StatusOK, status)
+	data = result["data"].(map[string]interface{})
+	assert.Equal(t, float64(3), data["used"])
+	assert.Equal(t, float64(0), data["remaining"])
+}
+
+// Negative - Test Case
+func TestFailedCreateTrackOverQuota(t *testing.T) {
+	server, _ := setUpServer(t)
+	quota := configs.QuotaDailyPoints
+	configs.QuotaDailyPoints = 1
+	t.Cleanup(func() { configs.QuotaDailyPoints = quota })
+
+	// Test Data
+	payload := trackPayload("pinmarker", "fcd3f23e-e5aa-11ee-892a-3216422910e9")
+	status, _ := sendJSON(t, http.MethodPost, server.URL+"/api/v1/tracks", payload)
+	assert.Equal(t, http.StatusCreated, status)
+
+	// Exec
+	jsonPayload, _ := json.Marshal(payload)
+	resp, err := http.Post(server.URL+"/api/v1/tracks/", "application/json", bytes.NewBuffer(jsonPayload))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Template Response : Retry once the UTC day is over
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	assert.NoError(t, err)
+	assert.True(t, retryAfter > 0 && retryAfter <= 24*60*60)
+}
+
+func TestFailedGetAllTrackOverRateLimit(t *testing.T) {
+	server, _ := setUpServer(t)
+	setRateLimit(t, "read_track", configs.RateLimit{Rate: 0.01, Burst: 1})
+
+	// Test Data
+	url := server.URL + "/api/v1/tracks/pinmarker/"
+	first := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	second := "0b4b8d0e-5c3a-4d8e-9a3e-2f3a1c6b7d10"
+
+	// Exec : The first user's bucket is empty after one read, the second user's is not
+	status, _ := sendJSON(t, http.MethodGet, url+first, nil)
+	assert.Equal(t, http.StatusOK, status)
+
+	resp, err := http.Get(url + first)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	assert.NoError(t, err)
+	assert.True(t, retryAfter > 0 && retryAfter <= 100)
+
+	status, _ = sendJSON(t, http.MethodGet, url+second, nil)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestFailedRateLimitWithSpoofedForwardedFor(t *testing.T) {
+	server, _ := setUpServer(t)
+	setRateLimit(t, "read_track", configs.RateLimit{Rate: 0.01, Burst: 1})
+	setRateLimit(t, "read_shared", configs.RateLimit{Rate: 0.01, Burst: 1})
+
+	// Test Data
+	url := server.URL + "/api/v1/tracks/pinmarker/fcd3f23e-e5aa-11ee-892a-3216422910e9"
+
+	// Exec : A new X-Forwarded-For per request does not give a new bucket
+	status, _ := sendJSONWithHeader(t, http.MethodGet, url, map[string]string{"X-Forwarded-For": "203.0.113.1"}, nil)
+	assert.Equal(t, http.StatusOK, status)
+	status, _ = sendJSONWithHeader(t, http.MethodGet, url, map[string]string{"X-Forwarded-For": "203.0.113.2"}, nil)
+	assert.Equal(t, http.StatusTooManyRequests, status)
+
+	// Exec : The public shared link has its own limit
+	status, _ = sendJSON(t, http.MethodGet, server.URL+"/api/v1/shared/not-a-token", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = sendJSON(t, http.MethodGet, server.URL+"/api/v1/shared/not-a-token", nil)
+	assert.Equal(t, http.StatusTooManyRequests, status)
+}
+
+func TestFailedCreateTrackReplayCountedTwice(t *testing.T) {
+	server, _ := setUpServer(t)
+	quota := configs.QuotaDailyPoints
+	configs.QuotaDailyPoints = 2
+	t.Cleanup(func() { configs.QuotaDailyPoints = quota })
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	header := map[string]string{"Idempotency-Key": "replay-quota-0001"}
+	multi := []map[string]interface{}{trackMultiPayload(appSource, userID)}
+
+	// Exec : Each request three times, only the first one is counted
+	for i := 0; i < 3; i++ {
+		status, _ := sendJSONWithHeader(t, http.MethodPost, server.URL+"/api/v1/tracks", header, trackPayload(appSource, userID))
+		assert.Equal(t, http.StatusCreated, status)
+		status, _ = sendJSONWithHeader(t, http.MethodPost, server.URL+"/api/v1/tracks/multi?partial=true", header, multi)
+		assert.Equal(t, http.StatusCreated, status)
+	}
+
+	// Check Data
+	status, result := sendJSON(t, http.MethodGet, server.URL+"/api/v1/tracks/"+appSource+"/"+userID+"/usage", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, float64(2), result["data"].(map[string]interface{})["used"])
+}
+
+func TestFailedCreateTrackMultiPartialWithRepositoryError(t *testing.T) {
+	server, _ := setUpServerWithRepository(t, &failingTrackRepository{err: &repositories.RepositoryError{Kind: repositories.ErrBackendUnavailable, Message: "failed to batch insert to Firebase", Err: errors.New("timeout")}})
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	payload := []map[string]interface{}{
+		trackMultiPayload(appSource, userID),
+		trackMultiPayload(appSource, userID),
+	}
+
+	// Exec
+	status, result := sendJSON(t, http.MethodPost, server.URL+"/api/v1/tracks/multi?partial=true", payload)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "failed", result["status"])
+
+	// Check Data : The points are given back
+	status, result = sendJSON(t, http.MethodGet, server.URL+"/api/v1/tracks/"+appSource+"/"+userID+"/usage", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, float64(0), result["data"].(map[string]interface{})["used"])
+}
@@ -0,0 +1,122 @@
+package e2e
+
+import (
+	"net/http/httptest"
+	"pinmarker/entities"
+	"pinmarker/repositories"
+	"pinmarker/routes"
+	"pinmarker/utils"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Test Server
+func setUpServer(t *testing.T) (*httptest.Server, repositories.TrackRepository) {
+	t.Helper()
+
+	return setUpServerWithRepository(t, repositories.NewTrackMemoryRepository())
+}
+
+func setUpServerWithRepository(t *testing.T, trackRepo repositories.TrackRepository) (*httptest.Server, repositories.TrackRepository) {
+	t.Helper()
+
+	return setUpServerWithRepositories(t, newTestRepository(trackRepo)), trackRepo
+}
+
+// newTestRepository keeps every other document in memory
+func newTestRepository(trackRepo repositories.TrackRepository) routes.Repository {
+	return routes.Repository{
+		Track:    trackRepo,
+		Geofence: repositories.NewGeofenceMemoryRepository(),
+		Alert:    repositories.NewAlertMemoryRepository(),
+		Share:    repositories.NewShareMemoryRepository(),
+		ApiKey:   repositories.NewApiKeyMemoryRepository(),
+		Usage:    repositories.NewUsageMemoryRepository(),
+		Erasure:  repositories.NewUserErasureMemoryRepository(),
+	}
+}
+
+func setUpServerWithRepositories(t *testing.T, repo routes.Repository) *httptest.Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	// Setup Dependencies
+	router := gin.New()
+	routes.SetUpHandler(router, repo)
+
+	// Run
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// Test Repository : Every call fails with err
+type failingTrackRepository struct {
+	repositories.TrackRepository
+	err error
+}
+
+func (r *failingTrackRepository) Create(track *entities.Track) error {
+	return r.err
+}
+
+func (r *failingTrackRepository) CreateBatch(tracks []*entities.Track) error {
+	return r.err
+}
+
+func (r *failingTrackRepository) FindStored(tracks []*entities.Track) (map[uuid.UUID]entities.Track, error) {
+	return map[uuid.UUID]entities.Track{}, nil
+}
+
+func (r *failingTrackRepository) FindAll(pagination utils.Pagination, appsSource string, createdBy uuid.UUID) ([]*entities.Track, int, error) {
+	return nil, 0, r.err
+}
+
+func (r *failingTrackRepository) FindAppsUserTotal() ([]*entities.AppCount, error) {
+	return nil, r.err
+}
+
+// Test Data
+func seedTrack(t *testing.T, trackRepo repositories.TrackRepository, appSource, userID string) *entities.Track {
+	t.Helper()
+
+	track := &entities.Track{
+		BatteryIndicator: 80,
+		TrackLat:         -6.228755,
+		TrackLong:        106.820035,
+		TrackType:        "live",
+		AppsSource:       appSource,
+		CreatedBy:        uuid.MustParse(userID),
+	}
+	if err := trackRepo.Create(track); err != nil {
+		t.Fatalf("failed to seed track: %v", err)
+	}
+
+	return track
+}
+
+func seedTrackBatch(t *testing.T, trackRepo repositories.TrackRepository, appSource, userID string, total int) []*entities.Track {
+	t.Helper()
+
+	tracks := make([]*entities.Track, 0, total)
+	for i := 0; i < total; i++ {
+		tracks = append(tracks, &entities.Track{
+			BatteryIndicator: 80 - i,
+			TrackLat:         -6.228755,
+			TrackLong:        106.820035,
+			TrackType:        "live",
+			AppsSource:       appSource,
+			CreatedAt:        time.Now().Add(-time.Duration(i) * time.Minute),
+			CreatedBy:        uuid.MustParse(userID),
+		})
+	}
+	if err := trackRepo.CreateBatch(tracks); err != nil {
+		t.Fatalf("failed to seed tracks: %v", err)
+	}
+
+	return tracks
+}
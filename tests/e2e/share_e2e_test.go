@@ -0,0 +1,158 @@
+package e2e
+
+import (
+	"net/http"
+	"pinmarker/entities"
+	"pinmarker/repositories"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// seedShareTracks stores total share-loc tracks of the user recorded a minute apart, the newest first
+func seedShareTracks(t *testing.T, trackRepo repositories.TrackRepository, appSource, userID string, total int) []*entities.Track {
+	t.Helper()
+
+	tracks := make([]*entities.Track, 0, total)
+	for i := 0; i < total; i++ {
+		tracks = append(tracks, &entities.Track{
+			BatteryIndicator: 80 - i,
+			TrackLat:         -6.228755,
+			TrackLong:        106.820035,
+			TrackType:        "share-loc",
+			AppsSource:       appSource,
+			CreatedAt:        time.Now().Add(-time.Duration(i) * time.Minute),
+			CreatedBy:        uuid.MustParse(userID),
+		})
+	}
+	assert.NoError(t, trackRepo.CreateBatch(tracks))
+
+	return tracks
+}
+
+// Positive - Test Case
+func TestSuccessShareLinkUntilRevoked(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data : Recent shared points of the user, an old one and another user's one
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	tracks := seedShareTracks(t, trackRepo, appSource, userID, 2)
+	old := &entities.Track{
+		BatteryIndicator: 50,
+		TrackLat:         -6.2,
+		TrackLong:        106.8,
+		TrackType:        "share-loc",
+		AppsSource:       appSource,
+		CreatedBy:        uuid.MustParse(userID),
+		CreatedAt:        time.Now().Add(-3 * time.Hour),
+	}
+	assert.NoError(t, trackRepo.Create(old))
+	seedShareTracks(t, trackRepo, appSource, "0b4b8d0e-5c3a-4d8e-9a3e-2f3a1c6b7d10", 1)
+
+	// Exec : Create
+	status, result := sendJSON(t, http.MethodPost, server.URL+"/api/v1/shares", map[string]interface{}{
+		"app_source": appSource,
+		"created_by": userID,
+		"expires_in": "30m",
+	})
+	assert.Equal(t, http.StatusCreated, status)
+	data := result["data"].(map[string]interface{})
+	token := data["token"].(string)
+	shareID := data["id"].(string)
+	assert.NotEmpty(t, token)
+
+	// Exec : Public Read, only the user's recent points newest first
+	status, result = sendJSON(t, http.MethodGet, server.URL+"/api/v1/shared/"+token, nil)
+	assert.Equal(t, http.StatusOK, status)
+	shared := result["data"].([]interface{})
+	assert.Len(t, shared, 2)
+	assert.Equal(t, tracks[0].ID.String(), shared[0].(map[string]interface{})["id"])
+	metadata := result["metadata"].(map[string]interface{})
+	assert.Equal(t, userID, metadata["created_by"])
+
+	// Exec : Get All, the token is never listed
+	status, result = sendJSON(t, http.MethodGet, server.URL+"/api/v1/shares/"+appSource+"/"+userID, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, result["data"], 1)
+	assert.Nil(t, result["data"].([]interface{})[0].(map[string]interface{})["token"])
+
+	// Exec : Revoke, Then Read
+	status, _ = sendJSON(t, http.MethodDelete, server.URL+"/api/v1/shares/"+appSource+"/"+userID+"/"+shareID, nil)
+	assert.Equal(t, http.StatusOK, status)
+	status, result = sendJSON(t, http.MethodGet, server.URL+"/api/v1/shared/"+token, nil)
+	assert.Equal(t, http.StatusGone, status)
+	assert.Equal(t, "Share has expired or was revoked", result["message"])
+}
+
+func TestSuccessShareLinkOnlySharedType(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data : Live & shared points of the user in the same window, the live one newest
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	shared := seedShareTracks(t, trackRepo, appSource, userID, 2)
+	seedTrackBatch(t, trackRepo, appSource, userID, 3)
+
+	// Exec : Create
+	status, result := sendJSON(t, http.MethodPost, server.URL+"/api/v1/shares", map[string]interface{}{
+		"app_source": appSource,
+		"created_by": userID,
+	})
+	assert.Equal(t, http.StatusCreated, status)
+	token := result["data"].(map[string]interface{})["token"].(string)
+
+	// Exec : Public Read
+	status, result = sendJSON(t, http.MethodGet, server.URL+"/api/v1/shared/"+token, nil)
+	assert.Equal(t, http.StatusOK, status)
+
+	// Check Data : Only the share-loc points
+	data := result["data"].([]interface{})
+	assert.Len(t, data, 2)
+	for i, item := range data {
+		assert.Equal(t, shared[i].ID.String(), item.(map[string]interface{})["id"])
+		assert.Equal(t, "share-loc", item.(map[string]interface{})["track_type"])
+	}
+}
+
+// Negative - Test Case
+func TestFailedShareLinkWithForgedToken(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	status, result := sendJSON(t, http.MethodPost, server.URL+"/api/v1/shares", map[string]interface{}{
+		"app_source": appSource,
+		"created_by": userID,
+	})
+	assert.Equal(t, http.StatusCreated, status)
+	token := result["data"].(map[string]interface{})["token"].(string)
+
+	// Exec : Tampered signature & garbage
+	parts := strings.SplitN(token, ".", 2)
+	for _, forged := range []string{parts[0] + ".AAAA", "not-a-token"} {
+		status, result = sendJSON(t, http.MethodGet, server.URL+"/api/v1/shared/"+forged, nil)
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.Equal(t, "Share not found", result["message"])
+	}
+}
+
+func TestFailedPostCreateShareWithInvalidExpiry(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Exec
+	status, result := sendJSON(t, http.MethodPost, server.URL+"/api/v1/shares", map[string]interface{}{
+		"app_source": "pinmarker",
+		"created_by": "fcd3f23e-e5aa-11ee-892a-3216422910e9",
+		"expires_in": "30d",
+	})
+
+	// Template Response
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "failed", result["status"])
+	assert.Equal(t, "expires in must be a duration between 1m and 168h", result["message"])
+}
@@ -0,0 +1,141 @@
+package e2e
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"pinmarker/entities"
+	"pinmarker/repositories"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// seedRoute stores an L shaped route, east along the equator and then north, one point a minute
+func seedRoute(t *testing.T, trackRepo repositories.TrackRepository, appSource, userID string) []*entities.Track {
+	t.Helper()
+
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	tracks := make([]*entities.Track, 0)
+	for i := 0; i < 10; i++ {
+		lat, long := entities.Coordinate(0), entities.Coordinate(0.001*float64(i))
+		if i > 5 {
+			lat, long = entities.Coordinate(0.001*float64(i-5)), 0.005
+		}
+		tracks = append(tracks, &entities.Track{
+			BatteryIndicator: 80,
+			TrackLat:         lat,
+			TrackLong:        long,
+			TrackType:        "live",
+			AppsSource:       appSource,
+			CreatedBy:        uuid.MustParse(userID),
+			CreatedAt:        start.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	if err := trackRepo.CreateBatch(tracks); err != nil {
+		t.Fatalf("failed to seed route: %v", err)
+	}
+
+	return tracks
+}
+
+// Positive - Test Case
+func TestSuccessGetAllTrackWithSimplify(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	tracks := seedRoute(t, trackRepo, appSource, userID)
+
+	// Exec
+	url := server.URL + "/api/v1/tracks/" + appSource + "/" + userID + "?sort=asc&simplify=5"
+	resp, err := http.Get(url)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Prepare Response Test
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var result map[string]interface{}
+	err = json.Unmarshal(body, &result)
+	assert.NoError(t, err)
+
+	// Template Response
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "success", result["status"])
+
+	// Check Data : The start, the corner and the end, untouched
+	data, ok := result["data"].([]interface{})
+	assert.True(t, ok, "data should be a JSON array")
+	assert.Len(t, data, 3)
+	for i, index := range []int{0, 5, 9} {
+		track := data[i].(map[string]interface{})
+		assert.Equal(t, tracks[index].ID.String(), track["id"])
+		recordedAt, err := time.Parse(time.RFC3339Nano, track["recorded_at"].(string))
+		assert.NoError(t, err)
+		assert.True(t, tracks[index].RecordedAt.Equal(recordedAt))
+	}
+
+	// Check Metadata : Total still counts every track
+	metadata := result["metadata"].(map[string]interface{})
+	assert.Equal(t, float64(10), metadata["total"])
+}
+
+func TestSuccessExportTrackWithMaxPoints(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	tracks := seedRoute(t, trackRepo, appSource, userID)
+
+	// Exec
+	url := server.URL + "/api/v1/tracks/" + appSource + "/" + userID + "/export?format=csv&max_points=3"
+	resp, err := http.Get(url)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Check Rows : Header, start, corner & end
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, rows, 4)
+	assert.Equal(t, tracks[0].ID.String(), rows[1][0])
+	assert.Equal(t, tracks[5].ID.String(), rows[2][0])
+	assert.Equal(t, tracks[9].ID.String(), rows[3][0])
+}
+
+// Negative - Test Case
+func TestFailedGetAllTrackWithInvalidSimplify(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Test Data
+	url := server.URL + "/api/v1/tracks/pinmarker/fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	cases := map[string]string{
+		"simplify must be a number between 0 and 10000": "?simplify=-3",
+		"max_points must be between 2 and 100000":       "?max_points=1",
+	}
+
+	for message, query := range cases {
+		// Exec
+		resp, err := http.Get(url + query)
+		assert.NoError(t, err)
+
+		// Prepare Response Test
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.NoError(t, err)
+		var result map[string]interface{}
+		err = json.Unmarshal(body, &result)
+		assert.NoError(t, err)
+
+		// Template Response
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, "failed", result["status"])
+		assert.Equal(t, message, result["message"])
+	}
+}
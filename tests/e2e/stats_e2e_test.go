@@ -0,0 +1,102 @@
+package e2e
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"pinmarker/entities"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Positive - Test Case
+func TestSuccessGetTrackStatsPerDay(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data : A walk on one day, a single point on the next
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	day := time.Now().UTC().AddDate(0, 0, -2).Truncate(24 * time.Hour).Add(10 * time.Hour)
+	point := func(at time.Time, lat entities.Coordinate, battery int) *entities.Track {
+		return &entities.Track{
+			BatteryIndicator: battery,
+			TrackLat:         lat,
+			TrackLong:        106.8,
+			TrackType:        "live",
+			AppsSource:       appSource,
+			CreatedBy:        uuid.MustParse(userID),
+			CreatedAt:        at,
+		}
+	}
+	tracks := []*entities.Track{
+		point(day, -6.2000, 90),
+		point(day.Add(time.Minute), -6.2009, 85),
+		point(day.Add(2*time.Minute), -6.2018, 80),
+		point(day.Add(2*time.Minute+30*time.Second), -6.2018, 79),
+		point(day.Add(24*time.Hour), -6.2018, 100),
+	}
+	assert.NoError(t, trackRepo.CreateBatch(tracks))
+
+	// Exec
+	url := server.URL + "/api/v1/tracks/" + appSource + "/" + userID + "/stats?tz=UTC"
+	resp, err := http.Get(url)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Prepare Response Test
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var result map[string]interface{}
+	err = json.Unmarshal(body, &result)
+	assert.NoError(t, err)
+
+	// Template Response
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "success", result["status"])
+
+	// Check Day Totals : The standing still point adds no moving time
+	data, ok := result["data"].([]interface{})
+	assert.True(t, ok, "data should be a JSON array")
+	assert.Len(t, data, 2)
+	first := data[0].(map[string]interface{})
+	assert.Equal(t, day.Format("2006-01-02"), first["date"])
+	assert.Equal(t, float64(4), first["point_count"])
+	assert.InDelta(t, 200, first["distance"], 1)
+	assert.Equal(t, float64(120), first["moving_time"])
+	assert.InDelta(t, 1.67, first["avg_speed"], 0.01)
+	assert.InDelta(t, 1.67, first["max_speed"], 0.01)
+	assert.Equal(t, float64(79), first["battery_min"])
+	assert.Equal(t, float64(90), first["battery_max"])
+	second := data[1].(map[string]interface{})
+	assert.Equal(t, float64(1), second["point_count"])
+	assert.Equal(t, float64(0), second["distance"])
+
+	metadata := result["metadata"].(map[string]interface{})
+	assert.Equal(t, "UTC", metadata["tz"])
+}
+
+// Negative - Test Case
+func TestFailedGetTrackStatsWithInvalidTimeZone(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Exec
+	url := server.URL + "/api/v1/tracks/pinmarker/fcd3f23e-e5aa-11ee-892a-3216422910e9/stats?tz=Mars/Olympus"
+	resp, err := http.Get(url)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Prepare Response Test
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var result map[string]interface{}
+	err = json.Unmarshal(body, &result)
+	assert.NoError(t, err)
+
+	// Template Response
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "failed", result["status"])
+	assert.Equal(t, "tz must be a valid IANA time zone", result["message"])
+}
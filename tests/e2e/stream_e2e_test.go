@@ -0,0 +1,144 @@
+package e2e
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"pinmarker/repositories"
+	"pinmarker/services"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// readEvent reads the next Server-Sent Event, returning its name and data
+func readEvent(t *testing.T, reader *bufio.Reader) (string, string) {
+	t.Helper()
+
+	var name, data string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("stream ended: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case line == "" && name != "":
+			return name, data
+		case strings.HasPrefix(line, "event:"):
+			name = strings.TrimPrefix(line, "event:")
+		case strings.HasPrefix(line, "data:"):
+			data = strings.TrimPrefix(line, "data:")
+		}
+	}
+}
+
+// Positive - Test Case
+func TestSuccessStreamTrackWithHeartbeat(t *testing.T) {
+	heartbeat := configs.TrackStreamHeartbeat
+	configs.TrackStreamHeartbeat = 50 * time.Millisecond
+	t.Cleanup(func() { configs.TrackStreamHeartbeat = heartbeat })
+	server, _ := setUpServer(t)
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+
+	// Exec : Subscribe
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/v1/tracks/"+appSource+"/"+userID+"/stream", nil)
+	assert.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	reader := bufio.NewReader(resp.Body)
+
+	// Check Heartbeat : An idle stream is kept alive
+	name, _ := readEvent(t, reader)
+	assert.Equal(t, "heartbeat", name)
+
+	// Exec : Another user's point is not pushed, the subscribed user's one is
+	for _, createdBy := range []string{"0b4b8d0e-5c3a-4d8e-9a3e-2f3a1c6b7d10", userID} {
+		status, _ := sendJSON(t, http.MethodPost, server.URL+"/api/v1/tracks", map[string]interface{}{
+			"battery_indicator": 80,
+			"track_lat":         -6.228755,
+			"track_long":        106.820035,
+			"track_type":        "live",
+			"app_source":        appSource,
+			"created_by":        createdBy,
+		})
+		assert.Equal(t, http.StatusCreated, status)
+	}
+
+	// Check Track
+	for {
+		name, data := readEvent(t, reader)
+		if name == "heartbeat" {
+			continue
+		}
+		assert.Equal(t, "track", name)
+		var track entities.Track
+		assert.NoError(t, json.Unmarshal([]byte(data), &track))
+		assert.Equal(t, userID, track.CreatedBy.String())
+		break
+	}
+}
+
+func TestSuccessTrackHubSkipsReplayedTrack(t *testing.T) {
+	hub := services.NewTrackHub()
+	trackService := services.NewTrackService(repositories.NewTrackMemoryRepository(), nil, hub)
+	createdBy := uuid.MustParse("fcd3f23e-e5aa-11ee-892a-3216422910e9")
+	subscription := hub.Subscribe("pinmarker", createdBy)
+	defer hub.Unsubscribe(subscription)
+	newTrack := func(id uuid.UUID) *entities.Track {
+		return &entities.Track{ID: id, BatteryIndicator: 80, TrackLat: -6.2, TrackLong: 106.8, TrackType: "live", AppsSource: "pinmarker", CreatedBy: createdBy}
+	}
+
+	// Exec : The same client id twice, alone and in a batch with a new one
+	id := uuid.New()
+	assert.NoError(t, trackService.CreateTrack(newTrack(id)))
+	assert.NoError(t, trackService.CreateTrack(newTrack(id)))
+	fresh := newTrack(uuid.New())
+	assert.NoError(t, trackService.CreateTrackMulti([]*entities.Track{newTrack(id), fresh}))
+
+	// Check : Each stored track is pushed once
+	assert.Len(t, subscription.Tracks, 2)
+	assert.Equal(t, id, (<-subscription.Tracks).ID)
+	assert.Equal(t, fresh.ID, (<-subscription.Tracks).ID)
+}
+
+// Negative - Test Case
+func TestFailedTrackHubDropsSlowSubscriber(t *testing.T) {
+	hub := services.NewTrackHub()
+	createdBy := uuid.MustParse("fcd3f23e-e5aa-11ee-892a-3216422910e9")
+	slow := hub.Subscribe("pinmarker", createdBy)
+	reader := hub.Subscribe("pinmarker", createdBy)
+
+	// Exec : One point more than the buffer holds, the reader keeps up
+	for i := 0; i <= configs.TrackStreamBuffer; i++ {
+		hub.TrackCreated([]*entities.Track{{ID: uuid.New(), AppsSource: "pinmarker", CreatedBy: createdBy}})
+		<-reader.Tracks
+	}
+
+	// Check : The slow subscriber gets its buffer and then a closed channel
+	received := 0
+	for range slow.Tracks {
+		received++
+	}
+	assert.Equal(t, configs.TrackStreamBuffer, received)
+
+	// Check : Unsubscribing twice is harmless
+	hub.Unsubscribe(slow)
+	hub.Unsubscribe(reader)
+	_, open := <-reader.Tracks
+	assert.False(t, open)
+}
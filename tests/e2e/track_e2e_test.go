@@ -3,20 +3,29 @@ package e2e
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"net/http"
+	neturl "net/url"
+	"pinmarker/entities"
+	"pinmarker/repositories"
+	"pinmarker/utils"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
 // Positive - Test Case
 func TestSuccessPostCreateTrackWithValidInput(t *testing.T) {
+	server, _ := setUpServer(t)
+
 	// Test Data
 	payload := map[string]interface{}{
 		"battery_indicator": 80,
-		"track_lat":         "-6.228755",
-		"track_long":        "106.820035",
+		"track_lat":         -6.228755,
+		"track_long":        106.820035,
 		"track_type":        "live",
 		"app_source":        "pinmarker",
 		"created_by":        "fcd3f23e-e5aa-11ee-892a-3216422910e9",
@@ -25,7 +34,7 @@ func TestSuccessPostCreateTrackWithValidInput(t *testing.T) {
 	jsonPayload, _ := json.Marshal(payload)
 
 	// Exec
-	url := "http://127.0.0.1:9000/api/v1/tracks"
+	url := server.URL + "/api/v1/tracks"
 	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonPayload))
 	assert.NoError(t, err)
 
@@ -62,20 +71,253 @@ func TestSuccessPostCreateTrackWithValidInput(t *testing.T) {
 	// Check Data Types
 	assert.IsType(t, "", data["id"])
 	assert.IsType(t, float64(0), data["battery_indicator"])
-	assert.IsType(t, "", data["track_lat"])
-	assert.IsType(t, "", data["track_long"])
+	assert.IsType(t, float64(0), data["track_lat"])
+	assert.IsType(t, float64(0), data["track_long"])
 	assert.IsType(t, "", data["track_type"])
 	assert.IsType(t, "", data["app_source"])
 	assert.IsType(t, "", data["created_by"])
 }
 
+func TestSuccessPostCreateTrackWithLegacyStringCoordinate(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Test Data
+	payload := map[string]interface{}{
+		"battery_indicator": 80,
+		"track_lat":         "-6.228755",
+		"track_long":        "106.820035",
+		"track_type":        "live",
+		"app_source":        "pinmarker",
+		"created_by":        "fcd3f23e-e5aa-11ee-892a-3216422910e9",
+	}
+
+	jsonPayload, _ := json.Marshal(payload)
+
+	// Exec
+	url := server.URL + "/api/v1/tracks"
+	resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonPayload))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Prepare Response Test
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var result map[string]interface{}
+	err = json.Unmarshal(body, &result)
+	assert.NoError(t, err)
+
+	// Template Response
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "success", result["status"])
+
+	// Check Data Fields : Stored As Number
+	data, ok := result["data"].(map[string]interface{})
+	assert.True(t, ok, "data should be a JSON object")
+	assert.Equal(t, -6.228755, data["track_lat"])
+	assert.Equal(t, 106.820035, data["track_long"])
+}
+
+func TestSuccessPostCreateTrackWithRecordedAt(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Test Data
+	recordedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	payload := map[string]interface{}{
+		"battery_indicator": 80,
+		"track_lat":         -6.228755,
+		"track_long":        106.820035,
+		"track_type":        "live",
+		"app_source":        "pinmarker",
+		"recorded_at":       recordedAt.Format(time.RFC3339Nano),
+		"created_by":        "fcd3f23e-e5aa-11ee-892a-3216422910e9",
+	}
+
+	jsonPayload, _ := json.Marshal(payload)
+
+	// Exec
+	url := server.URL + "/api/v1/tracks"
+	resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonPayload))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Prepare Response Test
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var result map[string]interface{}
+	err = json.Unmarshal(body, &result)
+	assert.NoError(t, err)
+
+	// Template Response
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "success", result["status"])
+
+	// Check Data Fields : Device Time Kept, Server Time Added
+	data, ok := result["data"].(map[string]interface{})
+	assert.True(t, ok, "data should be a JSON object")
+	storedRecordedAt, err := time.Parse(time.RFC3339Nano, data["recorded_at"].(string))
+	assert.NoError(t, err)
+	assert.True(t, recordedAt.Equal(storedRecordedAt))
+	storedCreatedAt, err := time.Parse(time.RFC3339Nano, data["created_at"].(string))
+	assert.NoError(t, err)
+	assert.True(t, recordedAt.Equal(storedCreatedAt))
+	receivedAt, err := time.Parse(time.RFC3339Nano, data["received_at"].(string))
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), receivedAt, time.Minute)
+}
+
+func TestSuccessPostCreateTrackMultiWithPartialResult(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	item := func(lat interface{}, trackType string) map[string]interface{} {
+		return map[string]interface{}{
+			"battery_indicator": 80,
+			"track_lat":         lat,
+			"track_long":        106.820035,
+			"track_type":        trackType,
+			"app_source":        "pinmarker",
+			"created_at":        time.Now().Format(time.RFC3339Nano),
+			"created_by":        userID,
+		}
+	}
+	payload := []map[string]interface{}{
+		item(-6.228755, "live"),
+		item(-6.228755, "live-loc"),
+		item(-6.228756, "share-loc"),
+		item(120, "live"),
+	}
+
+	jsonPayload, _ := json.Marshal(payload)
+
+	// Exec
+	url := server.URL + "/api/v1/tracks/multi?partial=true"
+	resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonPayload))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Prepare Response Test
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var result map[string]interface{}
+	err = json.Unmarshal(body, &result)
+	assert.NoError(t, err)
+
+	// Template Response
+	assert.Equal(t, http.StatusMultiStatus, resp.StatusCode)
+	assert.Equal(t, "success", result["status"])
+
+	// Validate : Result Per Index
+	dataArray, ok := result["data"].([]interface{})
+	assert.True(t, ok, "data should be an array")
+	assert.Len(t, dataArray, 4)
+	expected := []string{"created", "failed", "created", "failed"}
+	for i, raw := range dataArray {
+		item := raw.(map[string]interface{})
+		assert.Equal(t, float64(i), item["index"])
+		assert.Equal(t, expected[i], item["status"])
+		if expected[i] == "created" {
+			assert.NotEmpty(t, item["id"])
+		} else {
+			assert.NotEmpty(t, item["error"])
+		}
+	}
+	assert.Equal(t, "track type is not valid", dataArray[1].(map[string]interface{})["error"])
+
+	meta, ok := result["metadata"].(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, float64(4), meta["total"])
+	assert.Equal(t, float64(2), meta["created"])
+	assert.Equal(t, float64(2), meta["failed"])
+
+	// Validate : Only The Valid Items Are Stored
+	_, total, err := trackRepo.FindAll(utils.Pagination{Page: 1, Limit: 10}, "pinmarker", uuid.MustParse(userID))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+}
+
+func TestSuccessPostCreateTrackWithIdempotencyKeyReplay(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	payload := map[string]interface{}{
+		"battery_indicator": 80,
+		"track_lat":         -6.228755,
+		"track_long":        106.820035,
+		"track_type":        "live",
+		"app_source":        "pinmarker",
+		"created_by":        userID,
+	}
+	items := []map[string]interface{}{payload, payload}
+	for i := range items {
+		item := map[string]interface{}{"created_at": time.Now().Add(-time.Duration(i) * time.Minute).Format(time.RFC3339Nano)}
+		for k, v := range payload {
+			item[k] = v
+		}
+		items[i] = item
+	}
+
+	post := func(path string, body interface{}) (int, map[string]interface{}) {
+		jsonPayload, _ := json.Marshal(body)
+		req, err := http.NewRequest("POST", server.URL+path, bytes.NewBuffer(jsonPayload))
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "sync-2025-06-23-001")
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		raw, err := ioutil.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		var result map[string]interface{}
+		assert.NoError(t, json.Unmarshal(raw, &result))
+		return resp.StatusCode, result
+	}
+
+	// Exec : Single, Sent Twice
+	statusCode, first := post("/api/v1/tracks", payload)
+	assert.Equal(t, http.StatusCreated, statusCode)
+	statusCode, second := post("/api/v1/tracks", payload)
+	assert.Equal(t, http.StatusCreated, statusCode)
+	firstData := first["data"].(map[string]interface{})
+	secondData := second["data"].(map[string]interface{})
+	assert.Equal(t, firstData["id"], secondData["id"])
+	assert.Equal(t, firstData["created_at"], secondData["created_at"])
+
+	// Exec : Multi, Sent Twice
+	statusCode, first = post("/api/v1/tracks/multi", items)
+	assert.Equal(t, http.StatusCreated, statusCode)
+	statusCode, second = post("/api/v1/tracks/multi", items)
+	assert.Equal(t, http.StatusCreated, statusCode)
+	for i := range items {
+		assert.Equal(t, first["data"].([]interface{})[i].(map[string]interface{})["id"], second["data"].([]interface{})[i].(map[string]interface{})["id"])
+	}
+
+	// Validate : No Duplicate Stored
+	_, total, err := trackRepo.FindAll(utils.Pagination{Page: 1, Limit: 10}, "pinmarker", uuid.MustParse(userID))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+
+	// Exec : Same Key, Different Point
+	payload["track_lat"] = -6.3
+	statusCode, result := post("/api/v1/tracks", payload)
+	assert.Equal(t, http.StatusConflict, statusCode)
+	assert.Equal(t, "failed", result["status"])
+	assert.Equal(t, "track id was already used for a different track", result["message"])
+}
+
 func TestSuccessGetAllTrackWithValidOuput(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
 	// Test Data
 	appSource := "pinmarker"
 	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	seedTrack(t, trackRepo, appSource, userID)
 
 	// Exec
-	url := "http://127.0.0.1:9000/api/v1/tracks/" + appSource + "/" + userID
+	url := server.URL + "/api/v1/tracks/" + appSource + "/" + userID
 	req, err := http.NewRequest("GET", url, nil)
 	assert.NoError(t, err)
 
@@ -112,10 +354,10 @@ func TestSuccessGetAllTrackWithValidOuput(t *testing.T) {
 		assert.IsType(t, float64(0), track["battery_indicator"])
 
 		assert.NotEmpty(t, track["track_lat"])
-		assert.IsType(t, "", track["track_lat"])
+		assert.IsType(t, float64(0), track["track_lat"])
 
 		assert.NotEmpty(t, track["track_long"])
-		assert.IsType(t, "", track["track_long"])
+		assert.IsType(t, float64(0), track["track_long"])
 
 		assert.NotEmpty(t, track["track_type"])
 		assert.IsType(t, "", track["track_type"])
@@ -141,13 +383,15 @@ func TestSuccessGetAllTrackWithValidOuput(t *testing.T) {
 }
 
 func TestSuccessDeleteTrackWithValidID(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
 	// Test Data
-	id := "4dfabee1-e620-4b78-ab3a-93d71e51103c"
 	appSource := "pinmarker"
 	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	id := seedTrack(t, trackRepo, appSource, userID).ID.String()
 
 	// Exec
-	url := "http://127.0.0.1:9000/api/v1/tracks/" + appSource + "/" + userID + "/" + id
+	url := server.URL + "/api/v1/tracks/" + appSource + "/" + userID + "/" + id
 	req, err := http.NewRequest("DELETE", url, nil)
 	assert.NoError(t, err)
 
@@ -169,8 +413,179 @@ func TestSuccessDeleteTrackWithValidID(t *testing.T) {
 	assert.Equal(t, "Track deleted", result["message"])
 }
 
+func TestSuccessGetAllTrackWithCursorPagination(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	seedTrackBatch(t, trackRepo, appSource, userID, 5)
+
+	// Exec : Walk Forward
+	ids := make([]interface{}, 0)
+	cursor := ""
+	for page := 0; page < 3; page++ {
+		url := server.URL + "/api/v1/tracks/" + appSource + "/" + userID + "?limit=2&cursor=" + cursor
+		resp, err := http.Get(url)
+		assert.NoError(t, err)
+
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.NoError(t, err)
+		var result map[string]interface{}
+		err = json.Unmarshal(body, &result)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		dataArray, ok := result["data"].([]interface{})
+		assert.True(t, ok, "data should be an array")
+		for _, item := range dataArray {
+			ids = append(ids, item.(map[string]interface{})["id"])
+		}
+
+		meta, ok := result["metadata"].(map[string]interface{})
+		assert.True(t, ok)
+		assert.Equal(t, float64(2), meta["limit"])
+		if page == 0 {
+			assert.Empty(t, meta["prev_cursor"])
+		} else {
+			assert.NotEmpty(t, meta["prev_cursor"])
+		}
+		cursor = meta["next_cursor"].(string)
+	}
+
+	// Validate : Every Track Once, Newest First
+	assert.Len(t, ids, 5)
+	assert.Empty(t, cursor)
+	tracks, _, err := trackRepo.FindAll(utils.Pagination{Page: 1, Limit: 5}, appSource, uuid.MustParse(userID))
+	assert.NoError(t, err)
+	for i, track := range tracks {
+		assert.Equal(t, track.ID.String(), ids[i])
+	}
+}
+
+func TestSuccessGetAllTrackWithFilter(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	tracks := seedTrackBatch(t, trackRepo, appSource, userID, 6)
+	from := tracks[4].CreatedAt.Format(time.RFC3339Nano)
+	to := tracks[1].CreatedAt.Format(time.RFC3339Nano)
+
+	// Exec
+	query := neturl.Values{}
+	query.Set("from", from)
+	query.Set("to", to)
+	query.Set("track_type", "live")
+	query.Set("battery_max", "78")
+	query.Set("sort", "asc")
+	url := server.URL + "/api/v1/tracks/" + appSource + "/" + userID + "?" + query.Encode()
+	resp, err := http.Get(url)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Prepare Response Test
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var result map[string]interface{}
+	err = json.Unmarshal(body, &result)
+	assert.NoError(t, err)
+
+	// Template Response
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "success", result["status"])
+
+	// Validate : Oldest First Within Range
+	dataArray, ok := result["data"].([]interface{})
+	assert.True(t, ok, "data should be an array")
+	assert.Len(t, dataArray, 3)
+	for i, item := range dataArray {
+		assert.Equal(t, tracks[4-i].ID.String(), item.(map[string]interface{})["id"])
+	}
+	meta, ok := result["metadata"].(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, float64(3), meta["total"])
+}
+
+func TestSuccessGetTrackWithinAreaAndNearby(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data : Monas, Bundaran HI and Bandung
+	appSource := "pinmarker"
+	userID := uuid.MustParse("fcd3f23e-e5aa-11ee-892a-3216422910e9")
+	tracks := []*entities.Track{
+		{BatteryIndicator: 80, TrackLat: -6.175392, TrackLong: 106.827153, TrackType: "live", AppsSource: appSource, CreatedBy: userID, CreatedAt: time.Now()},
+		{BatteryIndicator: 80, TrackLat: -6.194946, TrackLong: 106.823060, TrackType: "live", AppsSource: appSource, CreatedBy: uuid.New(), CreatedAt: time.Now()},
+		{BatteryIndicator: 80, TrackLat: -6.917464, TrackLong: 107.619123, TrackType: "live", AppsSource: appSource, CreatedBy: userID, CreatedAt: time.Now()},
+	}
+	assert.NoError(t, trackRepo.CreateBatch(tracks))
+
+	// Exec : Area Around Central Jakarta
+	url := server.URL + "/api/v1/tracks/" + appSource + "/area?min_lat=-6.25&min_long=106.78&max_lat=-6.15&max_long=106.86"
+	resp, err := http.Get(url)
+	assert.NoError(t, err)
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.NoError(t, err)
+	var result map[string]interface{}
+	assert.NoError(t, json.Unmarshal(body, &result))
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Track fetched", result["message"])
+	dataArray, ok := result["data"].([]interface{})
+	assert.True(t, ok, "data should be an array")
+	assert.Len(t, dataArray, 2)
+
+	// Exec : 1 KM Around Monas For One User
+	url = server.URL + "/api/v1/tracks/" + appSource + "/nearby?lat=-6.175392&long=106.827153&radius=1000&created_by=" + userID.String()
+	resp, err = http.Get(url)
+	assert.NoError(t, err)
+	body, err = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.NoError(t, err)
+	result = map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(body, &result))
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	dataArray, ok = result["data"].([]interface{})
+	assert.True(t, ok, "data should be an array")
+	assert.Len(t, dataArray, 1)
+	nearby := dataArray[0].(map[string]interface{})
+	assert.Equal(t, tracks[0].ID.String(), nearby["id"])
+	assert.Equal(t, float64(0), nearby["distance"])
+}
+
+func TestSuccessGetTrackWithinAreaAcrossAntimeridian(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data : Fiji both sides of the antimeridian, and Jakarta
+	appSource := "pinmarker"
+	userID := uuid.MustParse("fcd3f23e-e5aa-11ee-892a-3216422910e9")
+	tracks := []*entities.Track{
+		{BatteryIndicator: 80, TrackLat: -16.8, TrackLong: 179.9, TrackType: "live", AppsSource: appSource, CreatedBy: userID, CreatedAt: time.Now()},
+		{BatteryIndicator: 80, TrackLat: -16.8, TrackLong: -179.9, TrackType: "live", AppsSource: appSource, CreatedBy: userID, CreatedAt: time.Now()},
+		{BatteryIndicator: 80, TrackLat: -6.175392, TrackLong: 106.827153, TrackType: "live", AppsSource: appSource, CreatedBy: userID, CreatedAt: time.Now()},
+	}
+	assert.NoError(t, trackRepo.CreateBatch(tracks))
+
+	// Exec : West edge east of the east edge
+	status, result := sendJSON(t, http.MethodGet, server.URL+"/api/v1/tracks/"+appSource+"/area?min_lat=-17.5&min_long=179.5&max_lat=-16&max_long=-179.5", nil)
+
+	// Template Response
+	assert.Equal(t, http.StatusOK, status)
+	dataArray, ok := result["data"].([]interface{})
+	assert.True(t, ok, "data should be an array")
+	assert.Len(t, dataArray, 2)
+	ids := []string{dataArray[0].(map[string]interface{})["id"].(string), dataArray[1].(map[string]interface{})["id"].(string)}
+	assert.ElementsMatch(t, []string{tracks[0].ID.String(), tracks[1].ID.String()}, ids)
+}
+
 // Negative - Test Case
 func TestFailedPostCreateTrackWithInvalidInput(t *testing.T) {
+	server, _ := setUpServer(t)
+
 	// Test Data
 	payload := map[string]interface{}{
 		"battery_indicator": 80,
@@ -184,7 +599,7 @@ func TestFailedPostCreateTrackWithInvalidInput(t *testing.T) {
 	jsonPayload, _ := json.Marshal(payload)
 
 	// Exec
-	url := "http://127.0.0.1:9000/api/v1/tracks"
+	url := server.URL + "/api/v1/tracks"
 	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonPayload))
 	assert.NoError(t, err)
 
@@ -207,13 +622,16 @@ func TestFailedPostCreateTrackWithInvalidInput(t *testing.T) {
 }
 
 func TestFailedDeleteTrackWithInvalidID(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
 	// Test Data
 	id := "4dfabee1-e620-4b78-ab3a-93d71e514444"
 	appSource := "pinmarker"
 	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	seedTrack(t, trackRepo, appSource, userID)
 
 	// Exec
-	url := "http://127.0.0.1:9000/api/v1/tracks/" + appSource + "/" + userID + "/" + id
+	url := server.URL + "/api/v1/tracks/" + appSource + "/" + userID + "/" + id
 	req, err := http.NewRequest("DELETE", url, nil)
 	assert.NoError(t, err)
 
@@ -234,3 +652,317 @@ func TestFailedDeleteTrackWithInvalidID(t *testing.T) {
 	assert.Equal(t, "failed", result["status"])
 	assert.Equal(t, "Track not found", result["message"])
 }
+
+func TestFailedGetAllTrackWithInvalidCursor(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+
+	// Exec
+	url := server.URL + "/api/v1/tracks/" + appSource + "/" + userID + "?cursor=not-a-cursor"
+	resp, err := http.Get(url)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Prepare Response Test
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var result map[string]interface{}
+	err = json.Unmarshal(body, &result)
+	assert.NoError(t, err)
+
+	// Template Response
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "failed", result["status"])
+	assert.Equal(t, "cursor is not valid", result["message"])
+}
+
+func TestFailedGetAllTrackWithInvalidFilter(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+
+	// Exec
+	url := server.URL + "/api/v1/tracks/" + appSource + "/" + userID + "?from=yesterday"
+	resp, err := http.Get(url)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Prepare Response Test
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var result map[string]interface{}
+	err = json.Unmarshal(body, &result)
+	assert.NoError(t, err)
+
+	// Template Response
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "failed", result["status"])
+	assert.Equal(t, "from must be a RFC3339 timestamp", result["message"])
+}
+
+func TestFailedGetTrackNearbyWithInvalidRadius(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Exec
+	url := server.URL + "/api/v1/tracks/pinmarker/nearby?lat=-6.175392&long=106.827153&radius=900000"
+	resp, err := http.Get(url)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Prepare Response Test
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var result map[string]interface{}
+	err = json.Unmarshal(body, &result)
+	assert.NoError(t, err)
+
+	// Template Response
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "failed", result["status"])
+	assert.Equal(t, "radius must be a number between 1 and 50000", result["message"])
+}
+
+func TestFailedPostCreateTrackWithInvalidCoordinate(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Test Data
+	cases := map[string]map[string]interface{}{
+		"track latitude must be between -90 and 90":    {"track_lat": 999, "track_long": 106.820035, "battery_indicator": 80},
+		"track longitude must be between -180 and 180": {"track_lat": -6.228755, "track_long": "-181", "battery_indicator": 80},
+		"battery indicator must be between 0 and 100":  {"track_lat": -6.228755, "track_long": 106.820035, "battery_indicator": 101},
+		"coordinate must be a number":                  {"track_lat": "abc", "track_long": 106.820035, "battery_indicator": 80},
+		"track latitude is required":                   {"track_long": 106.820035, "battery_indicator": 80},
+	}
+
+	for message, payload := range cases {
+		payload["track_type"] = "live"
+		payload["app_source"] = "pinmarker"
+		payload["created_by"] = "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+		jsonPayload, _ := json.Marshal(payload)
+
+		// Exec
+		url := server.URL + "/api/v1/tracks"
+		resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonPayload))
+		assert.NoError(t, err)
+
+		// Prepare Response Test
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.NoError(t, err)
+		var result map[string]interface{}
+		err = json.Unmarshal(body, &result)
+		assert.NoError(t, err)
+
+		// Template Response
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, "failed", result["status"])
+		assert.Equal(t, message, result["message"])
+	}
+}
+
+func TestFailedTrackWithNotFiniteCoordinate(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Test Data : NaN passes every range check, Inf is parsed before it
+	cases := []map[string]interface{}{
+		{"track_lat": "NaN", "track_long": 106.820035},
+		{"track_lat": -6.228755, "track_long": "nan"},
+		{"track_lat": "Infinity", "track_long": 106.820035},
+		{"track_lat": -6.228755, "track_long": "-Inf"},
+	}
+
+	for _, payload := range cases {
+		payload["battery_indicator"] = 80
+		payload["track_type"] = "live"
+		payload["app_source"] = "pinmarker"
+		payload["created_by"] = "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+
+		// Exec
+		status, result := sendJSON(t, http.MethodPost, server.URL+"/api/v1/tracks", payload)
+
+		// Template Response
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, "coordinate must be a number", result["message"])
+	}
+
+	// Exec : Query coordinate
+	status, result := sendJSON(t, http.MethodGet, server.URL+"/api/v1/tracks/pinmarker/nearby?lat=NaN&long=106.827153&radius=1000", nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "lat must be a number between -90 and 90", result["message"])
+}
+
+func TestFailedPostCreateTrackWithRecordedAtOutOfTolerance(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Test Data
+	cases := map[string]time.Time{
+		"recorded at is too far in the future": time.Now().Add(time.Hour),
+		"recorded at is too far in the past":   time.Now().AddDate(0, -1, 0),
+	}
+
+	for message, recordedAt := range cases {
+		payload := map[string]interface{}{
+			"battery_indicator": 80,
+			"track_lat":         -6.228755,
+			"track_long":        106.820035,
+			"track_type":        "live",
+			"app_source":        "pinmarker",
+			"recorded_at":       recordedAt.Format(time.RFC3339Nano),
+			"created_by":        "fcd3f23e-e5aa-11ee-892a-3216422910e9",
+		}
+		jsonPayload, _ := json.Marshal(payload)
+
+		// Exec
+		url := server.URL + "/api/v1/tracks"
+		resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonPayload))
+		assert.NoError(t, err)
+
+		// Prepare Response Test
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.NoError(t, err)
+		var result map[string]interface{}
+		err = json.Unmarshal(body, &result)
+		assert.NoError(t, err)
+
+		// Template Response
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, "failed", result["status"])
+		assert.Equal(t, message, result["message"])
+	}
+}
+
+func TestFailedTrackWithRepositoryError(t *testing.T) {
+	// Test Data
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	payload := map[string]interface{}{
+		"battery_indicator": 80,
+		"track_lat":         -6.228755,
+		"track_long":        106.820035,
+		"track_type":        "live",
+		"app_source":        "pinmarker",
+		"created_by":        userID,
+	}
+	jsonPayload, _ := json.Marshal(payload)
+
+	cases := []struct {
+		name       string
+		err        error
+		method     string
+		path       string
+		statusCode int
+		status     string
+	}{
+		{"summary empty", repositories.ErrTrackNotFound, "GET", "/api/v1/tracks/summary", http.StatusNotFound, "failed"},
+		{"create conflict", repositories.ErrTrackConflict, "POST", "/api/v1/tracks", http.StatusConflict, "failed"},
+		{"create invalid", &repositories.RepositoryError{Kind: repositories.ErrTrackInvalid, Message: "failed to convert track"}, "POST", "/api/v1/tracks", http.StatusUnprocessableEntity, "failed"},
+		{"list unavailable", &repositories.RepositoryError{Kind: repositories.ErrBackendUnavailable, Message: "failed to read from Firebase", Err: errors.New("timeout")}, "GET", "/api/v1/tracks/pinmarker/" + userID, http.StatusServiceUnavailable, "failed"},
+		{"list unexpected", errors.New("unexpected"), "GET", "/api/v1/tracks/pinmarker/" + userID, http.StatusInternalServerError, "error"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server, _ := setUpServerWithRepository(t, &failingTrackRepository{err: tc.err})
+
+			// Exec
+			req, err := http.NewRequest(tc.method, server.URL+tc.path, bytes.NewBuffer(jsonPayload))
+			assert.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			// Prepare Response Test
+			body, err := ioutil.ReadAll(resp.Body)
+			assert.NoError(t, err)
+			var result map[string]interface{}
+			err = json.Unmarshal(body, &result)
+			assert.NoError(t, err)
+
+			// Template Response
+			assert.Equal(t, tc.statusCode, resp.StatusCode)
+			assert.Equal(t, tc.status, result["status"])
+			assert.Equal(t, tc.err.Error(), result["message"])
+		})
+	}
+}
+
+func TestFailedPostCreateTrackMultiWithConflictWritesNothing(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	stored := seedTrack(t, trackRepo, appSource, userID)
+	conflict := trackMultiPayload(appSource, userID)
+	conflict["id"] = stored.ID.String()
+	conflict["track_lat"] = -6.3
+	payload := []map[string]interface{}{
+		trackMultiPayload(appSource, userID),
+		conflict,
+		trackMultiPayload(appSource, userID),
+	}
+
+	// Exec : Whole batch
+	status, result := sendJSON(t, http.MethodPost, server.URL+"/api/v1/tracks/multi", payload)
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "track id was already used for a different track at index 1", result["message"])
+
+	// Validate : Nothing Written, Nothing Charged
+	_, total, err := trackRepo.FindAll(utils.Pagination{Page: 1, Limit: 10}, appSource, uuid.MustParse(userID))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	status, result = sendJSON(t, http.MethodGet, server.URL+"/api/v1/tracks/"+appSource+"/"+userID+"/usage", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, float64(0), result["data"].(map[string]interface{})["used"])
+
+	// Exec : Partial mode writes the others only
+	status, result = sendJSON(t, http.MethodPost, server.URL+"/api/v1/tracks/multi?partial=true", payload)
+	assert.Equal(t, http.StatusMultiStatus, status)
+	data := result["data"].([]interface{})
+	assert.Equal(t, "created", data[0].(map[string]interface{})["status"])
+	assert.Equal(t, "failed", data[1].(map[string]interface{})["status"])
+	assert.Equal(t, "track id was already used for a different track", data[1].(map[string]interface{})["error"])
+	assert.Equal(t, "created", data[2].(map[string]interface{})["status"])
+
+	// Validate : The stored track is untouched
+	tracks, total, err := trackRepo.FindAll(utils.Pagination{Page: 1, Limit: 10}, appSource, uuid.MustParse(userID))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+	for _, track := range tracks {
+		if track.ID == stored.ID {
+			assert.Equal(t, stored.TrackLat, track.TrackLat)
+		}
+	}
+}
+
+func TestFailedGetAllTrackWithInvalidPagination(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Test Data
+	url := server.URL + "/api/v1/tracks/pinmarker/fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	cases := []struct {
+		query   string
+		message string
+	}{
+		{"?limit=501", "limit must be between 1 and 500"},
+		{"?limit=0", "limit must be between 1 and 500"},
+		{"?limit=ten", "limit must be between 1 and 500"},
+		{"?sort=newest", "sort must be asc or desc"},
+		{"/trash?limit=100000", "limit must be between 1 and 500"},
+	}
+
+	for _, tc := range cases {
+		// Exec
+		status, result := sendJSON(t, http.MethodGet, url+tc.query, nil)
+
+		// Template Response
+		assert.Equal(t, http.StatusBadRequest, status, tc.query)
+		assert.Equal(t, "failed", result["status"])
+		assert.Equal(t, tc.message, result["message"])
+	}
+}
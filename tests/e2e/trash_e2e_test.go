@@ -0,0 +1,112 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Positive - Test Case
+func TestSuccessDeleteAndRecoverTrack(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	tracks := seedTrackBatch(t, trackRepo, appSource, userID, 2)
+	url := server.URL + "/api/v1/tracks/" + appSource + "/" + userID
+
+	// Exec : Delete moves the track to the trash
+	status, result := sendJSON(t, http.MethodDelete, url+"/"+tracks[0].ID.String(), nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "Track deleted", result["message"])
+
+	status, result = sendJSON(t, http.MethodGet, url, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, result["data"], 1)
+
+	// Exec : Replaying the trashed id keeps it in the trash
+	payload := trackPayload(appSource, userID)
+	payload["id"] = tracks[0].ID.String()
+	payload["track_lat"], payload["track_long"] = tracks[0].TrackLat, tracks[0].TrackLong
+	status, _ = sendJSON(t, http.MethodPost, server.URL+"/api/v1/tracks", payload)
+	assert.Equal(t, http.StatusCreated, status)
+	status, result = sendJSON(t, http.MethodGet, url, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, result["data"], 1)
+
+	status, result = sendJSON(t, http.MethodGet, url+"/trash", nil)
+	assert.Equal(t, http.StatusOK, status)
+	data := result["data"].([]interface{})
+	assert.Len(t, data, 1)
+	trashed := data[0].(map[string]interface{})
+	assert.Equal(t, tracks[0].ID.String(), trashed["id"])
+	assert.NotEmpty(t, trashed["deleted_at"])
+	assert.Equal(t, float64(1), result["metadata"].(map[string]interface{})["total"])
+
+	// Exec : Recover puts it back and it is the latest again
+	status, result = sendJSON(t, http.MethodPut, url+"/"+tracks[0].ID.String()+"/recover", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "Track recovered", result["message"])
+	assert.Equal(t, tracks[0].ID.String(), result["data"].(map[string]interface{})["id"])
+
+	status, result = sendJSON(t, http.MethodGet, url, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, result["data"], 2)
+	status, result = sendJSON(t, http.MethodGet, url+"/latest", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, tracks[0].ID.String(), result["data"].(map[string]interface{})["id"])
+	status, result = sendJSON(t, http.MethodGet, url+"/trash", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, result["data"], 0)
+}
+
+func TestSuccessPurgeTrash(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	track := seedTrack(t, trackRepo, appSource, userID)
+	assert.NoError(t, trackRepo.DeleteByID(appSource, uuid.MustParse(userID), track.ID))
+
+	// Exec : Still inside the retention
+	purged, err := trackRepo.PurgeTrash(time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), purged)
+
+	// Exec : Past the retention
+	purged, err = trackRepo.PurgeTrash(time.Now().Add(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	url := server.URL + "/api/v1/tracks/" + appSource + "/" + userID
+	status, result := sendJSON(t, http.MethodGet, url+"/trash", nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, result["data"], 0)
+	status, _ = sendJSON(t, http.MethodPut, url+"/"+track.ID.String()+"/recover", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+// Negative - Test Case
+func TestFailedRecoverTrack(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data : A live track is not in the trash
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	track := seedTrack(t, trackRepo, appSource, userID)
+	url := server.URL + "/api/v1/tracks/" + appSource + "/" + userID
+
+	// Exec
+	status, result := sendJSON(t, http.MethodPut, url+"/"+track.ID.String()+"/recover", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "Track not found", result["message"])
+
+	status, result = sendJSON(t, http.MethodPut, url+"/not-uuid/recover", nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "track id is not valid", result["message"])
+}
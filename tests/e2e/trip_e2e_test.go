@@ -0,0 +1,112 @@
+package e2e
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"pinmarker/entities"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Positive - Test Case
+func TestSuccessGetTrackTripsWithGapAndJump(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data : Two trips split by a pause, a jump and a lone point, plus a shared location
+	appSource := "pinmarker"
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	start := time.Now().Add(-3 * time.Hour).Truncate(time.Second)
+	point := func(minute int, lat, long entities.Coordinate, trackType string) *entities.Track {
+		return &entities.Track{
+			BatteryIndicator: 80,
+			TrackLat:         lat,
+			TrackLong:        long,
+			TrackType:        trackType,
+			AppsSource:       appSource,
+			CreatedBy:        uuid.MustParse(userID),
+			CreatedAt:        start.Add(time.Duration(minute) * time.Minute),
+		}
+	}
+	tracks := []*entities.Track{
+		point(0, -6.2000, 106.8000, "live"),
+		point(1, -6.2009, 106.8000, "live"),
+		point(2, -6.2018, 106.8000, "live"),
+		point(3, -6.5000, 106.8000, "share-loc"),
+		point(40, -6.2100, 106.8100, "live"),
+		point(45, -6.2100, 106.8110, "live"),
+		point(46, -6.9000, 107.6000, "live"),
+	}
+	assert.NoError(t, trackRepo.CreateBatch(tracks))
+
+	// Exec
+	url := server.URL + "/api/v1/tracks/" + appSource + "/" + userID + "/trips?gap=10m&distance=1000"
+	resp, err := http.Get(url)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Prepare Response Test
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	var result map[string]interface{}
+	err = json.Unmarshal(body, &result)
+	assert.NoError(t, err)
+
+	// Template Response
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "success", result["status"])
+
+	// Check Trips
+	data, ok := result["data"].([]interface{})
+	assert.True(t, ok, "data should be a JSON array")
+	assert.Len(t, data, 2)
+	first := data[0].(map[string]interface{})
+	assert.Equal(t, float64(3), first["point_count"])
+	assert.Equal(t, float64(120), first["duration"])
+	assert.Equal(t, -6.2, first["start_lat"])
+	assert.Equal(t, -6.2018, first["end_lat"])
+	assert.InDelta(t, 200, first["distance"], 1)
+	assert.Equal(t, float64(180), first["bearing"])
+	second := data[1].(map[string]interface{})
+	assert.Equal(t, float64(2), second["point_count"])
+	assert.Equal(t, float64(300), second["duration"])
+	assert.InDelta(t, 90, second["bearing"], 0.01)
+
+	metadata := result["metadata"].(map[string]interface{})
+	assert.Equal(t, float64(2), metadata["total"])
+	assert.Equal(t, "10m0s", metadata["gap"])
+}
+
+// Negative - Test Case
+func TestFailedGetTrackTripsWithInvalidThreshold(t *testing.T) {
+	server, _ := setUpServer(t)
+
+	// Test Data
+	url := server.URL + "/api/v1/tracks/pinmarker/fcd3f23e-e5aa-11ee-892a-3216422910e9/trips"
+	cases := map[string]string{
+		"gap must be a duration between 1m and 24h":      "?gap=5s",
+		"distance must be a number between 1 and 100000": "?distance=-1",
+	}
+
+	for message, query := range cases {
+		// Exec
+		resp, err := http.Get(url + query)
+		assert.NoError(t, err)
+
+		// Prepare Response Test
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.NoError(t, err)
+		var result map[string]interface{}
+		err = json.Unmarshal(body, &result)
+		assert.NoError(t, err)
+
+		// Template Response
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, "failed", result["status"])
+		assert.Equal(t, message, result["message"])
+	}
+}
@@ -0,0 +1,198 @@
+package e2e
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"pinmarker/entities"
+	"pinmarker/repositories"
+	"pinmarker/routes"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// seedUserData stores a record of every document for the user, in two app sources
+func seedUserData(t *testing.T, repo routes.Repository, userID string) {
+	t.Helper()
+
+	createdBy := uuid.MustParse(userID)
+	tracks := seedTrackBatch(t, repo.Track, "pinmarker", userID, 2)
+	assert.NoError(t, repo.Track.DeleteByID("pinmarker", createdBy, tracks[1].ID))
+	seedTrack(t, repo.Track, "myride", userID)
+
+	lat, long := entities.Coordinate(-6.228755), entities.Coordinate(106.820035)
+	fence := &entities.Geofence{Name: "Home", FenceType: "circle", CenterLat: &lat, CenterLong: &long, Radius: 100, AppsSource: "pinmarker", CreatedBy: createdBy}
+	assert.NoError(t, repo.Geofence.Create(fence))
+	assert.NoError(t, repo.Geofence.CreateEvents([]*entities.GeofenceEvent{{
+		GeofenceID: fence.ID, EventType: "enter", TrackID: tracks[0].ID, TrackLat: lat, TrackLong: long,
+		AppsSource: "pinmarker", RecordedAt: time.Now(), CreatedBy: createdBy,
+	}}))
+	assert.NoError(t, repo.Alert.Save(&entities.TrackAlert{AppsSource: "pinmarker", CreatedBy: createdBy, AlertType: "low_battery", TrackID: tracks[0].ID, RecordedAt: time.Now(), SentAt: time.Now()}))
+	assert.NoError(t, repo.Share.Create(&entities.ShareLink{AppsSource: "pinmarker", CreatedBy: createdBy, ExpiresAt: time.Now().Add(time.Hour)}))
+	_, err := repo.Usage.Reserve("myride", createdBy, "2026-01-01", 1, 0)
+	assert.NoError(t, err)
+}
+
+// adminToken signs a token carrying the admin claim
+func adminToken(t *testing.T) string {
+	t.Helper()
+
+	return signToken(t, jwt.MapClaims{"sub": uuid.NewString(), "exp": time.Now().Add(time.Hour).Unix(), "admin": true})
+}
+
+// Positive - Test Case
+func TestSuccessExportUserData(t *testing.T) {
+	setUpAuthKey(t)
+	repo := newTestRepository(repositories.NewTrackMemoryRepository())
+	server := setUpServerWithRepositories(t, repo)
+
+	// Test Data
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	seedUserData(t, repo, userID)
+	url := server.URL + "/api/v1/users/" + userID
+	admin := adminToken(t)
+
+	// Exec : Apps
+	status, result := sendJSONWithToken(t, http.MethodGet, url+"/apps", admin, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []interface{}{"myride", "pinmarker"}, result["data"])
+
+	// Exec : Archive
+	req, err := http.NewRequest(http.MethodGet, url+"/export", nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+admin)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/zip", resp.Header.Get("Content-Type"))
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	assert.NoError(t, err)
+
+	// Check Data
+	files := make(map[string][]byte)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		assert.NoError(t, err)
+		files[file.Name], _ = io.ReadAll(reader)
+		reader.Close()
+	}
+	var manifest entities.UserDataManifest
+	assert.NoError(t, json.Unmarshal(files["manifest.json"], &manifest))
+	assert.Equal(t, []entities.UserDataApp{
+		{AppsSource: "pinmarker", Records: map[string]int{
+			"tracks": 1, "tracks_trash": 1, "tracks_latest": 1, "geofences": 1, "geofence_events": 1, "track_alerts": 1, "shares": 1,
+		}},
+		{AppsSource: "myride", Records: map[string]int{"tracks": 1, "tracks_latest": 1, "track_usages": 1}},
+	}, manifest.Apps)
+	var trash []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(files["pinmarker/tracks_trash.json"], &trash))
+	assert.Len(t, trash, 1)
+	assert.NotEmpty(t, trash[0]["deleted_at"])
+}
+
+func TestSuccessEraseUserData(t *testing.T) {
+	setUpAuthKey(t)
+	repo := newTestRepository(repositories.NewTrackMemoryRepository())
+	server := setUpServerWithRepositories(t, repo)
+	admin := adminToken(t)
+
+	// Test Data : Another user's track must stay
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	otherID := "0b4b8d0e-5c3a-4d8e-9a3e-2f3a1c6b7d10"
+	seedUserData(t, repo, userID)
+	seedTrack(t, repo.Track, "pinmarker", otherID)
+	url := server.URL + "/api/v1/users/" + userID
+
+	// Exec
+	status, result := sendJSONWithToken(t, http.MethodDelete, url, admin, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "User permanentally deleted", result["message"])
+	data := result["data"].(map[string]interface{})
+	assert.Equal(t, true, data["verified"])
+	assert.Len(t, data["deleted"], 2)
+	assert.Empty(t, data["remaining"])
+
+	// Check Data : Nothing left in any document
+	status, result = sendJSONWithToken(t, http.MethodGet, url+"/apps", admin, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, result["data"])
+	status, _ = sendJSONWithToken(t, http.MethodGet, server.URL+"/api/v1/tracks/pinmarker/"+userID+"/latest", admin, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+	fences, _ := repo.Geofence.FindAll("pinmarker", uuid.MustParse(userID))
+	assert.Empty(t, fences)
+	shares, _ := repo.Share.FindAll("pinmarker", uuid.MustParse(userID))
+	assert.Empty(t, shares)
+	usage, _ := repo.Usage.FindByUser("myride", uuid.MustParse(userID))
+	assert.Nil(t, usage)
+	status, _ = sendJSONWithToken(t, http.MethodGet, server.URL+"/api/v1/tracks/pinmarker/"+otherID+"/latest", admin, nil)
+	assert.Equal(t, http.StatusOK, status)
+
+	// Check Data : Audit record
+	status, result = sendJSONWithToken(t, http.MethodGet, url+"/erasures", admin, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, result["data"], 1)
+}
+
+// Negative - Test Case
+func TestFailedEraseUserData(t *testing.T) {
+	server, trackRepo := setUpAuthServer(t)
+
+	// Test Data
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	seedTrack(t, trackRepo, "pinmarker", userID)
+	url := server.URL + "/api/v1/users/" + userID
+	expiresAt := time.Now().Add(time.Hour).Unix()
+
+	// Exec : Without a token
+	status, result := sendJSON(t, http.MethodDelete, url, nil)
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, "authorization bearer token is required", result["message"])
+
+	// Exec : The user's own token is not an admin token
+	token := signToken(t, jwt.MapClaims{"sub": userID, "exp": expiresAt})
+	status, result = sendJSONWithToken(t, http.MethodDelete, url, token, nil)
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "admin token is required", result["message"])
+	status, _ = sendJSONWithToken(t, http.MethodGet, url+"/export", token, nil)
+	assert.Equal(t, http.StatusForbidden, status)
+
+	// Exec : Admin with an invalid user
+	admin := signToken(t, jwt.MapClaims{"sub": uuid.NewString(), "exp": expiresAt, "admin": true})
+	status, result = sendJSONWithToken(t, http.MethodDelete, server.URL+"/api/v1/users/not-uuid", admin, nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "created by is not valid", result["message"])
+
+	// Check Data : Untouched
+	status, result = sendJSONWithToken(t, http.MethodGet, url+"/apps", admin, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []interface{}{"pinmarker"}, result["data"])
+}
+
+func TestFailedEraseUserDataWithoutAuth(t *testing.T) {
+	server, trackRepo := setUpServer(t)
+
+	// Test Data
+	userID := "fcd3f23e-e5aa-11ee-892a-3216422910e9"
+	seedTrack(t, trackRepo, "pinmarker", userID)
+
+	// Exec : Not mounted while no key set can verify an admin token
+	req, err := http.NewRequest(http.MethodDelete, server.URL+"/api/v1/users/"+userID, nil)
+	assert.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Check Data : Untouched
+	status, _ := sendJSON(t, http.MethodGet, server.URL+"/api/v1/tracks/pinmarker/"+userID+"/latest", nil)
+	assert.Equal(t, http.StatusOK, status)
+}
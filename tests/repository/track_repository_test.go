@@ -0,0 +1,413 @@
+package repository
+
+import (
+	"path/filepath"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"pinmarker/repositories"
+	"pinmarker/utils"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testUser  = uuid.MustParse("fcd3f23e-e5aa-11ee-892a-3216422910e9")
+	otherUser = uuid.MustParse("0b4b8d0e-5c3a-4d8e-9a3e-2f3a1c6b7d10")
+)
+
+// trackRepositories builds a fresh repository of every driver that runs without a backend,
+// gorm is backed by a sqlite file of the test
+var trackRepositories = map[string]func(t *testing.T) repositories.TrackRepository{
+	"memory": func(t *testing.T) repositories.TrackRepository {
+		return repositories.NewTrackMemoryRepository()
+	},
+	"gorm": func(t *testing.T) repositories.TrackRepository {
+		t.Setenv("DB_DRIVER", "sqlite")
+		t.Setenv("DB_DSN", filepath.Join(t.TempDir(), "pinmarker.db"))
+		configs.InitDatabase()
+		t.Cleanup(func() {
+			if db, err := configs.Database.DB(); err == nil {
+				db.Close()
+			}
+			configs.Database = nil
+		})
+
+		return repositories.NewTrackGormRepository()
+	},
+}
+
+// runTrackRepository runs the case against every driver
+func runTrackRepository(t *testing.T, run func(t *testing.T, repo repositories.TrackRepository)) {
+	for name, build := range trackRepositories {
+		t.Run(name, func(t *testing.T) {
+			run(t, build(t))
+		})
+	}
+}
+
+// seedTracks stores total tracks of the user recorded a minute apart, the newest first
+func seedTracks(t *testing.T, repo repositories.TrackRepository, createdBy uuid.UUID, total int) []*entities.Track {
+	t.Helper()
+
+	now := time.Now().Truncate(time.Second)
+	tracks := make([]*entities.Track, 0, total)
+	for i := 0; i < total; i++ {
+		tracks = append(tracks, &entities.Track{
+			BatteryIndicator: 80 - i,
+			TrackLat:         -6.228755,
+			TrackLong:        106.820035,
+			TrackType:        "live",
+			AppsSource:       "pinmarker",
+			CreatedBy:        createdBy,
+			RecordedAt:       now.Add(-time.Duration(i) * time.Minute),
+		})
+	}
+	assert.NoError(t, repo.CreateBatch(tracks))
+
+	return tracks
+}
+
+func trackIDs(tracks []*entities.Track) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(tracks))
+	for _, track := range tracks {
+		ids = append(ids, track.ID)
+	}
+
+	return ids
+}
+
+// Positive - Test Case
+func TestSuccessTrackRepositoryCursor(t *testing.T) {
+	runTrackRepository(t, func(t *testing.T, repo repositories.TrackRepository) {
+		// Test Data
+		tracks := seedTracks(t, repo, testUser, 5)
+		seedTracks(t, repo, otherUser, 2)
+
+		// Exec : Walk every page forward
+		pagination := utils.Pagination{Limit: 2, Sort: "desc", UseCursor: true}
+		walked := make([]*entities.Track, 0)
+		pages := 0
+		for {
+			page, next, _, err := repo.FindAllByCursor(pagination, "pinmarker", testUser)
+			assert.NoError(t, err)
+			walked = append(walked, page...)
+			pages++
+			if next == "" {
+				break
+			}
+			pagination.Cursor = next
+		}
+
+		// Check Data : Newest first, no track twice
+		assert.Equal(t, 3, pages)
+		assert.Equal(t, trackIDs(tracks), trackIDs(walked))
+
+		// Exec : Back from the last page
+		pagination.Cursor = ""
+		first, next, _, err := repo.FindAllByCursor(pagination, "pinmarker", testUser)
+		assert.NoError(t, err)
+		pagination.Cursor = next
+		_, _, prev, err := repo.FindAllByCursor(pagination, "pinmarker", testUser)
+		assert.NoError(t, err)
+		pagination.Cursor = prev
+		back, _, _, err := repo.FindAllByCursor(pagination, "pinmarker", testUser)
+		assert.NoError(t, err)
+		assert.Equal(t, trackIDs(first), trackIDs(back))
+	})
+}
+
+func TestSuccessTrackRepositoryFilter(t *testing.T) {
+	runTrackRepository(t, func(t *testing.T, repo repositories.TrackRepository) {
+		// Test Data
+		tracks := seedTracks(t, repo, testUser, 5)
+		share := &entities.Track{
+			BatteryIndicator: 50, TrackLat: -6.2, TrackLong: 106.8, TrackType: "share-loc",
+			AppsSource: "pinmarker", CreatedBy: testUser, RecordedAt: tracks[0].RecordedAt.Add(time.Minute),
+		}
+		assert.NoError(t, repo.Create(share))
+		from := tracks[3].RecordedAt
+		to := tracks[1].RecordedAt
+		batteryMin := 78
+
+		// Exec : Time range
+		result, total, err := repo.FindAll(utils.Pagination{Page: 1, Limit: 10, Sort: "asc", Filter: utils.TrackFilter{From: &from, To: &to}}, "pinmarker", testUser)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, total)
+		assert.Equal(t, []uuid.UUID{tracks[3].ID, tracks[2].ID, tracks[1].ID}, trackIDs(result))
+
+		// Exec : Track type & battery
+		result, total, err = repo.FindAll(utils.Pagination{Page: 1, Limit: 10, Filter: utils.TrackFilter{TrackType: "live", BatteryMin: &batteryMin}}, "pinmarker", testUser)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, total)
+		assert.Equal(t, trackIDs(tracks[:3]), trackIDs(result))
+
+		// Exec : Offset page
+		result, total, err = repo.FindAll(utils.Pagination{Page: 2, Limit: 2, Filter: utils.TrackFilter{TrackType: "live"}}, "pinmarker", testUser)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, total)
+		assert.Equal(t, trackIDs(tracks[2:4]), trackIDs(result))
+	})
+}
+
+func TestSuccessTrackRepositoryFilterWithOffset(t *testing.T) {
+	runTrackRepository(t, func(t *testing.T, repo repositories.TrackRepository) {
+		// Test Data : Bounds in a zone far from the server's
+		tracks := seedTracks(t, repo, testUser, 5)
+		zone := time.FixedZone("UTC+14", 14*60*60)
+		if _, offset := time.Now().Zone(); offset == 14*60*60 {
+			zone = time.FixedZone("UTC-12", -12*60*60)
+		}
+		from := tracks[3].RecordedAt.In(zone)
+		to := tracks[1].RecordedAt.In(zone)
+
+		// Exec : Offset page & cursor page
+		result, total, err := repo.FindAll(utils.Pagination{Page: 1, Limit: 10, Sort: "asc", Filter: utils.TrackFilter{From: &from, To: &to}}, "pinmarker", testUser)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, total)
+		assert.Equal(t, []uuid.UUID{tracks[3].ID, tracks[2].ID, tracks[1].ID}, trackIDs(result))
+		result, _, _, err = repo.FindAllByCursor(utils.Pagination{Limit: 10, UseCursor: true, Filter: utils.TrackFilter{From: &from, To: &to}}, "pinmarker", testUser)
+		assert.NoError(t, err)
+		assert.Equal(t, trackIDs(tracks[1:4]), trackIDs(result))
+	})
+}
+
+func TestSuccessTrackRepositoryGeohash(t *testing.T) {
+	runTrackRepository(t, func(t *testing.T, repo repositories.TrackRepository) {
+		// Test Data : Monas, Kota Tua and Bandung
+		points := map[string][2]float64{
+			"monas":    {-6.175392, 106.827153},
+			"kota tua": {-6.135200, 106.813301},
+			"bandung":  {-6.917464, 107.619125},
+		}
+		ids := make(map[string]uuid.UUID)
+		for name, point := range points {
+			track := &entities.Track{
+				BatteryIndicator: 80, TrackLat: entities.Coordinate(point[0]), TrackLong: entities.Coordinate(point[1]),
+				TrackType: "live", AppsSource: "pinmarker", CreatedBy: testUser,
+			}
+			assert.NoError(t, repo.Create(track))
+			assert.NotEmpty(t, track.Geohash)
+			ids[name] = track.ID
+		}
+
+		// Exec : Box around Jakarta
+		box, err := repo.FindWithinBox(utils.SpatialQuery{
+			AppsSource: "pinmarker", MinLat: -6.25, MinLong: 106.75, MaxLat: -6.1, MaxLong: 106.9, Limit: 10,
+		})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []uuid.UUID{ids["monas"], ids["kota tua"]}, trackIDs(box))
+
+		// Exec : 1 km around Monas, of the user only
+		query := utils.SpatialQuery{AppsSource: "pinmarker", CreatedBy: &testUser, Lat: -6.175392, Long: 106.827153, Radius: 1000, Limit: 10}
+		query.MinLat, query.MinLong, query.MaxLat, query.MaxLong = utils.GeoBoxAround(query.Lat, query.Long, query.Radius)
+		nearby, err := repo.FindWithinRadius(query)
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{ids["monas"]}, trackIDs(nearby))
+
+		// Exec : Another user has nothing there
+		query.CreatedBy = &otherUser
+		nearby, err = repo.FindWithinRadius(query)
+		assert.NoError(t, err)
+		assert.Empty(t, nearby)
+	})
+}
+
+func TestSuccessTrackRepositoryRadiusAcrossAntimeridian(t *testing.T) {
+	runTrackRepository(t, func(t *testing.T, repo repositories.TrackRepository) {
+		// Test Data : Both sides of the antimeridian near Fiji, and one out of reach
+		points := map[string][2]float64{
+			"west": {-17.0, 179.98},
+			"east": {-17.0, -179.99},
+			"far":  {-17.0, -179.5},
+		}
+		ids := make(map[string]uuid.UUID)
+		for name, point := range points {
+			track := &entities.Track{
+				BatteryIndicator: 80, TrackLat: entities.Coordinate(point[0]), TrackLong: entities.Coordinate(point[1]),
+				TrackType: "live", AppsSource: "pinmarker", CreatedBy: testUser,
+			}
+			assert.NoError(t, repo.Create(track))
+			ids[name] = track.ID
+		}
+
+		// Exec : 5 km around a point just west of it, the box wraps
+		query := utils.SpatialQuery{AppsSource: "pinmarker", Lat: -17.0, Long: 179.99, Radius: 5000, Limit: 10}
+		query.MinLat, query.MinLong, query.MaxLat, query.MaxLong = utils.GeoBoxAround(query.Lat, query.Long, query.Radius)
+		assert.Greater(t, query.MinLong, query.MaxLong)
+		nearby, err := repo.FindWithinRadius(query)
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{ids["west"], ids["east"]}, trackIDs(nearby))
+	})
+}
+
+func TestSuccessTrackRepositoryIdempotentCreate(t *testing.T) {
+	runTrackRepository(t, func(t *testing.T, repo repositories.TrackRepository) {
+		// Test Data
+		id := uuid.New()
+		original := &entities.Track{ID: id, BatteryIndicator: 80, TrackLat: -6.2, TrackLong: 106.8, TrackType: "live", AppsSource: "pinmarker", CreatedBy: testUser}
+		assert.NoError(t, repo.Create(original))
+		assert.False(t, original.Replayed)
+
+		// Exec : Replay with another body
+		replay := &entities.Track{ID: id, BatteryIndicator: 10, TrackLat: -6.3, TrackLong: 106.9, TrackType: "live", AppsSource: "pinmarker", CreatedBy: testUser}
+		assert.NoError(t, repo.Create(replay))
+		assert.Equal(t, 80, replay.BatteryIndicator)
+		assert.True(t, original.ReceivedAt.Equal(replay.ReceivedAt))
+		assert.True(t, replay.Replayed)
+
+		// Exec : Batch replay next to a new track
+		batch := []*entities.Track{
+			{ID: id, BatteryIndicator: 10, TrackLat: -6.3, TrackLong: 106.9, TrackType: "live", AppsSource: "pinmarker", CreatedBy: testUser},
+			{ID: uuid.New(), BatteryIndicator: 70, TrackLat: -6.3, TrackLong: 106.9, TrackType: "live", AppsSource: "pinmarker", CreatedBy: testUser},
+		}
+		assert.NoError(t, repo.CreateBatch(batch))
+		assert.Equal(t, 80, batch[0].BatteryIndicator)
+		assert.True(t, batch[0].Replayed)
+		assert.False(t, batch[1].Replayed)
+
+		// Check Data : The replays wrote nothing
+		_, total, err := repo.FindAll(utils.Pagination{Page: 1, Limit: 10}, "pinmarker", testUser)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, total)
+
+		// Check Data : Stored ids, a trashed one included
+		assert.NoError(t, repo.DeleteByID("pinmarker", testUser, batch[1].ID))
+		stored, err := repo.FindStored([]*entities.Track{
+			{ID: id, AppsSource: "pinmarker", CreatedBy: testUser},
+			{ID: batch[1].ID, AppsSource: "pinmarker", CreatedBy: testUser},
+			{ID: uuid.New(), AppsSource: "pinmarker", CreatedBy: testUser},
+			{AppsSource: "pinmarker", CreatedBy: testUser},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, stored, 2)
+		assert.Equal(t, 80, stored[id].BatteryIndicator)
+		assert.Equal(t, batch[1].ID, stored[batch[1].ID].ID)
+	})
+}
+
+func TestSuccessTrackRepositoryClientIDOfAnotherUser(t *testing.T) {
+	runTrackRepository(t, func(t *testing.T, repo repositories.TrackRepository) {
+		// Test Data
+		id := uuid.New()
+		assert.NoError(t, repo.Create(&entities.Track{ID: id, BatteryIndicator: 80, TrackLat: -6.2, TrackLong: 106.8, TrackType: "live", AppsSource: "pinmarker", CreatedBy: testUser}))
+
+		// Exec : The id is not stored for another user
+		stored, err := repo.FindStored([]*entities.Track{{ID: id, AppsSource: "pinmarker", CreatedBy: otherUser}})
+		assert.NoError(t, err)
+		assert.Empty(t, stored)
+
+		// Exec : Another user's create never replays the first user's track, gorm keeps
+		// ids table wide so it is a conflict there
+		for _, create := range []func(track *entities.Track) error{
+			repo.Create,
+			func(track *entities.Track) error { return repo.CreateBatch([]*entities.Track{track}) },
+		} {
+			track := &entities.Track{ID: id, BatteryIndicator: 70, TrackLat: -6.2, TrackLong: 106.8, TrackType: "live", AppsSource: "pinmarker", CreatedBy: otherUser}
+			if err := create(track); err != nil {
+				assert.ErrorIs(t, err, repositories.ErrTrackConflict)
+				continue
+			}
+			assert.Equal(t, otherUser, track.CreatedBy)
+			assert.Equal(t, 70, track.BatteryIndicator)
+		}
+
+		// Check Data : The first user's track is untouched
+		tracks, _, err := repo.FindAll(utils.Pagination{Page: 1, Limit: 10}, "pinmarker", testUser)
+		assert.NoError(t, err)
+		assert.Len(t, tracks, 1)
+		assert.Equal(t, 80, tracks[0].BatteryIndicator)
+	})
+}
+
+func TestSuccessTrackRepositoryTrash(t *testing.T) {
+	runTrackRepository(t, func(t *testing.T, repo repositories.TrackRepository) {
+		// Test Data
+		tracks := seedTracks(t, repo, testUser, 2)
+
+		// Exec : Delete the newest
+		assert.NoError(t, repo.DeleteByID("pinmarker", testUser, tracks[0].ID))
+		live, total, err := repo.FindAll(utils.Pagination{Page: 1, Limit: 10}, "pinmarker", testUser)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, []uuid.UUID{tracks[1].ID}, trackIDs(live))
+		latest, err := repo.FindLatest("pinmarker", []uuid.UUID{testUser})
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{tracks[1].ID}, trackIDs(latest))
+		trash, total, err := repo.FindTrash(utils.Pagination{Page: 1, Limit: 10}, "pinmarker", testUser)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, tracks[0].ID, trash[0].ID)
+		assert.False(t, trash[0].DeletedAt.IsZero())
+		assert.ErrorIs(t, repo.DeleteByID("pinmarker", testUser, tracks[0].ID), repositories.ErrTrackNotFound)
+
+		// Exec : Replaying the trashed id does not bring it back
+		replay := &entities.Track{ID: tracks[0].ID, BatteryIndicator: 10, TrackLat: -6.2, TrackLong: 106.8, TrackType: "live", AppsSource: "pinmarker", CreatedBy: testUser}
+		assert.NoError(t, repo.Create(replay))
+		_, total, err = repo.FindAll(utils.Pagination{Page: 1, Limit: 10}, "pinmarker", testUser)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+
+		// Exec : Recover
+		recovered, err := repo.RecoverByID("pinmarker", testUser, tracks[0].ID)
+		assert.NoError(t, err)
+		assert.Equal(t, tracks[0].ID, recovered.ID)
+		latest, err = repo.FindLatest("pinmarker", []uuid.UUID{testUser})
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{tracks[0].ID}, trackIDs(latest))
+		_, err = repo.RecoverByID("pinmarker", testUser, tracks[0].ID)
+		assert.ErrorIs(t, err, repositories.ErrTrackNotFound)
+
+		// Exec : Purge only past the retention
+		assert.NoError(t, repo.DeleteByID("pinmarker", testUser, tracks[1].ID))
+		purged, err := repo.PurgeTrash(time.Now().Add(-time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), purged)
+		purged, err = repo.PurgeTrash(time.Now().Add(time.Second))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), purged)
+		_, total, err = repo.FindTrash(utils.Pagination{Page: 1, Limit: 10}, "pinmarker", testUser)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, total)
+	})
+}
+
+func TestSuccessTrackRepositoryRetention(t *testing.T) {
+	runTrackRepository(t, func(t *testing.T, repo repositories.TrackRepository) {
+		// Test Data : Recorded long ago but received today, and one in the trash
+		tracks := seedTracks(t, repo, testUser, 2)
+		seedTracks(t, repo, otherUser, 1)
+		old := &entities.Track{
+			BatteryIndicator: 80, TrackLat: -6.2, TrackLong: 106.8, TrackType: "live",
+			AppsSource: "pinmarker", CreatedBy: testUser, RecordedAt: time.Now().AddDate(0, 0, -40),
+		}
+		assert.NoError(t, repo.Create(old))
+		assert.NoError(t, repo.DeleteByID("pinmarker", testUser, tracks[1].ID))
+
+		// Exec : Received today, inside the retention
+		deleted, err := repo.DeleteAllTracksByDaysCreated(30)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), deleted)
+		_, total, err := repo.FindAll(utils.Pagination{Page: 1, Limit: 10}, "pinmarker", testUser)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, total)
+
+		// Exec : A cutoff past every track, the trashed one too
+		deleted, err = repo.DeleteAllTracksByDaysCreated(-1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), deleted)
+
+		// Check Data : Nothing left, the latest records go with the tracks
+		_, total, err = repo.FindAll(utils.Pagination{Page: 1, Limit: 10}, "pinmarker", testUser)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, total)
+		_, total, err = repo.FindTrash(utils.Pagination{Page: 1, Limit: 10}, "pinmarker", testUser)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, total)
+		latest, err := repo.FindLatest("pinmarker", []uuid.UUID{testUser, otherUser})
+		assert.NoError(t, err)
+		assert.Empty(t, latest)
+	})
+}
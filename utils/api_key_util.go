@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"pinmarker/configs"
+)
+
+var ErrApiKeyInvalid = errors.New("api key is not valid")
+var ErrApiKeyForbidden = errors.New("api key is not valid for the app source")
+
+// ApiKeyGenerate returns a new random key with its hash and the prefix shown in listings,
+// only the hash and the prefix are ever stored
+func ApiKeyGenerate() (raw, hash, prefix string, err error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", "", err
+	}
+	raw = configs.ApiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	return raw, ApiKeyHash(raw), raw[:len(configs.ApiKeyPrefix)+8], nil
+}
+
+// ApiKeyHash is the SHA-256 hex of the key, the keys are random so no salt is needed
+func ApiKeyHash(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+
+	return hex.EncodeToString(sum[:])
+}
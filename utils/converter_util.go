@@ -3,6 +3,9 @@ package utils
 import (
 	"encoding/json"
 	"fmt"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"time"
 	"unicode"
 )
 
@@ -37,3 +40,62 @@ func Capitalize(s string) string {
 	runes[0] = unicode.ToUpper(runes[0])
 	return string(runes)
 }
+
+// ConverterRequestToTrack maps a validated create request into a track
+func ConverterRequestToTrack(req entities.RequestCreateTrack) *entities.Track {
+	track := &entities.Track{
+		BatteryIndicator: req.BatteryIndicator,
+		TrackLat:         *req.TrackLat,
+		TrackLong:        *req.TrackLong,
+		TrackType:        req.TrackType,
+		AppsSource:       req.AppsSource,
+		CreatedBy:        req.CreatedBy,
+	}
+	if req.ID != nil {
+		track.ID = *req.ID
+	}
+	if req.RecordedAt != nil {
+		track.RecordedAt = *req.RecordedAt
+	}
+
+	return track
+}
+
+func ConverterRequestToTrackMulti(item entities.RequestCreateTrackMultiItem) *entities.Track {
+	track := ConverterRequestToTrack(item.RequestCreateTrack)
+	if item.RecordedAt == nil {
+		track.RecordedAt = item.CreatedAt
+	}
+
+	return track
+}
+
+// ConverterRequestToGeofence copies a validated shape onto the fence, only the fields of its
+// fence type are kept
+func ConverterRequestToGeofence(req entities.RequestUpdateGeofence, fence *entities.Geofence) {
+	fence.Name = req.Name
+	fence.FenceType = req.FenceType
+	fence.CenterLat, fence.CenterLong, fence.Radius, fence.Polygon = nil, nil, 0, nil
+	switch req.FenceType {
+	case "circle":
+		fence.CenterLat, fence.CenterLong, fence.Radius = req.CenterLat, req.CenterLong, req.Radius
+	case "polygon":
+		fence.Polygon = req.Polygon
+	}
+}
+
+// ConverterRequestToShare builds the share of a validated request, expiring expires_in from now
+func ConverterRequestToShare(req entities.RequestCreateShare, now time.Time) *entities.ShareLink {
+	expiresIn := configs.ShareDefaultExpiry
+	if req.ExpiresIn != "" {
+		expiresIn, _ = time.ParseDuration(req.ExpiresIn)
+	}
+
+	return &entities.ShareLink{
+		From:       req.From,
+		To:         req.To,
+		ExpiresAt:  now.Add(expiresIn).Truncate(time.Second),
+		AppsSource: req.AppsSource,
+		CreatedBy:  req.CreatedBy,
+	}
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var ErrCursorInvalid = errors.New("cursor is not valid")
+
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+	Direction string    `json:"d"`
+}
+
+func CursorEncode(cursor Cursor) string {
+	j, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(j)
+}
+
+func CursorDecode(raw string) (Cursor, error) {
+	var cursor Cursor
+
+	// Empty Cursor : First Page
+	if raw == "" {
+		cursor.Direction = "next"
+		return cursor, nil
+	}
+
+	j, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return cursor, ErrCursorInvalid
+	}
+	if err := json.Unmarshal(j, &cursor); err != nil {
+		return cursor, ErrCursorInvalid
+	}
+	if cursor.ID == "" || (cursor.Direction != "next" && cursor.Direction != "prev") {
+		return cursor, ErrCursorInvalid
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,209 @@
+package utils
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TrackExporter writes tracks one at a time, so an export never holds the whole history
+type TrackExporter interface {
+	ContentType() string
+	Extension() string
+	Begin() error
+	Write(track *entities.Track) error
+	End() error
+}
+
+func NewTrackExporter(format string, w io.Writer, name string) (TrackExporter, error) {
+	switch format {
+	case "gpx":
+		return &gpxExporter{w: w, name: name}, nil
+	case "kml":
+		return &kmlExporter{w: w, name: name}, nil
+	case "geojson":
+		return &geojsonExporter{w: w}, nil
+	case "csv":
+		return &csvExporter{w: csv.NewWriter(w)}, nil
+	default:
+		return nil, errors.New("format must be one of " + strings.Join(configs.ExportFormats, ", "))
+	}
+}
+
+// exportTime returns the device time of the track, tracks stored before recorded_at
+// existed carry it in created_at
+func exportTime(track *entities.Track) time.Time {
+	if track.RecordedAt.IsZero() {
+		return track.CreatedAt
+	}
+	return track.RecordedAt
+}
+
+func exportCoordinate(coordinate entities.Coordinate) string {
+	return strconv.FormatFloat(float64(coordinate), 'f', -1, 64)
+}
+
+func exportEscape(text string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(text))
+	return b.String()
+}
+
+// Exporter : GPX
+type gpxExporter struct {
+	w    io.Writer
+	name string
+}
+
+func (e *gpxExporter) ContentType() string { return "application/gpx+xml" }
+func (e *gpxExporter) Extension() string   { return "gpx" }
+
+func (e *gpxExporter) Begin() error {
+	_, err := fmt.Fprintf(e.w, "%s<gpx version=\"1.1\" creator=\"pinmarker\" xmlns=\"http://www.topografix.com/GPX/1/1\">\n<trk><name>%s</name><trkseg>\n", xml.Header, exportEscape(e.name))
+	return err
+}
+
+func (e *gpxExporter) Write(track *entities.Track) error {
+	_, err := fmt.Fprintf(e.w, "<trkpt lat=\"%s\" lon=\"%s\"><time>%s</time><type>%s</type></trkpt>\n",
+		exportCoordinate(track.TrackLat), exportCoordinate(track.TrackLong),
+		exportTime(track).UTC().Format(time.RFC3339Nano), exportEscape(track.TrackType))
+	return err
+}
+
+func (e *gpxExporter) End() error {
+	_, err := io.WriteString(e.w, "</trkseg></trk>\n</gpx>\n")
+	return err
+}
+
+// Exporter : KML
+type kmlExporter struct {
+	w    io.Writer
+	name string
+}
+
+func (e *kmlExporter) ContentType() string { return "application/vnd.google-earth.kml+xml" }
+func (e *kmlExporter) Extension() string   { return "kml" }
+
+func (e *kmlExporter) Begin() error {
+	_, err := fmt.Fprintf(e.w, "%s<kml xmlns=\"http://www.opengis.net/kml/2.2\">\n<Document><name>%s</name>\n", xml.Header, exportEscape(e.name))
+	return err
+}
+
+func (e *kmlExporter) Write(track *entities.Track) error {
+	_, err := fmt.Fprintf(e.w, "<Placemark><name>%s</name><TimeStamp><when>%s</when></TimeStamp><Point><coordinates>%s,%s</coordinates></Point></Placemark>\n",
+		exportEscape(track.TrackType), exportTime(track).UTC().Format(time.RFC3339Nano),
+		exportCoordinate(track.TrackLong), exportCoordinate(track.TrackLat))
+	return err
+}
+
+func (e *kmlExporter) End() error {
+	_, err := io.WriteString(e.w, "</Document>\n</kml>\n")
+	return err
+}
+
+// Exporter : GeoJSON
+type geojsonExporter struct {
+	w     io.Writer
+	count int
+}
+
+type geojsonFeature struct {
+	Type     string `json:"type"`
+	Geometry struct {
+		Type        string                 `json:"type"`
+		Coordinates [2]entities.Coordinate `json:"coordinates"`
+	} `json:"geometry"`
+	Properties struct {
+		ID               string    `json:"id"`
+		BatteryIndicator int       `json:"battery_indicator"`
+		TrackType        string    `json:"track_type"`
+		RecordedAt       time.Time `json:"recorded_at"`
+		ReceivedAt       time.Time `json:"received_at"`
+	} `json:"properties"`
+}
+
+func (e *geojsonExporter) ContentType() string { return "application/geo+json" }
+func (e *geojsonExporter) Extension() string   { return "geojson" }
+
+func (e *geojsonExporter) Begin() error {
+	_, err := io.WriteString(e.w, "{\"type\":\"FeatureCollection\",\"features\":[\n")
+	return err
+}
+
+func (e *geojsonExporter) Write(track *entities.Track) error {
+	// Feature : GeoJSON coordinates are long, lat
+	var feature geojsonFeature
+	feature.Type = "Feature"
+	feature.Geometry.Type = "Point"
+	feature.Geometry.Coordinates = [2]entities.Coordinate{track.TrackLong, track.TrackLat}
+	feature.Properties.ID = track.ID.String()
+	feature.Properties.BatteryIndicator = track.BatteryIndicator
+	feature.Properties.TrackType = track.TrackType
+	feature.Properties.RecordedAt = exportTime(track)
+	feature.Properties.ReceivedAt = track.ReceivedAt
+
+	data, err := json.Marshal(feature)
+	if err != nil {
+		return err
+	}
+	if e.count > 0 {
+		if _, err := io.WriteString(e.w, ",\n"); err != nil {
+			return err
+		}
+	}
+	e.count++
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *geojsonExporter) End() error {
+	_, err := io.WriteString(e.w, "\n]}\n")
+	return err
+}
+
+// Exporter : CSV
+type csvExporter struct {
+	w *csv.Writer
+}
+
+func (e *csvExporter) ContentType() string { return "text/csv" }
+func (e *csvExporter) Extension() string   { return "csv" }
+
+func (e *csvExporter) Begin() error {
+	return e.w.Write([]string{"id", "recorded_at", "received_at", "track_lat", "track_long", "battery_indicator", "track_type"})
+}
+
+func (e *csvExporter) Write(track *entities.Track) error {
+	receivedAt := ""
+	if !track.ReceivedAt.IsZero() {
+		receivedAt = track.ReceivedAt.Format(time.RFC3339Nano)
+	}
+	if err := e.w.Write([]string{
+		track.ID.String(),
+		exportTime(track).Format(time.RFC3339Nano),
+		receivedAt,
+		exportCoordinate(track.TrackLat),
+		exportCoordinate(track.TrackLong),
+		strconv.Itoa(track.BatteryIndicator),
+		track.TrackType,
+	}); err != nil {
+		return err
+	}
+
+	// Flush : Keep the rows moving to the client
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvExporter) End() error {
+	e.w.Flush()
+	return e.w.Error()
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"errors"
+	"pinmarker/configs"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrackFilter struct {
+	From       *time.Time
+	To         *time.Time
+	TrackType  string
+	BatteryMin *int
+	BatteryMax *int
+}
+
+func TrackFilterBuilder(c *gin.Context) (TrackFilter, error) {
+	var filter TrackFilter
+
+	// Time Range
+	from, err := filterTime(c, "from")
+	if err != nil {
+		return filter, err
+	}
+	to, err := filterTime(c, "to")
+	if err != nil {
+		return filter, err
+	}
+	if from != nil && to != nil && from.After(*to) {
+		return filter, errors.New("from must be before to")
+	}
+	filter.From = from
+	filter.To = to
+
+	// Track Type
+	if trackType := c.Query("track_type"); trackType != "" {
+		if !ValidatorContains(configs.TrackTypes, trackType) {
+			return filter, errors.New("track type is not valid")
+		}
+		filter.TrackType = trackType
+	}
+
+	// Battery Range
+	batteryMin, err := filterInt(c, "battery_min")
+	if err != nil {
+		return filter, err
+	}
+	batteryMax, err := filterInt(c, "battery_max")
+	if err != nil {
+		return filter, err
+	}
+	if batteryMin != nil && batteryMax != nil && *batteryMin > *batteryMax {
+		return filter, errors.New("battery_min must not be greater than battery_max")
+	}
+	filter.BatteryMin = batteryMin
+	filter.BatteryMax = batteryMax
+
+	return filter, nil
+}
+
+func filterTime(c *gin.Context, key string) (*time.Time, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+
+	// An unescaped "+" offset arrives as a space
+	raw = strings.ReplaceAll(raw, " ", "+")
+	t, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return nil, errors.New(key + " must be a RFC3339 timestamp")
+	}
+
+	return &t, nil
+}
+
+func filterInt(c *gin.Context, key string) (*int, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+
+	val, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, errors.New(key + " must be a number")
+	}
+
+	return &val, nil
+}
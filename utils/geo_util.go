@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"math"
+	"time"
+)
+
+const GeoEarthRadius = 6371008.8
+
+func geoRadian(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// GeoDistance returns the haversine distance between two coordinates in meters
+func GeoDistance(lat1, long1, lat2, long2 float64) float64 {
+	dLat := geoRadian(lat2 - lat1)
+	dLong := geoRadian(long2 - long1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(geoRadian(lat1))*math.Cos(geoRadian(lat2))*math.Sin(dLong/2)*math.Sin(dLong/2)
+
+	return 2 * GeoEarthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// GeoBoxAround returns the bounding box (min lat, min long, max lat, max long) of a circle.
+// A box crossing the antimeridian wraps around it, its min long is then greater than its max long
+func GeoBoxAround(lat, long, radius float64) (float64, float64, float64, float64) {
+	dLat := radius / GeoEarthRadius * 180 / math.Pi
+	minLat := math.Max(lat-dLat, -90)
+	maxLat := math.Min(lat+dLat, 90)
+
+	// Near the poles the circle spans every longitude
+	if minLat == -90 || maxLat == 90 {
+		return minLat, -180, maxLat, 180
+	}
+	dLong := dLat / math.Cos(geoRadian(lat))
+	if dLong >= 180 {
+		return minLat, -180, maxLat, 180
+	}
+
+	return minLat, geoWrapLong(long - dLong), maxLat, geoWrapLong(long + dLong)
+}
+
+// geoWrapLong brings a longitude past -180 or 180 back from the other side
+func geoWrapLong(long float64) float64 {
+	if long < -180 {
+		return long + 360
+	}
+	if long > 180 {
+		return long - 360
+	}
+
+	return long
+}
+
+// GeoLongRanges splits a longitude range wrapping around the antimeridian into the part up
+// to 180 and the part from -180, a range that doesn't wrap is returned whole
+func GeoLongRanges(minLong, maxLong float64) [][2]float64 {
+	if minLong <= maxLong {
+		return [][2]float64{{minLong, maxLong}}
+	}
+
+	return [][2]float64{{minLong, 180}, {-180, maxLong}}
+}
+
+// GeoBearing returns the initial bearing from the first coordinate to the second,
+// in degrees clockwise from north
+func GeoBearing(lat1, long1, lat2, long2 float64) float64 {
+	dLong := geoRadian(long2 - long1)
+	y := math.Sin(dLong) * math.Cos(geoRadian(lat2))
+	x := math.Cos(geoRadian(lat1))*math.Sin(geoRadian(lat2)) -
+		math.Sin(geoRadian(lat1))*math.Cos(geoRadian(lat2))*math.Cos(dLong)
+
+	return math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
+}
+
+// GeoSpeed returns the speed in meters per second of covering distance meters in duration
+func GeoSpeed(distance float64, duration time.Duration) float64 {
+	if duration <= 0 {
+		return 0
+	}
+
+	return distance / duration.Seconds()
+}
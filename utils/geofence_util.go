@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"errors"
+	"pinmarker/entities"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	GeofenceMaxPolygonPoints = 100
+	GeofenceEventMaxLimit    = 1000
+)
+
+type GeofenceEventQuery struct {
+	GeofenceID *uuid.UUID
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+}
+
+func GeofenceEventQueryBuilder(c *gin.Context) (GeofenceEventQuery, error) {
+	query := GeofenceEventQuery{
+		Limit: 100,
+	}
+
+	// Geofence
+	if raw := c.Query("geofence_id"); raw != "" {
+		geofenceID, err := uuid.Parse(raw)
+		if err != nil {
+			return query, errors.New("geofence id is not valid")
+		}
+		query.GeofenceID = &geofenceID
+	}
+
+	// Limit
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > GeofenceEventMaxLimit {
+			return query, errors.New("limit must be between 1 and " + strconv.Itoa(GeofenceEventMaxLimit))
+		}
+		query.Limit = limit
+	}
+
+	// Time Range
+	filter, err := TrackFilterBuilder(c)
+	if err != nil {
+		return query, err
+	}
+	query.From, query.To = filter.From, filter.To
+
+	return query, nil
+}
+
+// GeofenceContains reports whether the coordinate is inside the fence, polygons are
+// treated as flat which holds for fences a few kilometers wide
+func GeofenceContains(fence *entities.Geofence, lat, long float64) bool {
+	switch fence.FenceType {
+	case "circle":
+		if fence.CenterLat == nil || fence.CenterLong == nil {
+			return false
+		}
+		return GeoDistance(float64(*fence.CenterLat), float64(*fence.CenterLong), lat, long) <= fence.Radius
+	case "polygon":
+		// Ray Casting
+		inside := false
+		points := fence.Polygon
+		for i, j := 0, len(points)-1; i < len(points); j, i = i, i+1 {
+			latI, longI := float64(points[i].Lat), float64(points[i].Long)
+			latJ, longJ := float64(points[j].Lat), float64(points[j].Long)
+			if (latI > lat) != (latJ > lat) && long < (longJ-longI)*(lat-latI)/(latJ-latI)+longI {
+				inside = !inside
+			}
+		}
+		return inside
+	default:
+		return false
+	}
+}
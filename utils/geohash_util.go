@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"math"
+	"strings"
+)
+
+const (
+	GeohashPrecision = 9
+	geohashBase32    = "0123456789bcdefghjkmnpqrstuvwxyz"
+	geohashMaxCover  = 12
+)
+
+func GeohashEncode(lat, long float64, precision int) string {
+	minLat, maxLat := -90.0, 90.0
+	minLong, maxLong := -180.0, 180.0
+
+	var hash strings.Builder
+	bit, ch, even := 0, 0, true
+	for hash.Len() < precision {
+		if even {
+			mid := (minLong + maxLong) / 2
+			if long >= mid {
+				ch |= 1 << (4 - bit)
+				minLong = mid
+			} else {
+				maxLong = mid
+			}
+		} else {
+			mid := (minLat + maxLat) / 2
+			if lat >= mid {
+				ch |= 1 << (4 - bit)
+				minLat = mid
+			} else {
+				maxLat = mid
+			}
+		}
+		even = !even
+
+		if bit < 4 {
+			bit++
+		} else {
+			hash.WriteByte(geohashBase32[ch])
+			bit, ch = 0, 0
+		}
+	}
+
+	return hash.String()
+}
+
+// geohashCellSize returns the height and width in degrees of a cell at the precision
+func geohashCellSize(precision int) (float64, float64) {
+	bits := 5 * precision
+	latBits := bits / 2
+	longBits := bits - latBits
+
+	return 180 / math.Pow(2, float64(latBits)), 360 / math.Pow(2, float64(longBits))
+}
+
+// GeohashCover returns the geohash prefixes whose cells cover the bounding box, a box wrapping
+// around the antimeridian is covered one side at a time
+func GeohashCover(minLat, minLong, maxLat, maxLong float64) []string {
+	prefixes := make([]string, 0)
+	for _, longs := range GeoLongRanges(minLong, maxLong) {
+		prefixes = append(prefixes, geohashCoverBox(minLat, longs[0], maxLat, longs[1])...)
+	}
+
+	return prefixes
+}
+
+// geohashCoverBox covers a box that doesn't wrap, using the finest precision that keeps the
+// number of prefixes small
+func geohashCoverBox(minLat, minLong, maxLat, maxLong float64) []string {
+	precision := 1
+	for p := GeohashPrecision; p > 1; p-- {
+		h, w := geohashCellSize(p)
+		rows := math.Ceil((maxLat-minLat)/h) + 1
+		cols := math.Ceil((maxLong-minLong)/w) + 1
+		if rows*cols <= geohashMaxCover {
+			precision = p
+			break
+		}
+	}
+
+	// Walk the box one cell at a time
+	h, w := geohashCellSize(precision)
+	seen := make(map[string]bool)
+	prefixes := make([]string, 0)
+	for lat := minLat; lat < maxLat+h; lat += h {
+		for long := minLong; long < maxLong+w; long += w {
+			hash := GeohashEncode(math.Min(lat, maxLat), math.Min(long, maxLong), precision)
+			if !seen[hash] {
+				seen[hash] = true
+				prefixes = append(prefixes, hash)
+			}
+		}
+	}
+
+	return prefixes
+}
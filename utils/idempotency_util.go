@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+const IdempotencyKeyMaxLength = 255
+
+// Namespace of the track ids derived from an Idempotency-Key header
+var idempotencyNamespace = uuid.MustParse("7b0c3c58-3f0e-4a53-9a53-4f1e6d2a9c11")
+
+func ValidatorIdempotencyKey(key string) error {
+	if len(key) > IdempotencyKeyMaxLength {
+		return fmt.Errorf("idempotency key must be at most %d characters long", IdempotencyKeyMaxLength)
+	}
+	return nil
+}
+
+// IdempotencyTrackID derives a stable track id from the key, so a retried request maps every
+// item onto the id it got the first time. Scope tells apart the endpoints sharing a key
+func IdempotencyTrackID(key, scope, appsSource string, createdBy uuid.UUID, index int) (uuid.UUID, error) {
+	if key == "" {
+		return uuid.Nil, errors.New("idempotency key is required")
+	}
+
+	name := fmt.Sprintf("%s/%s/%s/%s/%d", scope, appsSource, createdBy.String(), key, index)
+	return uuid.NewSHA1(idempotencyNamespace, []byte(name)), nil
+}
@@ -0,0 +1,234 @@
+package utils
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"math"
+	"path/filepath"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImportTrackFormat picks the import format from the query, or from the file extension
+func ImportTrackFormat(format string, filename string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(filename)) {
+		case ".gpx":
+			format = "gpx"
+		case ".geojson", ".json":
+			format = "geojson"
+		}
+	}
+	if !ValidatorContains(configs.ImportFormats, format) {
+		return "", errors.New("format must be one of " + strings.Join(configs.ImportFormats, ", "))
+	}
+
+	return format, nil
+}
+
+// ImportTrackParse converts every point of a GPX or GeoJSON file into a track request owned
+// by the user, points without a valid track type of their own get trackType
+func ImportTrackParse(format string, r io.Reader, appsSource string, createdBy uuid.UUID, trackType string) (entities.RequestCreateTrackMulti, error) {
+	var points []importPoint
+	var err error
+	switch format {
+	case "gpx":
+		points, err = importGPX(r)
+	case "geojson":
+		points, err = importGeoJSON(r)
+	default:
+		err = errors.New("format must be one of " + strings.Join(configs.ImportFormats, ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	items := make(entities.RequestCreateTrackMulti, 0, len(points))
+	for _, point := range points {
+		item := entities.RequestCreateTrackMultiItem{
+			RequestCreateTrack: entities.RequestCreateTrack{
+				BatteryIndicator: point.BatteryIndicator,
+				TrackLat:         point.Lat,
+				TrackLong:        point.Long,
+				TrackType:        trackType,
+				AppsSource:       appsSource,
+				RecordedAt:       point.RecordedAt,
+				CreatedBy:        createdBy,
+			},
+		}
+		if ValidatorContains(configs.TrackTypes, point.TrackType) {
+			item.TrackType = point.TrackType
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+type importPoint struct {
+	Lat              *entities.Coordinate
+	Long             *entities.Coordinate
+	RecordedAt       *time.Time
+	BatteryIndicator int
+	TrackType        string
+}
+
+func importCoordinate(raw string) *entities.Coordinate {
+	value, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil
+	}
+	coordinate := entities.Coordinate(value)
+	return &coordinate
+}
+
+func importTime(raw string) *time.Time {
+	value, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(raw))
+	if err != nil {
+		return nil
+	}
+	return &value
+}
+
+// Import : GPX track, route & way points
+type gpxPoint struct {
+	Lat  string `xml:"lat,attr"`
+	Long string `xml:"lon,attr"`
+	Time string `xml:"time"`
+	Type string `xml:"type"`
+}
+
+type gpxFile struct {
+	Tracks []struct {
+		Segments []struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+	Routes []struct {
+		Points []gpxPoint `xml:"rtept"`
+	} `xml:"rte"`
+	Waypoints []gpxPoint `xml:"wpt"`
+}
+
+func importGPX(r io.Reader) ([]importPoint, error) {
+	var file gpxFile
+	if err := xml.NewDecoder(r).Decode(&file); err != nil {
+		return nil, errors.New("file is not a valid GPX document")
+	}
+
+	gpx := make([]gpxPoint, 0)
+	for _, track := range file.Tracks {
+		for _, segment := range track.Segments {
+			gpx = append(gpx, segment.Points...)
+		}
+	}
+	for _, route := range file.Routes {
+		gpx = append(gpx, route.Points...)
+	}
+	gpx = append(gpx, file.Waypoints...)
+
+	points := make([]importPoint, 0, len(gpx))
+	for _, point := range gpx {
+		points = append(points, importPoint{
+			Lat:        importCoordinate(point.Lat),
+			Long:       importCoordinate(point.Long),
+			RecordedAt: importTime(point.Time),
+			TrackType:  point.Type,
+		})
+	}
+
+	return points, nil
+}
+
+// Import : GeoJSON points, multi points & line strings
+type geojsonImportGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+type geojsonImportFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   *geojsonImportGeometry `json:"geometry"`
+	Properties struct {
+		RecordedAt       string   `json:"recorded_at"`
+		Time             string   `json:"time"`
+		CoordTimes       []string `json:"coordTimes"`
+		BatteryIndicator int      `json:"battery_indicator"`
+		TrackType        string   `json:"track_type"`
+	} `json:"properties"`
+}
+
+type geojsonImportFile struct {
+	geojsonImportFeature
+	Features []geojsonImportFeature `json:"features"`
+}
+
+func importGeoJSON(r io.Reader) ([]importPoint, error) {
+	var file geojsonImportFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, errors.New("file is not a valid GeoJSON document")
+	}
+
+	// Feature Collection, or a single feature
+	features := file.Features
+	switch file.Type {
+	case "FeatureCollection":
+	case "Feature":
+		features = []geojsonImportFeature{file.geojsonImportFeature}
+	default:
+		return nil, errors.New("file must be a GeoJSON Feature or FeatureCollection")
+	}
+
+	points := make([]importPoint, 0, len(features))
+	for _, feature := range features {
+		if feature.Geometry == nil {
+			continue
+		}
+
+		// Coordinates : Long, Lat
+		var positions [][]float64
+		switch feature.Geometry.Type {
+		case "Point":
+			var position []float64
+			if err := json.Unmarshal(feature.Geometry.Coordinates, &position); err != nil {
+				return nil, errors.New("file has a Point with invalid coordinates")
+			}
+			positions = [][]float64{position}
+		case "MultiPoint", "LineString":
+			if err := json.Unmarshal(feature.Geometry.Coordinates, &positions); err != nil {
+				return nil, errors.New("file has a " + feature.Geometry.Type + " with invalid coordinates")
+			}
+		default:
+			continue
+		}
+
+		recordedAt := feature.Properties.RecordedAt
+		if recordedAt == "" {
+			recordedAt = feature.Properties.Time
+		}
+		for i, position := range positions {
+			point := importPoint{
+				RecordedAt:       importTime(recordedAt),
+				BatteryIndicator: feature.Properties.BatteryIndicator,
+				TrackType:        feature.Properties.TrackType,
+			}
+			if i < len(feature.Properties.CoordTimes) {
+				point.RecordedAt = importTime(feature.Properties.CoordTimes[i])
+			}
+			if len(position) >= 2 {
+				lat, long := entities.Coordinate(position[1]), entities.Coordinate(position[0])
+				point.Lat, point.Long = &lat, &long
+			}
+			points = append(points, point)
+		}
+	}
+
+	return points, nil
+}
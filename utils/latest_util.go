@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"errors"
+	"pinmarker/configs"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// LatestTrackUsersBuilder reads the users of a bulk latest track request, given as repeated
+// or comma separated created_by, duplicates are dropped
+func LatestTrackUsersBuilder(c *gin.Context) ([]uuid.UUID, error) {
+	users := make([]uuid.UUID, 0)
+	seen := make(map[uuid.UUID]bool)
+	for _, raw := range c.QueryArray("created_by") {
+		for _, item := range strings.Split(raw, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			user, err := uuid.Parse(item)
+			if err != nil {
+				return nil, errors.New("created by is not valid")
+			}
+			if !seen[user] {
+				seen[user] = true
+				users = append(users, user)
+			}
+		}
+	}
+
+	if len(users) == 0 || len(users) > configs.TrackLatestMaxUsers {
+		return nil, errors.New("created by must list between 1 and " + strconv.Itoa(configs.TrackLatestMaxUsers) + " users")
+	}
+
+	return users, nil
+}
@@ -1,29 +1,46 @@
 package utils
 
 import (
+	"errors"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
+const PaginationMaxLimit = 500
+
 type Pagination struct {
-	Page  int
-	Limit int
+	Page      int
+	Limit     int
+	Cursor    string
+	UseCursor bool
+	Sort      string
+	Filter    TrackFilter
+	Simplify  SimplifyQuery
 }
 
-func PaginationBuilder(c *gin.Context) Pagination {
+func PaginationBuilder(c *gin.Context) (Pagination, error) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	cursor, useCursor := c.GetQuery("cursor")
+	sort := strings.ToLower(c.DefaultQuery("sort", "desc"))
 
 	if page < 1 {
 		page = 1
 	}
-	if limit < 1 {
-		limit = 10
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > PaginationMaxLimit {
+		return Pagination{}, errors.New("limit must be between 1 and " + strconv.Itoa(PaginationMaxLimit))
+	}
+	if sort != "asc" && sort != "desc" {
+		return Pagination{}, errors.New("sort must be asc or desc")
 	}
 
 	return Pagination{
-		Page:  page,
-		Limit: limit,
-	}
+		Page:      page,
+		Limit:     limit,
+		Cursor:    cursor,
+		UseCursor: useCursor,
+		Sort:      sort,
+	}, nil
 }
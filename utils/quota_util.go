@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// QuotaDay is the UTC day a point is counted on
+func QuotaDay(now time.Time) string {
+	return now.UTC().Format("2006-01-02")
+}
+
+// QuotaResetsAt is the next UTC midnight
+func QuotaResetsAt(now time.Time) time.Time {
+	return now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+}
+
+// RetryAfter formats a wait as the whole seconds of a Retry-After header
+func RetryAfter(wait time.Duration) string {
+	return strconv.Itoa(int(math.Max(1, math.Ceil(wait.Seconds()))))
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"math"
+	"pinmarker/configs"
+	"sync"
+	"time"
+)
+
+// rateBucket is the tokens left when it was last touched, and how long it takes to refill
+type rateBucket struct {
+	tokens float64
+	last   time.Time
+	refill time.Duration
+}
+
+// RateLimiter keeps a token bucket per key in memory, every instance limits on its own
+type RateLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*rateBucket
+	lastSweep time.Time
+}
+
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		buckets: make(map[string]*rateBucket),
+	}
+}
+
+// Allow takes a token from the key's bucket, or tells how long until one is refilled
+func (l *RateLimiter) Allow(key string, limit configs.RateLimit, now time.Time) (bool, time.Duration) {
+	if limit.Rate <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now)
+
+	// Refill
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &rateBucket{
+			tokens: float64(limit.Burst),
+			last:   now,
+			refill: time.Duration(float64(limit.Burst) / limit.Rate * float64(time.Second)),
+		}
+		l.buckets[key] = bucket
+	}
+	bucket.tokens = math.Min(float64(limit.Burst), bucket.tokens+now.Sub(bucket.last).Seconds()*limit.Rate)
+	bucket.last = now
+
+	// Take
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+
+	return false, time.Duration((1 - bucket.tokens) / limit.Rate * float64(time.Second))
+}
+
+// sweep drops the buckets idle long enough to be full again, at most once a minute
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.last) > bucket.refill {
+			delete(l.buckets, key)
+		}
+	}
+}
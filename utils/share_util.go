@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"pinmarker/configs"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrShareTokenInvalid = errors.New("Share not found")
+var ErrShareExpired = errors.New("Share has expired or was revoked")
+
+// ShareToken is the signed part of a share link, it only locates the share record
+type ShareToken struct {
+	ID         uuid.UUID `json:"id"`
+	AppsSource string    `json:"app"`
+	CreatedBy  uuid.UUID `json:"user"`
+	ExpiresAt  int64     `json:"exp"`
+}
+
+// ShareTokenSign returns the payload and its HMAC-SHA256, both base64url encoded
+func ShareTokenSign(token ShareToken) (string, error) {
+	j, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(j)
+
+	return payload + "." + base64.RawURLEncoding.EncodeToString(shareSignature(payload)), nil
+}
+
+// ShareTokenParse verifies the signature and the expiry of a share token
+func ShareTokenParse(raw string, now time.Time) (ShareToken, error) {
+	var token ShareToken
+
+	payload, signature, ok := strings.Cut(raw, ".")
+	if !ok {
+		return token, ErrShareTokenInvalid
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, shareSignature(payload)) {
+		return token, ErrShareTokenInvalid
+	}
+	j, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return token, ErrShareTokenInvalid
+	}
+	if err := json.Unmarshal(j, &token); err != nil {
+		return token, ErrShareTokenInvalid
+	}
+	if !now.Before(time.Unix(token.ExpiresAt, 0)) {
+		return token, ErrShareExpired
+	}
+
+	return token, nil
+}
+
+func shareSignature(payload string) []byte {
+	mac := hmac.New(sha256.New, configs.ShareTokenSecret)
+	mac.Write([]byte(payload))
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"pinmarker/entities"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	SimplifyMaxTolerance = 10000
+	SimplifyMaxPoints    = 100000
+)
+
+// SimplifyQuery reduces a route to its shape, a zero value keeps every point
+type SimplifyQuery struct {
+	Tolerance float64
+	MaxPoints int
+}
+
+func (q SimplifyQuery) Enabled() bool {
+	return q.Tolerance > 0 || q.MaxPoints > 0
+}
+
+func SimplifyQueryBuilder(c *gin.Context) (SimplifyQuery, error) {
+	var query SimplifyQuery
+
+	// Tolerance : Meters a dropped point may stray from the simplified route
+	if raw := c.Query("simplify"); raw != "" {
+		tolerance, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(tolerance) || tolerance < 0 || tolerance > SimplifyMaxTolerance {
+			return query, errors.New("simplify must be a number between 0 and " + strconv.Itoa(SimplifyMaxTolerance))
+		}
+		query.Tolerance = tolerance
+	}
+
+	// Max Points
+	if raw := c.Query("max_points"); raw != "" {
+		maxPoints, err := strconv.Atoi(raw)
+		if err != nil || maxPoints < 2 || maxPoints > SimplifyMaxPoints {
+			return query, errors.New("max_points must be between 2 and " + strconv.Itoa(SimplifyMaxPoints))
+		}
+		query.MaxPoints = maxPoints
+	}
+
+	return query, nil
+}
+
+// SimplifyTrack keeps the Douglas-Peucker points of the route further than tolerance meters
+// from it, and at most maxPoints of the most significant ones. The first and last points
+// are always kept and every kept track is returned untouched, timestamps included.
+func SimplifyTrack(tracks []*entities.Track, query SimplifyQuery) []*entities.Track {
+	maxPoints := query.MaxPoints
+	if maxPoints == 0 {
+		maxPoints = -1
+	}
+
+	return SimplifyTrackPage(tracks, query.Tolerance, maxPoints, true, true)
+}
+
+// SimplifyTrackPage simplifies one page of a longer route, only the route's own ends are
+// pinned. maxPoints below zero keeps any number of points, zero keeps none.
+func SimplifyTrackPage(tracks []*entities.Track, tolerance float64, maxPoints int, pinFirst, pinLast bool) []*entities.Track {
+	if maxPoints == 0 {
+		return []*entities.Track{}
+	}
+	if len(tracks) <= 2 && (maxPoints < 0 || len(tracks) <= maxPoints) {
+		return tracks
+	}
+
+	// Significance : Page ends anchor the split, but only the route's ends can not be dropped
+	importance := simplifyImportance(tracks)
+	if !pinFirst {
+		importance[0] = math.MaxFloat64
+	}
+	if !pinLast {
+		importance[len(tracks)-1] = math.MaxFloat64
+	}
+
+	// Tolerance
+	kept := make([]int, 0, len(tracks))
+	for i, value := range importance {
+		if tolerance == 0 || value > tolerance {
+			kept = append(kept, i)
+		}
+	}
+
+	// Max Points : The most significant ones, back in route order
+	if maxPoints > 0 && len(kept) > maxPoints {
+		sort.SliceStable(kept, func(a, b int) bool {
+			return importance[kept[a]] > importance[kept[b]]
+		})
+		kept = kept[:maxPoints]
+		sort.Ints(kept)
+	}
+
+	simplified := make([]*entities.Track, 0, len(kept))
+	for _, i := range kept {
+		simplified = append(simplified, tracks[i])
+	}
+
+	return simplified
+}
+
+// simplifyImportance returns for each point the tolerance under which Douglas-Peucker would
+// still keep it, ends are infinitely significant
+func simplifyImportance(tracks []*entities.Track) []float64 {
+	n := len(tracks)
+	importance := make([]float64, n)
+	if n == 0 {
+		return importance
+	}
+	importance[0], importance[n-1] = math.Inf(1), math.Inf(1)
+
+	// Projection : Meters around the first point, plenty for route sized areas
+	x := make([]float64, n)
+	y := make([]float64, n)
+	lat0, long0 := float64(tracks[0].TrackLat), float64(tracks[0].TrackLong)
+	scale := math.Cos(geoRadian(lat0))
+	for i, track := range tracks {
+		x[i] = geoRadian(float64(track.TrackLong)-long0) * scale * GeoEarthRadius
+		y[i] = geoRadian(float64(track.TrackLat)-lat0) * GeoEarthRadius
+	}
+
+	// Split : A point is never more significant than the split that exposed it
+	type segment struct {
+		first, last int
+		limit       float64
+	}
+	stack := []segment{{0, n - 1, math.Inf(1)}}
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if s.last-s.first < 2 {
+			continue
+		}
+
+		index, distance := -1, -1.0
+		for i := s.first + 1; i < s.last; i++ {
+			d := simplifySegmentDistance(x[i], y[i], x[s.first], y[s.first], x[s.last], y[s.last])
+			if d > distance {
+				index, distance = i, d
+			}
+		}
+		importance[index] = math.Min(distance, s.limit)
+		stack = append(stack, segment{s.first, index, importance[index]}, segment{index, s.last, importance[index]})
+	}
+
+	return importance
+}
+
+// simplifySegmentDistance returns the distance from a point to the segment between two others
+func simplifySegmentDistance(px, py, ax, ay, bx, by float64) float64 {
+	dx, dy := bx-ax, by-ay
+	length := dx*dx + dy*dy
+	if length == 0 {
+		return math.Hypot(px-ax, py-ay)
+	}
+	t := math.Max(0, math.Min(1, ((px-ax)*dx+(py-ay)*dy)/length))
+
+	return math.Hypot(px-(ax+t*dx), py-(ay+t*dy))
+}
@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	SpatialMaxRadius = 50000
+	SpatialMaxLimit  = 1000
+)
+
+type SpatialQuery struct {
+	AppsSource string
+	CreatedBy  *uuid.UUID
+	MinLat     float64
+	MinLong    float64
+	MaxLat     float64
+	MaxLong    float64
+	Lat        float64
+	Long       float64
+	Radius     float64
+	Limit      int
+	Filter     TrackFilter
+}
+
+func SpatialBoxBuilder(c *gin.Context) (SpatialQuery, error) {
+	query, err := spatialBuilder(c)
+	if err != nil {
+		return query, err
+	}
+
+	// Bounding Box
+	if query.MinLat, err = spatialFloat(c, "min_lat", -90, 90); err != nil {
+		return query, err
+	}
+	if query.MaxLat, err = spatialFloat(c, "max_lat", -90, 90); err != nil {
+		return query, err
+	}
+	if query.MinLong, err = spatialFloat(c, "min_long", -180, 180); err != nil {
+		return query, err
+	}
+	if query.MaxLong, err = spatialFloat(c, "max_long", -180, 180); err != nil {
+		return query, err
+	}
+	if query.MinLat > query.MaxLat {
+		return query, errors.New("min_lat must not be greater than max_lat")
+	}
+
+	// Antimeridian : A min_long greater than max_long is a box wrapping around it
+
+	return query, nil
+}
+
+func SpatialRadiusBuilder(c *gin.Context) (SpatialQuery, error) {
+	query, err := spatialBuilder(c)
+	if err != nil {
+		return query, err
+	}
+
+	// Circle
+	if query.Lat, err = spatialFloat(c, "lat", -90, 90); err != nil {
+		return query, err
+	}
+	if query.Long, err = spatialFloat(c, "long", -180, 180); err != nil {
+		return query, err
+	}
+	if query.Radius, err = spatialFloat(c, "radius", 1, SpatialMaxRadius); err != nil {
+		return query, err
+	}
+	query.MinLat, query.MinLong, query.MaxLat, query.MaxLong = GeoBoxAround(query.Lat, query.Long, query.Radius)
+
+	return query, nil
+}
+
+func spatialBuilder(c *gin.Context) (SpatialQuery, error) {
+	query := SpatialQuery{
+		AppsSource: c.Param("app_source"),
+		Limit:      100,
+	}
+
+	// Created By
+	if raw := c.Query("created_by"); raw != "" {
+		createdBy, err := uuid.Parse(raw)
+		if err != nil {
+			return query, errors.New("created by is not valid")
+		}
+		query.CreatedBy = &createdBy
+	}
+
+	// Limit
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > SpatialMaxLimit {
+			return query, errors.New("limit must be between 1 and " + strconv.Itoa(SpatialMaxLimit))
+		}
+		query.Limit = limit
+	}
+
+	// Filter
+	filter, err := TrackFilterBuilder(c)
+	if err != nil {
+		return query, err
+	}
+	query.Filter = filter
+
+	return query, nil
+}
+
+func spatialFloat(c *gin.Context, key string, min, max float64) (float64, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return 0, errors.New(key + " is required")
+	}
+
+	val, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(val) || val < min || val > max {
+		return 0, errors.New(key + " must be a number between " +
+			strconv.FormatFloat(min, 'f', -1, 64) + " and " + strconv.FormatFloat(max, 'f', -1, 64))
+	}
+
+	return val, nil
+}
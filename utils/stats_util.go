@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"errors"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Slowest speed in meters per second that still counts as moving
+const StatsMovingSpeed = 0.5
+
+type StatsQuery struct {
+	Location *time.Location
+	Filter   TrackFilter
+}
+
+func StatsQueryBuilder(c *gin.Context) (StatsQuery, error) {
+	query := StatsQuery{
+		Location: time.Local,
+	}
+
+	// Time Zone : Days are cut at midnight of tz
+	if raw := c.Query("tz"); raw != "" {
+		location, err := time.LoadLocation(raw)
+		if err != nil {
+			return query, errors.New("tz must be a valid IANA time zone")
+		}
+		query.Location = location
+	}
+
+	// Filter
+	filter, err := TrackFilterBuilder(c)
+	if err != nil {
+		return query, err
+	}
+	query.Filter = filter
+
+	return query, nil
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	TripDefaultGap      = 10 * time.Minute
+	TripMaxGap          = 24 * time.Hour
+	TripDefaultDistance = 2000
+	TripMaxDistance     = 100000
+)
+
+type TripQuery struct {
+	Gap      time.Duration
+	Distance float64
+	Filter   TrackFilter
+}
+
+func TripQueryBuilder(c *gin.Context) (TripQuery, error) {
+	query := TripQuery{
+		Gap:      TripDefaultGap,
+		Distance: TripDefaultDistance,
+	}
+
+	// Gap : Longest pause inside one trip
+	if raw := c.Query("gap"); raw != "" {
+		gap, err := time.ParseDuration(raw)
+		if err != nil || gap < time.Minute || gap > TripMaxGap {
+			return query, errors.New("gap must be a duration between 1m and 24h")
+		}
+		query.Gap = gap
+	}
+
+	// Distance : Longest jump between two points of one trip
+	if raw := c.Query("distance"); raw != "" {
+		distance, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(distance) || distance < 1 || distance > TripMaxDistance {
+			return query, errors.New("distance must be a number between 1 and " + strconv.Itoa(TripMaxDistance))
+		}
+		query.Distance = distance
+	}
+
+	// Filter : Trips are cut out of the live track unless asked otherwise
+	filter, err := TrackFilterBuilder(c)
+	if err != nil {
+		return query, err
+	}
+	if filter.TrackType == "" {
+		filter.TrackType = "live"
+	}
+	query.Filter = filter
+
+	return query, nil
+}
@@ -1,5 +1,16 @@
 package utils
 
+import (
+	"errors"
+	"pinmarker/configs"
+	"pinmarker/entities"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
 func ValidatorContains(slice []string, item string) bool {
 	for _, v := range slice {
 		if v == item {
@@ -8,3 +19,184 @@ func ValidatorContains(slice []string, item string) bool {
 	}
 	return false
 }
+
+func ValidatorTrack(req entities.RequestCreateTrack) error {
+	// Validator Field
+	if req.TrackLat == nil {
+		return errors.New("track latitude is required")
+	}
+	if req.TrackLong == nil {
+		return errors.New("track longitude is required")
+	}
+	if req.TrackType == "" {
+		return errors.New("track type is required")
+	}
+	if req.AppsSource == "" {
+		return errors.New("app source is required")
+	}
+
+	// Validator UUID
+	if req.CreatedBy == uuid.Nil {
+		return errors.New("created by is required and must be a valid UUID")
+	}
+
+	// Validator : Range
+	if *req.TrackLat < -90 || *req.TrackLat > 90 {
+		return errors.New("track latitude must be between -90 and 90")
+	}
+	if *req.TrackLong < -180 || *req.TrackLong > 180 {
+		return errors.New("track longitude must be between -180 and 180")
+	}
+	if req.BatteryIndicator < 0 || req.BatteryIndicator > 100 {
+		return errors.New("battery indicator must be between 0 and 100")
+	}
+
+	// Validator : Track Type & Apps Source
+	if !ValidatorContains(configs.TrackTypes, req.TrackType) {
+		return errors.New("track type is not valid")
+	}
+	if !ValidatorContains(configs.AppsSources, req.AppsSource) {
+		return errors.New("app source is not valid")
+	}
+
+	// Validator : Recorded At
+	if req.RecordedAt != nil {
+		if err := ValidatorRecordedAt(*req.RecordedAt, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func ValidatorTrackMultiItem(item entities.RequestCreateTrackMultiItem) error {
+	if err := ValidatorTrack(item.RequestCreateTrack); err != nil {
+		return err
+	}
+	// Validator : Recorded At, or its legacy name created_at
+	if item.RecordedAt == nil {
+		if item.CreatedAt.IsZero() {
+			return errors.New("recorded at is required")
+		}
+		if err := ValidatorRecordedAt(item.CreatedAt, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidatorTrackImportItem applies the multi item rules to an imported point, except the past
+// tolerance since imports bring in history recorded long before it reaches the server
+func ValidatorTrackImportItem(item entities.RequestCreateTrackMultiItem) error {
+	recordedAt := item.RecordedAt
+	item.RecordedAt = nil
+	if err := ValidatorTrack(item.RequestCreateTrack); err != nil {
+		return err
+	}
+	if recordedAt == nil {
+		return errors.New("recorded at is required")
+	}
+	if recordedAt.After(time.Now().Add(configs.TrackFutureTolerance)) {
+		return errors.New("recorded at is too far in the future")
+	}
+
+	return nil
+}
+
+func ValidatorRecordedAt(recordedAt time.Time, receivedAt time.Time) error {
+	if recordedAt.After(receivedAt.Add(configs.TrackFutureTolerance)) {
+		return errors.New("recorded at is too far in the future")
+	}
+	if recordedAt.Before(receivedAt.Add(-configs.TrackPastTolerance)) {
+		return errors.New("recorded at is too far in the past")
+	}
+
+	return nil
+}
+
+func ValidatorGeofence(req entities.RequestUpdateGeofence) error {
+	// Validator Field
+	if req.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(req.Name) > 100 {
+		return errors.New("name must not be longer than 100 characters")
+	}
+	if !ValidatorContains(configs.GeofenceTypes, req.FenceType) {
+		return errors.New("fence type must be circle or polygon")
+	}
+
+	switch req.FenceType {
+	case "circle":
+		// Validator : Circle
+		if req.CenterLat == nil || req.CenterLong == nil {
+			return errors.New("center latitude and longitude are required")
+		}
+		if *req.CenterLat < -90 || *req.CenterLat > 90 {
+			return errors.New("center latitude must be between -90 and 90")
+		}
+		if *req.CenterLong < -180 || *req.CenterLong > 180 {
+			return errors.New("center longitude must be between -180 and 180")
+		}
+		if req.Radius < 1 || req.Radius > SpatialMaxRadius {
+			return errors.New("radius must be a number between 1 and " + strconv.Itoa(SpatialMaxRadius))
+		}
+	case "polygon":
+		// Validator : Polygon
+		if len(req.Polygon) < 3 || len(req.Polygon) > GeofenceMaxPolygonPoints {
+			return errors.New("polygon must have between 3 and " + strconv.Itoa(GeofenceMaxPolygonPoints) + " points")
+		}
+		for _, point := range req.Polygon {
+			if point.Lat < -90 || point.Lat > 90 {
+				return errors.New("polygon latitude must be between -90 and 90")
+			}
+			if point.Long < -180 || point.Long > 180 {
+				return errors.New("polygon longitude must be between -180 and 180")
+			}
+		}
+	}
+
+	return nil
+}
+
+func ValidatorCreateGeofence(req entities.RequestCreateGeofence) error {
+	if req.AppsSource == "" {
+		return errors.New("app source is required")
+	}
+	if !ValidatorContains(configs.AppsSources, req.AppsSource) {
+		return errors.New("app source is not valid")
+	}
+	if req.CreatedBy == uuid.Nil {
+		return errors.New("created by is required and must be a valid UUID")
+	}
+
+	return ValidatorGeofence(req.RequestUpdateGeofence)
+}
+
+func ValidatorCreateShare(req entities.RequestCreateShare) error {
+	if req.AppsSource == "" {
+		return errors.New("app source is required")
+	}
+	if !ValidatorContains(configs.AppsSources, req.AppsSource) {
+		return errors.New("app source is not valid")
+	}
+	if req.CreatedBy == uuid.Nil {
+		return errors.New("created by is required and must be a valid UUID")
+	}
+
+	// Validator : Expiry
+	if req.ExpiresIn != "" {
+		expiresIn, err := time.ParseDuration(req.ExpiresIn)
+		if err != nil || expiresIn < time.Minute || expiresIn > configs.ShareMaxExpiry {
+			return errors.New("expires in must be a duration between 1m and " + strings.TrimSuffix(configs.ShareMaxExpiry.String(), "0m0s"))
+		}
+	}
+
+	// Validator : Time Window
+	if req.From != nil && req.To != nil && req.From.After(*req.To) {
+		return errors.New("from must be before to")
+	}
+
+	return nil
+}